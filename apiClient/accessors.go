@@ -0,0 +1,46 @@
+package apiClient
+
+import "fmt"
+
+// accessors for the loosely typed values returned by the API
+// these return the zero value if a property is missing or has an unexpected type, rather than panicking
+
+// get a string property from the resource data
+func (resource *Resource) GetString(key string) string {
+	return getString(resource.Data, key)
+}
+
+// get an object property from the resource data
+func (resource *Resource) GetMap(key string) map[string]interface{} {
+	return getMap(resource.Data[key])
+}
+
+func getString(data map[string]interface{}, key string) string {
+	if value, ok := data[key].(string); ok {
+		return value
+	}
+	return ""
+}
+
+func getMap(value interface{}) map[string]interface{} {
+	if m, ok := value.(map[string]interface{}); ok {
+		return m
+	}
+	return nil
+}
+
+func getSlice(value interface{}) []interface{} {
+	if s, ok := value.([]interface{}); ok {
+		return s
+	}
+	return nil
+}
+
+// the response for a single resource must be an object
+func getResponseObject(responseData interface{}) (map[string]interface{}, error) {
+	object := getMap(responseData)
+	if object == nil {
+		return nil, fmt.Errorf("unexpected response from the Turbot API, expected an object but got: %v", responseData)
+	}
+	return object, nil
+}
@@ -0,0 +1,127 @@
+package apiClient
+
+import (
+	"fmt"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+	"sync"
+)
+
+// the maximum number of resources read in a single query
+const maxBatchSize = 25
+
+// the maximum number of batch queries sent at the same time
+const maxBatchWorkers = 4
+
+// resourceBatcher coalesces concurrent ReadResource calls into a single graphql query, aliasing each resource
+// read. Terraform reads resources in parallel during plan and refresh, so this greatly reduces the number of API calls
+// a read is sent immediately if a worker is free - reads made while all workers are busy are queued, and each worker
+// sends everything queued (up to maxBatchSize) as a single query when its previous query completes
+type resourceBatcher struct {
+	client  *Client
+	lock    sync.Mutex
+	pending []*batchRequest
+	// the number of running workers
+	workers int
+}
+
+type batchRequest struct {
+	aka        string
+	properties map[string]string
+	result     chan batchResult
+}
+
+type batchResult struct {
+	resource *Resource
+	err      error
+}
+
+func newResourceBatcher(client *Client) *resourceBatcher {
+	return &resourceBatcher{client: client}
+}
+
+// add the read to the pending queue and wait for the result
+func (b *resourceBatcher) readResource(resourceAka string, properties map[string]string) (*Resource, error) {
+	request := &batchRequest{
+		aka:        resourceAka,
+		properties: properties,
+		result:     make(chan batchResult, 1),
+	}
+
+	b.lock.Lock()
+	b.pending = append(b.pending, request)
+	if b.workers < maxBatchWorkers {
+		b.workers++
+		go b.run()
+	}
+	b.lock.Unlock()
+
+	result := <-request.result
+	return result.resource, result.err
+}
+
+// send the pending requests in batches until there are none left
+func (b *resourceBatcher) run() {
+	for {
+		requests := b.next()
+		if requests == nil {
+			return
+		}
+		b.execute(requests)
+	}
+}
+
+// remove the next batch from the pending queue, or return nil and stop the worker if the queue is empty
+func (b *resourceBatcher) next() []*batchRequest {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if len(b.pending) == 0 {
+		b.workers--
+		return nil
+	}
+	count := len(b.pending)
+	if count > maxBatchSize {
+		count = maxBatchSize
+	}
+	requests := b.pending[:count]
+	b.pending = b.pending[count:]
+	return requests
+}
+
+func (b *resourceBatcher) execute(requests []*batchRequest) {
+	// a single read does not need to be batched
+	if len(requests) == 1 {
+		b.executeSingle(requests[0])
+		return
+	}
+
+	query := batchReadResourceQuery(requests)
+	var responseData = map[string]interface{}{}
+	err := b.client.doRequest(query, nil, &responseData)
+	if err != nil {
+		// an error for any resource (e.g. a resource not found) fails the whole query, but the other resources are still returned
+		b.client.Logf(helpers.LogTransport, "[DEBUG] batch read of %d resources returned an error: %s", len(requests), err.Error())
+	} else {
+		b.client.Logf(helpers.LogTransport, "[DEBUG] read %d resources in a single batch", len(requests))
+	}
+
+	for i, request := range requests {
+		data := responseData[batchAlias(i)]
+		if data == nil {
+			// this resource failed - read it separately to return the correct error for it
+			b.executeSingle(request)
+			continue
+		}
+		resource, err := b.client.AssignResourceResults(data, request.properties)
+		request.result <- batchResult{resource, err}
+	}
+}
+
+func (b *resourceBatcher) executeSingle(request *batchRequest) {
+	resource, err := b.client.readResource(request.aka, request.properties)
+	request.result <- batchResult{resource, err}
+}
+
+// the alias of the i'th resource in the batch query
+func batchAlias(i int) string {
+	return fmt.Sprintf("resource%d", i)
+}
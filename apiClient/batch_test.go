@@ -0,0 +1,87 @@
+package apiClient
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResourceBatcher_GetResourceAkas(t *testing.T) {
+	client, server := newFixtureClient(t, "read_resource_batch")
+	defer server.Close()
+	batcher := newResourceBatcher(client)
+	client.batcher = batcher
+
+	// while all workers are busy, reads are queued
+	batcher.workers = maxBatchWorkers
+	type akasResult struct {
+		akas []string
+		err  error
+	}
+	var results []chan akasResult
+	for i, aka := range []string{"tmod:@turbot/turbot#/", "190233581346752"} {
+		result := make(chan akasResult, 1)
+		results = append(results, result)
+		go func(aka string) {
+			akas, err := client.GetResourceAkas(aka)
+			result <- akasResult{akas, err}
+		}(aka)
+		waitForPending(t, batcher, i+1)
+	}
+
+	// a worker sends the queued reads as a single query
+	batcher.run()
+
+	root := <-results[0]
+	assert.NoError(t, root.err)
+	assert.Equal(t, []string{"tmod:@turbot/turbot#/"}, root.akas)
+	folder := <-results[1]
+	assert.NoError(t, folder.err)
+	assert.Equal(t, []string{"tmod:@turbot/turbot#/folder/190233581346752"}, folder.akas)
+	assert.Len(t, server.requests, 1)
+	assert.Equal(t, maxBatchWorkers-1, batcher.workers)
+}
+
+func TestResourceBatcher_PartialError(t *testing.T) {
+	client, server := newFixtureClient(t, "read_resource_batch_partial_error")
+	defer server.Close()
+	batcher := newResourceBatcher(client)
+
+	var requests []*batchRequest
+	for _, aka := range []string{"162167737977850", "190233581346753", "190233581346752"} {
+		requests = append(requests, &batchRequest{
+			aka:        aka,
+			properties: map[string]string{"title": "title"},
+			result:     make(chan batchResult, 1),
+		})
+	}
+	batcher.execute(requests)
+
+	// the resources which were returned are not read again
+	result := <-requests[0].result
+	assert.NoError(t, result.err)
+	assert.Equal(t, "Turbot", result.resource.Data["title"])
+	result = <-requests[2].result
+	assert.NoError(t, result.err)
+	assert.Equal(t, "provider_test", result.resource.Data["title"])
+
+	// only the failed resource is read separately, to return its own error
+	result = <-requests[1].result
+	assert.True(t, NotFoundError(result.err))
+	assert.Len(t, server.requests, 2)
+	assert.False(t, strings.Contains(server.requests[1].Query, "162167737977850"))
+}
+
+// wait until the batcher has queued the given number of reads
+func waitForPending(t *testing.T, batcher *resourceBatcher, count int) {
+	for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(time.Millisecond) {
+		batcher.lock.Lock()
+		pending := len(batcher.pending)
+		batcher.lock.Unlock()
+		if pending == count {
+			return
+		}
+	}
+	t.Fatalf("timed out waiting for %d pending reads", count)
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/mitchellh/go-homedir"
 	"github.com/terraform-providers/terraform-provider-turbot/helpers"
 	"io/ioutil"
-	"log"
 	"net/url"
 	"os"
 	"path"
@@ -18,13 +17,31 @@ import (
 	"regexp"
 	"runtime"
 	"strings"
+	"time"
 )
 
 // Turbot API Client
+// the client is safe for concurrent use by multiple goroutines - its configuration must not be changed once created
 type Client struct {
-	AccessKey string
-	SecretKey string
+	AccessKey           string
+	SecretKey           string
+	RegistryCredentials RegistryCredentials
+	ReadOnly            bool
+	ChangeReference     string
+	DefaultTags         map[string]interface{}
+	DefaultParent       string
+	// sent with every request, so workspace admins can attribute API traffic to the provider and run
+	UserAgent     string
+	CorrelationId string
+	ExtraHeaders  map[string]string
+	// if set, the query, variables and response of every request are logged (at debug level)
+	GraphqlLogging bool
+	// the minimum level of the messages logged by each subsystem
+	LogLevels helpers.LogLevels
 	Graphql   *graphql.Client
+	batcher   *resourceBatcher
+	// set by ForOperation - the retries of the requests of the operation
+	retries *operationRetries
 }
 
 func CreateClient(config ClientConfig) (*Client, error) {
@@ -35,34 +52,74 @@ func CreateClient(config ClientConfig) (*Client, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to get credentials, error: %s", err.Error())
 	}
-	return &Client{
-		AccessKey: credentials.AccessKey,
-		SecretKey: credentials.SecretKey,
-		Graphql:   graphql.NewClient(credentials.Workspace),
-	}, nil
+	if err := validateExtraHeaders(config.ExtraHeaders); err != nil {
+		return nil, fmt.Errorf("invalid extra_headers: %s", err.Error())
+	}
+	retryPolicy := DefaultRetryPolicy()
+	if config.RetryPolicy != nil {
+		retryPolicy = *config.RetryPolicy
+	}
+	client := &Client{
+		AccessKey:           credentials.AccessKey,
+		SecretKey:           credentials.SecretKey,
+		RegistryCredentials: GetRegistryCredentials(config),
+		ReadOnly:            config.ReadOnly,
+		ChangeReference:     config.ChangeReference,
+		UserAgent:           config.UserAgent,
+		CorrelationId:       config.CorrelationId,
+		ExtraHeaders:        config.ExtraHeaders,
+		DefaultTags:         config.DefaultTags,
+		DefaultParent:       config.DefaultParent,
+		GraphqlLogging:      config.GraphqlLogging,
+		LogLevels:           config.LogLevels,
+		Graphql:             graphql.NewClient(credentials.Workspace, graphql.WithHTTPClient(newHttpClient(retryPolicy, newRateLimiter(config.MaxRequestsPerSecond), config.LogLevels))),
+	}
+	client.batcher = newResourceBatcher(client)
+	return client, nil
 }
 
-func GetCredentials(config ClientConfig) (ClientCredentials, error) {
-	credentials := config.Credentials
-	if len(credentials.AccessKey) == 0 {
-		credentials.AccessKey = os.Getenv("TURBOT_ACCESS_KEY")
+// mod registry credentials are optional - they are only needed to install private mods
+func GetRegistryCredentials(config ClientConfig) RegistryCredentials {
+	registryCredentials := config.RegistryCredentials
+	if len(registryCredentials.AccessKey) == 0 {
+		registryCredentials.AccessKey = os.Getenv("TURBOT_REGISTRY_ACCESS_KEY")
 	}
-	if len(credentials.SecretKey) == 0 {
-		credentials.SecretKey = os.Getenv("TURBOT_SECRET_KEY")
+	if len(registryCredentials.SecretKey) == 0 {
+		registryCredentials.SecretKey = os.Getenv("TURBOT_REGISTRY_SECRET_KEY")
 	}
-	if len(credentials.Workspace) == 0 {
-		credentials.Workspace = os.Getenv("TURBOT_WORKSPACE")
+	return registryCredentials
+}
+
+// GetCredentials resolves the credentials using the chain:
+// explicit provider arguments > environment variables > credentials file profile > credential process
+// each credential is resolved separately, so (for example) the workspace may be set in the config
+// while the keys are read from a profile
+func GetCredentials(config ClientConfig) (ClientCredentials, error) {
+	credentials := config.Credentials
+	sources := credentialSources{}
+	sources.record(credentials, "provider configuration")
+
+	credentials = mergeCredentials(credentials, ClientCredentials{
+		AccessKey: getEnv("TURBOT_ACCESS_KEY", "TURBOT_ACCESS_KEY_ID"),
+		SecretKey: getEnv("TURBOT_SECRET_KEY", "TURBOT_SECRET_ACCESS_KEY"),
+		Workspace: os.Getenv("TURBOT_WORKSPACE"),
+	})
+	sources.record(credentials, "environment variables")
+
+	credentialProcess := config.CredentialProcess
+	if len(credentialProcess) == 0 {
+		credentialProcess = os.Getenv("TURBOT_CREDENTIAL_PROCESS")
 	}
 
 	if !CredentialsSet(credentials) {
-		// if credentials were not passed in, get from the credentials file
+		// if credentials were not passed in, get the missing values from the credentials file
 		var err error
 		credentialsPath := config.CredentialsPath
 		if len(credentialsPath) == 0 {
 			credentialsPath = os.Getenv("TURBOT_SHARED_CREDENTIALS_FILE")
 		}
 
-		// if no credentials path was specified, use ~/.turbot/credentials
+		// if no credentials path was specified, use ~/.config/turbot/credentials.yml
 		if len(credentialsPath) == 0 {
 			credentialsPath = filepath.Join(userHomeDir(), ".config", "turbot", "credentials.yml")
 		} else {
@@ -75,25 +132,115 @@ func GetCredentials(config ClientConfig) (ClientCredentials, error) {
 		if len(config.Profile) == 0 {
 			config.Profile = os.Getenv("TURBOT_PROFILE")
 		}
-		credentials, err = loadProfile(credentialsPath, config.Profile)
+		profile, err := loadProfile(credentialsPath, config.Profile)
+		switch {
+		case err == nil:
+			credentials = mergeCredentials(credentials, profile.ClientCredentials)
+			sources.record(credentials, fmt.Sprintf("profile %s of credentials file %s", profileName(config.Profile), credentialsPath))
+			if len(credentialProcess) == 0 {
+				credentialProcess = profile.CredentialProcess
+			}
+		case os.IsNotExist(err) && len(credentialProcess) > 0:
+			// the credentials file is optional if a credential process is set
+			config.LogLevels.Logf(helpers.LogTransport, "[DEBUG] credentials file %s not found, using credential_process", credentialsPath)
+		default:
+			return ClientCredentials{}, err
+		}
+		if !CredentialsSet(credentials) && len(credentialProcess) == 0 {
+			return ClientCredentials{}, fmt.Errorf("failed to load all credentials for profile %s from credentials file %s", profileName(config.Profile), credentialsPath)
+		}
+	}
+
+	if !CredentialsSet(credentials) {
+		processCredentials, err := runCredentialProcess(credentialProcess)
 		if err != nil {
 			return ClientCredentials{}, err
 		}
+		credentials = mergeCredentials(credentials, processCredentials)
+		sources.record(credentials, "credential_process")
 		if !CredentialsSet(credentials) {
-			return ClientCredentials{}, errors.New("failed to get credentials")
+			return ClientCredentials{}, fmt.Errorf("failed to load all credentials - %s not set by credential_process '%s'", strings.Join(sources.missing(), ", "), credentialProcess)
 		}
 	}
+	config.LogLevels.Logf(helpers.LogTransport, "[DEBUG] Turbot credentials resolved - access key: %s, secret key: %s, workspace: %s", sources.accessKey, sources.secretKey, sources.workspace)
+
 	var err error
 	// update workspace url
-	credentials.Workspace, err = BuildApiUrl(credentials.Workspace)
+	credentials.Workspace, err = BuildApiUrl(credentials.Workspace, config.ApiVersion, config.EndpointPath)
 	if err != nil {
 		return ClientCredentials{}, err
 	}
 	return credentials, nil
 }
 
+// the source in the credentials chain each credential was resolved from, for debug logging
+type credentialSources struct {
+	accessKey string
+	secretKey string
+	workspace string
+}
+
+// record the source of any credentials which have been set since the last source
+func (s *credentialSources) record(credentials ClientCredentials, source string) {
+	if len(s.accessKey) == 0 && len(credentials.AccessKey) != 0 {
+		s.accessKey = source
+	}
+	if len(s.secretKey) == 0 && len(credentials.SecretKey) != 0 {
+		s.secretKey = source
+	}
+	if len(s.workspace) == 0 && len(credentials.Workspace) != 0 {
+		s.workspace = source
+	}
+}
+
+// the names of the credentials which have not been resolved
+func (s *credentialSources) missing() []string {
+	var missing []string
+	if len(s.accessKey) == 0 {
+		missing = append(missing, "accessKey")
+	}
+	if len(s.secretKey) == 0 {
+		missing = append(missing, "secretKey")
+	}
+	if len(s.workspace) == 0 {
+		missing = append(missing, "workspace")
+	}
+	return missing
+}
+
+// return the value of the first of the environment variables which is set
+func getEnv(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); len(value) != 0 {
+			return value
+		}
+	}
+	return ""
+}
+
+// fill any credentials which have not been set from the fallback credentials
+func mergeCredentials(credentials, fallback ClientCredentials) ClientCredentials {
+	if len(credentials.AccessKey) == 0 {
+		credentials.AccessKey = fallback.AccessKey
+	}
+	if len(credentials.SecretKey) == 0 {
+		credentials.SecretKey = fallback.SecretKey
+	}
+	if len(credentials.Workspace) == 0 {
+		credentials.Workspace = fallback.Workspace
+	}
+	return credentials
+}
+
+var (
+	apiVersionRegex    = regexp.MustCompile(`^(latest|v[0-9]+)$`)
+	workspacePathRegex = regexp.MustCompile(`\/api\/v[0-9]+$|latest$`)
+)
+
 // convert workspace into a fully formed api url
-func BuildApiUrl(rawWorkspace string) (string, error) {
+// if apiVersion is set (e.g. "v5"), the api is pinned to that version, so an upgrade of the workspace cannot change the
+// behaviour of the queries. if endpointPath is set it replaces the path of the workspace, e.g. for a proxy
+func BuildApiUrl(rawWorkspace, apiVersion, endpointPath string) (string, error) {
 
 	// acceptable forms of workspace are:
 	// bananaman-turbot.putney
@@ -101,30 +248,68 @@ func BuildApiUrl(rawWorkspace string) (string, error) {
 	// bananaman-turbot.putney.turbot.io/
 	// bananaman-turbot.putney.turbot.io/api/v5
 	// bananaman-turbot.putney.turbot.io/api/v5/
+	// bananaman-turbot.putney.turbot.io/api/latest/graphql
 	// https://bananaman-turbot.putney.turbot.io
 	// https://bananaman-turbot.putney.turbot.io/api/v5
+	// http://localhost:8080 (the scheme is only added if it is missing)
+
+	if apiVersion != "" && endpointPath != "" {
+		return "", errors.New("failed to create client - only one of api_version and endpoint_path may be set")
+	}
+	if apiVersion != "" && !apiVersionRegex.MatchString(apiVersion) {
+		return "", fmt.Errorf("failed to create client - invalid api_version '%s': must be 'latest' or a version such as 'v5'", apiVersion)
+	}
+	if endpointPath != "" && !strings.HasPrefix(endpointPath, "/") {
+		return "", fmt.Errorf("failed to create client - invalid endpoint_path '%s': must be an absolute path such as '/api/v5/graphql'", endpointPath)
+	}
 
-	workspace := strings.TrimSuffix(rawWorkspace, "/")
+	workspace := strings.TrimSpace(rawWorkspace)
+	if workspace == "" {
+		return "", errors.New("failed to create client - workspace is not set. Set the workspace argument, the TURBOT_WORKSPACE environment variable or the workspace of the credentials profile")
+	}
+	workspace = strings.TrimSuffix(workspace, "/")
 
-	// check for "https://"' prefix
-	if !strings.HasPrefix(workspace, "https://") {
+	// default the scheme to "https://"
+	if !strings.Contains(workspace, "://") {
 		workspace = "https://" + workspace
 	}
 	u, err := url.Parse(workspace)
 	if err != nil {
 		return "", fmt.Errorf("failed to create client - could not parse workspace url %s, error %s", rawWorkspace, err.Error())
 	}
-	if u.Path == "invalid" {
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return "", fmt.Errorf("failed to create client - workspace url '%s' has unsupported scheme '%s', expected https", rawWorkspace, u.Scheme)
+	}
+	if u.Host == "" || u.Path == "invalid" {
 		return "", fmt.Errorf("failed to create client - could not parse workspace url '%s'", rawWorkspace)
 	}
 
-	if u.Path != "" {
-		apiVersionRegex := regexp.MustCompile(`\/api\/v[0-9]+$|latest$`)
-		if !apiVersionRegex.Match([]byte(u.Path)) {
-			return "", fmt.Errorf("invalid worksapce %s", workspace)
+	workspaceVersion := "latest"
+	if u.Path != "" && endpointPath == "" {
+		// the workspace may already be the graphql endpoint
+		u.Path = strings.TrimSuffix(u.Path, "/graphql")
+		if !workspacePathRegex.MatchString(u.Path) {
+			return "", fmt.Errorf("failed to create client - invalid workspace url '%s': the path must be an api version, e.g. https://example.turbot.com/api/v5 or https://example.turbot.com/api/latest", rawWorkspace)
+		}
+		workspaceVersion = path.Base(u.Path)
+	}
+
+	switch {
+	case endpointPath != "":
+		u.Path = endpointPath
+	case apiVersion != "":
+		// a version in the workspace url must not contradict the pinned version
+		if workspaceVersion != "latest" && workspaceVersion != apiVersion && apiVersionRegex.MatchString(workspaceVersion) {
+			return "", fmt.Errorf("failed to create client - workspace url '%s' is for api version '%s', which conflicts with api_version '%s'", rawWorkspace, workspaceVersion, apiVersion)
+		}
+		apiPath := "/api"
+		if u.Path != "" {
+			apiPath = path.Dir(u.Path)
 		}
+		u.Path = path.Join(apiPath, apiVersion, "graphql")
+	case u.Path != "":
 		u.Path = path.Join(u.Path, "graphql")
-	} else {
+	default:
 		u.Path = "/api/latest/graphql"
 	}
 
@@ -140,6 +325,10 @@ func CredentialsSet(credentials ClientCredentials) bool {
 func (client *Client) Validate() error {
 	query, responseObject := validationQuery()
 	err := client.doRequest(query, nil, &responseObject)
+	// the credentials were rejected, rather than the request failing
+	if PermissionDeniedError(err) {
+		return fmt.Errorf("authorisation failed. Verify workspace, access_key and secret_access_key have been set correctly: %w", err)
+	}
 	if err == nil && !responseObject.isValid() {
 		err = errors.New("authorisation failed. Verify workspace, access_key and secret_access_key have been set correctly")
 	}
@@ -156,27 +345,31 @@ func userHomeDir() string {
 	return os.Getenv("HOME")
 }
 
-func loadProfile(credentialsPath, profile string) (ClientCredentials, error) {
-	// if no profile specified, use default
+// if no profile specified, use default
+func profileName(profile string) string {
 	if len(profile) == 0 {
-		profile = "default"
+		return "default"
 	}
+	return profile
+}
+
+func loadProfile(credentialsPath, profile string) (credentialsProfile, error) {
+	profile = profileName(profile)
 	yamlFile, err := ioutil.ReadFile(credentialsPath)
 	if err != nil {
-		return ClientCredentials{}, err
+		return credentialsProfile{}, err
 	}
 
-	var credentialsMap = map[string]ClientCredentials{}
+	var credentialsMap = map[string]credentialsProfile{}
 
 	err = yaml.Unmarshal(yamlFile, &credentialsMap)
 	if err != nil {
-		log.Fatalf("Unmarshal: %v", err)
+		return credentialsProfile{}, fmt.Errorf("failed to parse credentials file %s: %s", credentialsPath, err.Error())
 	}
-	credentials := credentialsMap[profile]
-	if !CredentialsSet(credentials) {
-		return ClientCredentials{}, fmt.Errorf("failed to load all credentials for profile %s from credentials file %s", profile, credentialsPath)
+	credentials, ok := credentialsMap[profile]
+	if !ok {
+		return credentialsProfile{}, fmt.Errorf("profile %s not found in credentials file %s", profile, credentialsPath)
 	}
-
 	return credentials, nil
 }
 
@@ -190,7 +383,7 @@ func (client *Client) BuildPropertiesFromUpdateSchema(resourceId string, propert
 	responseData := &ResourceResponse{}
 	// execute api call
 	if err := client.doRequest(getResourceQuery, nil, &responseData); err != nil {
-		return nil, fmt.Errorf("error reading resource type id: %s", err.Error())
+		return nil, fmt.Errorf("error reading resource type id: %w", err)
 	}
 
 	resourceTypeId := responseData.Resource.Turbot.ResourceTypeId
@@ -199,18 +392,18 @@ func (client *Client) BuildPropertiesFromUpdateSchema(resourceId string, propert
 	response := &ResourceSchema{}
 	// execute api call
 	if err := client.doRequest(query, nil, &response); err != nil {
-		return nil, fmt.Errorf("error reading resource type id: %s", err.Error())
+		return nil, fmt.Errorf("error reading resource type id: %w", err)
 	}
 
 	if response.Resource.UpdateSchema == nil {
 		return nil, nil
 	}
 
-	var m = response.Resource.UpdateSchema.(map[string]interface{})
+	var m = getMap(response.Resource.UpdateSchema)
 	var excluded []interface{}
 	if value, ok := m["allOf"]; ok {
-		for _, schema := range value.([]interface{}) {
-			if res, ok := schema.(map[string]interface{}); ok {
+		for _, schema := range getSlice(value) {
+			if res := getMap(schema); res != nil {
 				if res["type"] == "object" {
 					// loop to flatten interface, so we will not get this structure - [[id1,id2],[id3,id4]]
 					for _, element := range helpers.GetNullProperties(res) {
@@ -225,6 +418,16 @@ func (client *Client) BuildPropertiesFromUpdateSchema(resourceId string, propert
 
 // execute graphql request
 func (client *Client) doRequest(query string, vars map[string]interface{}, responseData interface{}) error {
+	return client.doWatchedRequest(nil, query, vars, responseData)
+}
+
+// execute graphql request as a read of the watch, if it is not nil (see Watch)
+func (client *Client) doWatchedRequest(w *Watch, query string, vars map[string]interface{}, responseData interface{}) error {
+	// in read only mode, never send a mutation to the API
+	if client.ReadOnly && isMutation(query) {
+		return errors.New("the provider is configured with read_only = true - create, update and delete operations are not allowed")
+	}
+
 	// make a request
 	req := graphql.NewRequest(query)
 
@@ -238,14 +441,55 @@ func (client *Client) doRequest(query string, vars map[string]interface{}, respo
 	// set header fields
 	req.Header.Set("Cache-Control", "no-cache")
 	req.Header.Set("Authorization", basicAuthHeader(client.AccessKey, client.SecretKey))
+	client.setClientHeaders(req.Header)
+	if w != nil {
+		w.setHeaders(req.Header)
+	}
+
+	logRequest := client.requestLoggingEnabled()
+	if logRequest {
+		client.logRequest(query, vars)
+	}
 
-	// define a Context for the request
-	ctx := context.Background()
+	// define a Context for the request, including a responseInfo for the transport to populate
+	info := &responseInfo{Mutation: isMutation(query), CaptureBody: logRequest && client.GraphqlLogging, Watch: w}
+	ctx := context.WithValue(context.Background(), responseInfoKey, info)
 
 	// run it and capture the response
-	if err := client.Graphql.Run(ctx, req, &responseData); err != nil {
-		err = BuildHttpErrorMessage(err)
-		return err
+	start := time.Now()
+	err := client.Graphql.Run(ctx, req, &responseData)
+	duration := time.Since(start).Round(time.Millisecond)
+	if logRequest && client.GraphqlLogging && info.Body != nil {
+		client.Logf(helpers.LogTransport, "[DEBUG] Turbot API response: %s", redactedJsonBytes(info.Body))
 	}
+	if err != nil {
+		apiErr := newAPIError(err, info)
+		client.Logf(helpers.LogTransport, "[DEBUG] Turbot API request failed in %s, request id: %s, status code: %d, error code: %s, kind: %s, error: %s", duration, info.RequestId, info.StatusCode, info.ErrorCode, apiErr.Kind, apiErr.Message)
+		// the next read of the watch must not wait for a change to a result which was never returned
+		if w != nil {
+			w.reset()
+		}
+		return apiErr
+	}
+	client.Logf(helpers.LogTransport, "[DEBUG] Turbot API request succeeded in %s, request id: %s", duration, info.RequestId)
+	client.retries.record(info.Retries)
 	return nil
 }
+
+// log the request - the whole query if graphql_logging is enabled, otherwise a summary
+// the values of sensitive variables are never logged
+func (client *Client) logRequest(query string, vars map[string]interface{}) {
+	variables := "none"
+	if len(vars) > 0 {
+		variables = redactedJson(vars)
+	}
+	if client.GraphqlLogging {
+		client.Logf(helpers.LogTransport, "[DEBUG] Turbot API request, variables: %s, query:\n%s", variables, query)
+		return
+	}
+	client.Logf(helpers.LogTransport, "[DEBUG] Turbot API request: %s, variables: %s", summariseQuery(query), variables)
+}
+
+func isMutation(query string) bool {
+	return strings.HasPrefix(strings.TrimSpace(query), "mutation")
+}
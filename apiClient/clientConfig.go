@@ -1,9 +1,40 @@
 package apiClient
 
+import "github.com/terraform-providers/terraform-provider-turbot/helpers"
+
 type ClientConfig struct {
 	Credentials     ClientCredentials
 	CredentialsPath string
 	Profile         string
+	// a command which prints credentials as JSON - the last source in the credentials chain
+	CredentialProcess   string
+	RegistryCredentials RegistryCredentials
+	// if set, all mutations are blocked
+	ReadOnly bool
+	// if not set, DefaultRetryPolicy is used
+	RetryPolicy *RetryPolicy
+	// the maximum rate of requests sent to the API - if zero, requests are not rate limited
+	MaxRequestsPerSecond float64
+	// if set, sent with every request so changes in the Turbot activity log can be traced to the change (e.g. a ticket or CI run)
+	ChangeReference string
+	// identifies the provider and Terraform versions, e.g. "Terraform/0.12.24 terraform-provider-turbot/1.7.0"
+	UserAgent string
+	// if set, sent with every request so the requests of a run can be grouped
+	CorrelationId string
+	// additional headers sent with every request, e.g. for a proxy which requires them
+	ExtraHeaders map[string]string
+	// tags which are merged into the tags of every resource the provider manages
+	DefaultTags map[string]interface{}
+	// the parent of resources which do not set a parent
+	DefaultParent string
+	// log the query, variables and response of every request - requires TF_LOG=DEBUG (or TRACE)
+	GraphqlLogging bool
+	// the minimum level of the messages logged by each subsystem
+	LogLevels helpers.LogLevels
+	// the api version (e.g. "v5") the client is pinned to - if not set, the version of the workspace url is used
+	ApiVersion string
+	// the path of the graphql endpoint, replacing the path of the workspace url
+	EndpointPath string
 }
 
 type ClientCredentials struct {
@@ -11,3 +42,16 @@ type ClientCredentials struct {
 	SecretKey string `yaml:"secretKey"`
 	Workspace string
 }
+
+// a profile of the credentials file
+type credentialsProfile struct {
+	ClientCredentials `yaml:",inline"`
+	// a command which prints credentials as JSON, used for any credentials not set in the profile
+	CredentialProcess string `yaml:"credentialProcess"`
+}
+
+// credentials passed to the mod registry when installing mods
+type RegistryCredentials struct {
+	AccessKey string
+	SecretKey string
+}
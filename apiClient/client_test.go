@@ -0,0 +1,189 @@
+package apiClient
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestDoRequest_Headers(t *testing.T) {
+	client, server := newFixtureClient(t, "read_control")
+	defer server.Close()
+	client.ChangeReference = "CHG0001234"
+	client.UserAgent = "Terraform/0.12.24 (+https://www.terraform.io) terraform-provider-turbot/1.7.0"
+	client.CorrelationId = "3f2b9c"
+	client.ExtraHeaders = map[string]string{"X-Proxy-Token": "abc123"}
+
+	_, err := client.ReadControl(`uri: "tmod:@turbot/turbot#/control/types/modInstalled", resourceId: "190233581346760"`)
+	assert.NoError(t, err)
+	header := server.requests[0].Header
+	assert.Equal(t, basicAuthHeader("test-access-key", "test-secret-key"), header.Get("Authorization"))
+	assert.Equal(t, "no-cache", header.Get("Cache-Control"))
+	assert.Equal(t, "CHG0001234", header.Get(changeReferenceHeader))
+	assert.Equal(t, "Terraform/0.12.24 (+https://www.terraform.io) terraform-provider-turbot/1.7.0", header.Get("User-Agent"))
+	assert.Equal(t, "3f2b9c", header.Get(correlationIdHeader))
+	assert.Equal(t, "abc123", header.Get("X-Proxy-Token"))
+}
+
+func TestValidateExtraHeaders(t *testing.T) {
+	assert.NoError(t, validateExtraHeaders(nil))
+	assert.NoError(t, validateExtraHeaders(map[string]string{"X-Proxy-Token": "abc123", "Proxy-Authorization": "Bearer abc123"}))
+	assert.EqualError(t, validateExtraHeaders(map[string]string{"X Proxy": "abc123"}), "invalid header name 'X Proxy'")
+	assert.EqualError(t, validateExtraHeaders(map[string]string{"authorization": "Basic abc123"}), "header 'authorization' is set by the provider and may not be overridden")
+	assert.EqualError(t, validateExtraHeaders(map[string]string{"X-Proxy-Token": "abc\r\n123"}), "the value of header 'X-Proxy-Token' must not contain line breaks")
+}
+
+func TestDoRequest_ReadOnly(t *testing.T) {
+	client, server := newFixtureClient(t, "create_resource")
+	client.ReadOnly = true
+
+	// mutations are rejected without sending a request
+	_, err := client.CreateResource(map[string]interface{}{})
+	assert.EqualError(t, err, "error creating resource: the provider is configured with read_only = true - create, update and delete operations are not allowed")
+	assert.Empty(t, server.requests)
+	server.Server.Close()
+}
+
+func TestValidate_Unauthorized(t *testing.T) {
+	client, server := newFixtureClient(t, "validate_unauthorized")
+	defer server.Close()
+
+	err := client.Validate()
+	assert.Error(t, err)
+	assert.True(t, PermissionDeniedError(err))
+	assert.Contains(t, err.Error(), "authorisation failed. Verify workspace, access_key and secret_access_key have been set correctly")
+}
+
+func TestBuildApiUrl(t *testing.T) {
+	type test struct {
+		name         string
+		workspace    string
+		apiVersion   string
+		endpointPath string
+		expected     string
+		err          bool
+	}
+	tests := []test{
+		test{"Workspace name", "example.cloud.turbot.com", "", "", "https://example.cloud.turbot.com/api/latest/graphql", false},
+		test{"Api version", "https://example.cloud.turbot.com/api/v5/", "", "", "https://example.cloud.turbot.com/api/v5/graphql", false},
+		test{"Graphql endpoint", "https://example.cloud.turbot.com/api/latest/graphql", "", "", "https://example.cloud.turbot.com/api/latest/graphql", false},
+		test{"Http", "http://localhost:8080", "", "", "http://localhost:8080/api/latest/graphql", false},
+		test{"Empty", " ", "", "", "", true},
+		test{"Unsupported scheme", "ftp://example.cloud.turbot.com", "", "", "", true},
+		test{"Invalid path", "https://example.cloud.turbot.com/console", "", "", "", true},
+		test{"Pinned version", "example.cloud.turbot.com", "v5", "", "https://example.cloud.turbot.com/api/v5/graphql", false},
+		test{"Pinned version replaces latest", "https://example.cloud.turbot.com/api/latest", "v5", "", "https://example.cloud.turbot.com/api/v5/graphql", false},
+		test{"Pinned version matches workspace", "https://example.cloud.turbot.com/api/v5/graphql", "v5", "", "https://example.cloud.turbot.com/api/v5/graphql", false},
+		test{"Pinned version conflicts with workspace", "https://example.cloud.turbot.com/api/v4", "v5", "", "", true},
+		test{"Invalid pinned version", "example.cloud.turbot.com", "5", "", "", true},
+		test{"Endpoint path", "https://example.cloud.turbot.com/api/latest", "", "/turbot/api/v5/graphql", "https://example.cloud.turbot.com/turbot/api/v5/graphql", false},
+		test{"Relative endpoint path", "example.cloud.turbot.com", "", "api/v5/graphql", "", true},
+		test{"Version and endpoint path", "example.cloud.turbot.com", "v5", "/api/v5/graphql", "", true},
+	}
+	for _, test := range tests {
+		url, err := BuildApiUrl(test.workspace, test.apiVersion, test.endpointPath)
+		assert.Equal(t, test.err, err != nil, test.name)
+		assert.Equal(t, test.expected, url, test.name)
+	}
+}
+
+// clear the credential environment variables for the duration of a test, setting the given values
+func setCredentialsEnv(t *testing.T, values map[string]string) func() {
+	names := []string{"TURBOT_ACCESS_KEY", "TURBOT_ACCESS_KEY_ID", "TURBOT_SECRET_KEY", "TURBOT_SECRET_ACCESS_KEY", "TURBOT_WORKSPACE", "TURBOT_PROFILE", "TURBOT_SHARED_CREDENTIALS_FILE", "TURBOT_CREDENTIAL_PROCESS", "HOME"}
+	original := map[string]string{}
+	for _, name := range names {
+		original[name] = os.Getenv(name)
+		os.Unsetenv(name)
+	}
+	// use an empty home directory, so the default credentials file does not exist
+	home, err := ioutil.TempDir("", "turbot-home")
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv("HOME", home)
+	for name, value := range values {
+		os.Setenv(name, value)
+	}
+	return func() {
+		for _, name := range names {
+			os.Setenv(name, original[name])
+		}
+		os.RemoveAll(home)
+	}
+}
+
+func writeCredentialsFile(t *testing.T, content string) string {
+	file, err := ioutil.TempFile("", "credentials*.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if _, err := file.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+	return file.Name()
+}
+
+func TestGetCredentials_Chain(t *testing.T) {
+	credentialsPath := writeCredentialsFile(t, `
+default:
+  accessKey: profile-access-key
+  secretKey: profile-secret-key
+  workspace: profile.turbot.com
+`)
+	defer os.Remove(credentialsPath)
+	defer setCredentialsEnv(t, map[string]string{
+		"TURBOT_SECRET_KEY":              "env-secret-key",
+		"TURBOT_SHARED_CREDENTIALS_FILE": credentialsPath,
+	})()
+
+	// each value is resolved from the first source which sets it
+	credentials, err := GetCredentials(ClientConfig{Credentials: ClientCredentials{AccessKey: "config-access-key"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "config-access-key", credentials.AccessKey)
+	assert.Equal(t, "env-secret-key", credentials.SecretKey)
+	assert.Equal(t, "https://profile.turbot.com/api/latest/graphql", credentials.Workspace)
+}
+
+func TestGetCredentials_CredentialProcess(t *testing.T) {
+	credentialsPath := writeCredentialsFile(t, `
+default:
+  workspace: profile.turbot.com
+  credentialProcess: >-
+    echo '{"accessKey": "process-access-key", "secretKey": "process-secret-key", "workspace": "process.turbot.com"}'
+`)
+	defer os.Remove(credentialsPath)
+	defer setCredentialsEnv(t, map[string]string{"TURBOT_SHARED_CREDENTIALS_FILE": credentialsPath})()
+
+	// the credential process of the profile is used for the values not set in the profile
+	credentials, err := GetCredentials(ClientConfig{})
+	assert.NoError(t, err)
+	assert.Equal(t, "process-access-key", credentials.AccessKey)
+	assert.Equal(t, "process-secret-key", credentials.SecretKey)
+	assert.Equal(t, "https://profile.turbot.com/api/latest/graphql", credentials.Workspace)
+}
+
+func TestGetCredentials_CredentialProcessWithoutCredentialsFile(t *testing.T) {
+	defer setCredentialsEnv(t, map[string]string{"TURBOT_WORKSPACE": "env.turbot.com"})()
+
+	credentials, err := GetCredentials(ClientConfig{CredentialProcess: `echo '{"accessKey": "process-access-key", "secretKey": "process-secret-key"}'`})
+	assert.NoError(t, err)
+	assert.Equal(t, "process-access-key", credentials.AccessKey)
+	assert.Equal(t, "https://env.turbot.com/api/latest/graphql", credentials.Workspace)
+
+	_, err = GetCredentials(ClientConfig{CredentialProcess: `echo '{"accessKey": "process-access-key"}'`})
+	assert.EqualError(t, err, `failed to load all credentials - secretKey not set by credential_process 'echo '{"accessKey": "process-access-key"}''`)
+
+	_, err = GetCredentials(ClientConfig{CredentialProcess: "echo 'no credentials' >&2; exit 1"})
+	assert.EqualError(t, err, "credential_process 'echo 'no credentials' >&2; exit 1' failed: exit status 1: no credentials")
+}
+
+func TestGetCredentials_MissingCredentialsFile(t *testing.T) {
+	defer setCredentialsEnv(t, nil)()
+
+	// without a credential process, the credentials file is required
+	_, err := GetCredentials(ClientConfig{})
+	assert.Error(t, err)
+	assert.True(t, os.IsNotExist(err))
+}
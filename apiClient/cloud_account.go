@@ -0,0 +1,27 @@
+package apiClient
+
+import (
+	"fmt"
+)
+
+// read all cloud accounts of the resource type, fetching each page of results in turn
+func (client *Client) ReadCloudAccountList(resourceType, accountIdPath string) ([]CloudAccount, error) {
+	var accounts []CloudAccount
+	paging := ""
+	for {
+		query := readCloudAccountListQuery(resourceType, accountIdPath, paging)
+		responseData := &CloudAccountListResponse{}
+
+		// execute api call
+		if err := client.doRequest(query, nil, responseData); err != nil {
+			return nil, fmt.Errorf("error reading cloud account list: %w", err)
+		}
+		accounts = append(accounts, responseData.ResourceList.Items...)
+		// if there are no more pages, we are done
+		paging = responseData.ResourceList.Paging.Next
+		if paging == "" {
+			break
+		}
+	}
+	return accounts, nil
+}
@@ -0,0 +1,23 @@
+package apiClient
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestReadCloudAccountList_Paging(t *testing.T) {
+	client, server := newFixtureClient(t, "read_cloud_account_list_paging")
+	defer server.Close()
+
+	accounts, err := client.ReadCloudAccountList("tmod:@turbot/aws#/resource/types/account", "Id")
+	assert.NoError(t, err)
+	var accountIds, ids []string
+	for _, account := range accounts {
+		accountIds = append(accountIds, account.AccountId)
+		ids = append(ids, account.Turbot.Id)
+	}
+	assert.Equal(t, []string{"123456789012", "210987654321", "345678901234"}, accountIds)
+	assert.Equal(t, []string{"190233581346752", "190233581346753", "190233581346754"}, ids)
+	assert.Equal(t, "Sandbox", accounts[2].Title)
+	assert.Equal(t, "190233581346760", accounts[2].Parent)
+}
@@ -11,9 +11,47 @@ func (client *Client) ReadControl(args string) (*Control, error) {
 	// execute api call
 	err := client.doRequest(query, nil, responseData)
 	if err != nil {
-		return nil, fmt.Errorf("error reading control: %s", err.Error())
+		return nil, fmt.Errorf("error reading control: %w", err)
 	}
 	control := responseData.Control
 
 	return &control, nil
 }
+
+// return the number of controls matching the filter
+func (client *Client) ReadControlCount(filter string) (int, error) {
+	query := readControlCountQuery(filter)
+	var responseData = &ControlCountResponse{}
+
+	// execute api call
+	if err := client.doRequest(query, nil, responseData); err != nil {
+		return 0, fmt.Errorf("error reading control count: %w", err)
+	}
+	return responseData.ControlList.Metadata.Stats.Total, nil
+}
+
+// read the controls matching the filter, fetching each page of results in turn
+// if maxResults is greater than zero, stop once that many controls have been read
+func (client *Client) ReadControlList(filter string, maxResults int) ([]Control, error) {
+	var controls []Control
+	paging := ""
+	for {
+		query := readControlListQuery(filter, paging)
+		var responseData = &ControlListResponse{}
+
+		// execute api call
+		if err := client.doRequest(query, nil, responseData); err != nil {
+			return nil, fmt.Errorf("error reading control list: %w", err)
+		}
+		controls = append(controls, responseData.ControlList.Items...)
+		if maxResults > 0 && len(controls) >= maxResults {
+			return controls[:maxResults], nil
+		}
+		// if there are no more pages, we are done
+		paging = responseData.ControlList.Paging.Next
+		if paging == "" {
+			break
+		}
+	}
+	return controls, nil
+}
@@ -0,0 +1,74 @@
+package apiClient
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestReadControl(t *testing.T) {
+	client, server := newFixtureClient(t, "read_control")
+	defer server.Close()
+
+	control, err := client.ReadControl(`uri: "tmod:@turbot/turbot#/control/types/modInstalled", resourceId: "190233581346760"`)
+	assert.NoError(t, err)
+	assert.Equal(t, "error", control.State)
+	assert.Equal(t, "Peer dependency @turbot/aws-iam@>=5.0.0 is not installed", control.Reason)
+	assert.Equal(t, "tmod:@turbot/turbot#/control/types/modInstalled", control.Type.Uri)
+	assert.Equal(t, TurbotControlMetadata{
+		Id:              "190233581346770",
+		ResourceId:      "190233581346760",
+		UpdateTimestamp: "2020-03-01T12:00:00.000Z",
+	}, control.Turbot)
+}
+
+func TestReadControl_Throttled(t *testing.T) {
+	client, server := newFixtureClient(t, "read_control_throttled")
+	defer server.Close()
+
+	// the throttled request is retried
+	control, err := client.ReadControl(`id: "190233581346770"`)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", control.State)
+}
+
+func TestReadControl_ServerError(t *testing.T) {
+	client, server := newFixtureClient(t, "read_control_server_error")
+	defer server.Close()
+
+	_, err := client.ReadControl(`id: "190233581346770"`)
+	assert.Error(t, err)
+	assert.Equal(t, "error reading control: The server returned a Internal Server Error error (500). Please contact Turbot support. (request id: 5f3e1c0a-control)", err.Error())
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+	assert.Equal(t, "5f3e1c0a-control", apiErr.RequestId)
+	assert.Equal(t, ErrorKindOther, apiErr.Kind)
+}
+
+func TestReadControlList_Paging(t *testing.T) {
+	client, server := newFixtureClient(t, "read_control_list_paging")
+	defer server.Close()
+
+	controls, err := client.ReadControlList("state:alarm controlType:tmod:@turbot/aws-s3#/control/types/bucketVersioning", 0)
+	assert.NoError(t, err)
+	var reasons []string
+	for _, control := range controls {
+		reasons = append(reasons, control.Reason)
+	}
+	assert.Equal(t, []string{"Versioning is disabled", "Versioning is suspended"}, reasons)
+	assert.Equal(t, "190233581346791", controls[1].Turbot.ResourceId)
+	assert.Equal(t, "2020-03-03T12:00:00.000Z", controls[1].Turbot.UpdateTimestamp)
+}
+
+func TestReadControlList_MaxResults(t *testing.T) {
+	client, server := newFixtureClient(t, "read_control_list_paging")
+	// only the first page is read
+	server.interactions = server.interactions[:1]
+	defer server.Close()
+
+	controls, err := client.ReadControlList("state:alarm controlType:tmod:@turbot/aws-s3#/control/types/bucketVersioning", 1)
+	assert.NoError(t, err)
+	assert.Len(t, controls, 1)
+}
@@ -0,0 +1,49 @@
+package apiClient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// the JSON a credential process must write to stdout - any of the values may be omitted
+// if they are set by an earlier source in the credentials chain
+type credentialProcessOutput struct {
+	AccessKey string `json:"accessKey"`
+	SecretKey string `json:"secretKey"`
+	Workspace string `json:"workspace"`
+}
+
+// run an external command which prints credentials, e.g. to fetch the keys from a secrets manager
+// the command is run by the shell, so may include arguments and quoting
+func runCredentialProcess(command string) (ClientCredentials, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		// NOTE: do not include stdout in the error, as it may contain the secret key
+		message := err.Error()
+		if errorOutput := strings.TrimSpace(stderr.String()); errorOutput != "" {
+			message = fmt.Sprintf("%s: %s", message, errorOutput)
+		}
+		return ClientCredentials{}, fmt.Errorf("credential_process '%s' failed: %s", command, message)
+	}
+	var output credentialProcessOutput
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return ClientCredentials{}, fmt.Errorf("credential_process '%s' returned invalid output - expected a JSON object with accessKey, secretKey and workspace: %s", command, err.Error())
+	}
+	return ClientCredentials{
+		AccessKey: output.AccessKey,
+		SecretKey: output.SecretKey,
+		Workspace: output.Workspace,
+	}, nil
+}
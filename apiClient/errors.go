@@ -1,24 +1,144 @@
 package apiClient
 
 import (
+	"errors"
 	"fmt"
-	"github.com/pkg/errors"
 	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
 )
 
+// ErrorKind is the kind of failure an API error represents - callers use it to decide how to handle the error,
+// e.g. a resource which is not found has been deleted outside of terraform, but a permission error must fail
+type ErrorKind int
+
+const (
+	ErrorKindOther ErrorKind = iota
+	ErrorKindNotFound
+	ErrorKindPermissionDenied
+	ErrorKindValidation
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorKindNotFound:
+		return "NotFound"
+	case ErrorKindPermissionDenied:
+		return "PermissionDenied"
+	case ErrorKindValidation:
+		return "Validation"
+	}
+	return "Other"
+}
+
+// the kind of each graphql error code (the 'code' extension of the error)
+// codes are matched ignoring case and separators, so NOT_FOUND and notFound are equivalent
+var errorCodeKinds = map[string]ErrorKind{
+	"notfound":             ErrorKindNotFound,
+	"forbidden":            ErrorKindPermissionDenied,
+	"permissiondenied":     ErrorKindPermissionDenied,
+	"unauthorized":         ErrorKindPermissionDenied,
+	"unauthenticated":      ErrorKindPermissionDenied,
+	"baduserinput":         ErrorKindValidation,
+	"validationfailed":     ErrorKindValidation,
+	"datavalidationfailed": ErrorKindValidation,
+}
+
+// the kind of each http status - used if the error has no code
+var statusCodeKinds = map[int]ErrorKind{
+	http.StatusNotFound:            ErrorKindNotFound,
+	http.StatusUnauthorized:        ErrorKindPermissionDenied,
+	http.StatusForbidden:           ErrorKindPermissionDenied,
+	http.StatusUnprocessableEntity: ErrorKindValidation,
+}
+
+// the kind of an error with neither a code nor an error status, based on the message
+// permission errors are checked first, so a permission error is never treated as the resource not existing
+var errorMessageKinds = []struct {
+	pattern *regexp.Regexp
+	kind    ErrorKind
+}{
+	{regexp.MustCompile("(?i)permission denied|forbidden|not authorized"), ErrorKindPermissionDenied},
+	{regexp.MustCompile("(?i)not Found"), ErrorKindNotFound},
+	{regexp.MustCompile("(?i)data validation failed"), ErrorKindValidation},
+}
+
+// APIError is returned for a failed request to the Turbot API
+type APIError struct {
+	Kind ErrorKind
+	// the 'code' extension of the graphql error, if any
+	Code string
+	// the http status of the response, or 0 if no response was received
+	StatusCode int
+	// the id the API assigned to the request, used to find the request in the workspace logs
+	RequestId string
+	Message   string
+}
+
+func (e *APIError) Error() string {
+	// include the request id so the error can be correlated with the workspace logs
+	if e.RequestId != "" {
+		return fmt.Sprintf("%s (request id: %s)", e.Message, e.RequestId)
+	}
+	return e.Message
+}
+
+// build the error for a failed request from the graphql client error and the details of the response
+func newAPIError(err error, info *responseInfo) *APIError {
+	apiErr := &APIError{
+		Code:       info.ErrorCode,
+		StatusCode: info.StatusCode,
+		RequestId:  info.RequestId,
+		Message:    BuildHttpErrorMessage(err).Error(),
+	}
+	apiErr.Kind = classifyError(apiErr.Code, apiErr.StatusCode, apiErr.Message)
+	return apiErr
+}
+
+// the error code is the most specific, then the http status, then the message
+func classifyError(code string, statusCode int, message string) ErrorKind {
+	if kind, ok := errorCodeKinds[normaliseErrorCode(code)]; ok {
+		return kind
+	}
+	if kind, ok := statusCodeKinds[statusCode]; ok {
+		return kind
+	}
+	for _, m := range errorMessageKinds {
+		if m.pattern.MatchString(message) {
+			return m.kind
+		}
+	}
+	return ErrorKindOther
+}
+
+func normaliseErrorCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(code))
+}
+
+// GetErrorKind returns the kind of the error
+// errors which were not returned by the API, e.g. a lookup which finds no match, are classified by their message
+func GetErrorKind(err error) ErrorKind {
+	if err == nil {
+		return ErrorKindOther
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Kind
+	}
+	return classifyError("", 0, err.Error())
+}
+
 func NotFoundError(err error) bool {
-	notFoundErr := "(?i)not Found"
-	expectedErr := regexp.MustCompile(notFoundErr)
-	return expectedErr.Match([]byte(err.Error()))
+	return GetErrorKind(err) == ErrorKindNotFound
+}
+
+func PermissionDeniedError(err error) bool {
+	return GetErrorKind(err) == ErrorKindPermissionDenied
 }
 
 func FailedValidationError(err error) bool {
-	dataValidationError := "(?i)data validation failed"
-	expectedErr := regexp.MustCompile(dataValidationError)
-	return expectedErr.Match([]byte(err.Error()))
+	return GetErrorKind(err) == ErrorKindValidation
 }
 
 func BuildHttpErrorMessage(err error) error {
@@ -26,7 +146,12 @@ func BuildHttpErrorMessage(err error) error {
 	if NotFoundError(err) {
 		return err
 	}
-	errCodeString := strings.TrimSpace(strings.Split(err.Error(), ":")[2])
+	segments := strings.Split(err.Error(), ":")
+	// the error does not contain a status code, just return the error directly
+	if len(segments) < 3 {
+		return err
+	}
+	errCodeString := strings.TrimSpace(segments[2])
 	errCode, _ := strconv.ParseUint(errCodeString, 10, 32)
 
 	// if we fail to decode the error code, just return the error directly
@@ -34,8 +159,8 @@ func BuildHttpErrorMessage(err error) error {
 		return err
 	}
 	var errString string
-	if int(errCode) == 502 || int(errCode) == 503 || int(errCode) == 504 {
-		// retryable error codes - [502, 503, 504]
+	if int(errCode) == 429 || int(errCode) == 502 || int(errCode) == 503 || int(errCode) == 504 {
+		// retryable error codes - [429, 502, 503, 504]
 		errString = fmt.Sprintf("The server returned a %s error (%s). Please wait a few minutes and try again.", http.StatusText(int(errCode)), errCodeString)
 	} else {
 		// non-retryable errors
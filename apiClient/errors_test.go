@@ -0,0 +1,68 @@
+package apiClient
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestClassifyError(t *testing.T) {
+	type test struct {
+		name       string
+		code       string
+		statusCode int
+		message    string
+		expected   ErrorKind
+	}
+	tests := []test{
+		{"code", "NOT_FOUND", http.StatusOK, "graphql: resource 123", ErrorKindNotFound},
+		{"camel case code", "notFound", http.StatusOK, "graphql: resource 123", ErrorKindNotFound},
+		{"code takes precedence over message", "FORBIDDEN", http.StatusOK, "graphql: Not Found: resource 123", ErrorKindPermissionDenied},
+		{"validation code", "BAD_USER_INPUT", http.StatusOK, "graphql: title should be string", ErrorKindValidation},
+		{"status", "", http.StatusForbidden, "graphql: server returned a non-200 status code: 403", ErrorKindPermissionDenied},
+		{"unknown code uses status", "INTERNAL", http.StatusNotFound, "graphql: resource 123", ErrorKindNotFound},
+		{"message", "", http.StatusOK, "graphql: Not Found: resource 123", ErrorKindNotFound},
+		{"validation message", "", http.StatusOK, "graphql: Data validation failed. data.title: should be string", ErrorKindValidation},
+		{"permission message", "", http.StatusOK, "graphql: permission denied, resource not found", ErrorKindPermissionDenied},
+		{"other", "", http.StatusInternalServerError, "The server returned a Internal Server Error error (500). Please contact Turbot support.", ErrorKindOther},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, classifyError(test.code, test.statusCode, test.message), test.name)
+	}
+}
+
+func TestGetErrorKind_Wrapped(t *testing.T) {
+	apiErr := &APIError{Kind: ErrorKindPermissionDenied, Message: "graphql: Not Found: resource 123"}
+	err := fmt.Errorf("error reading resource: %w", apiErr)
+	assert.True(t, PermissionDeniedError(err))
+	assert.False(t, NotFoundError(err))
+
+	// errors which were not returned by the API are classified by their message
+	assert.True(t, NotFoundError(errors.New("error finding grant: grant not found")))
+	assert.Equal(t, ErrorKindOther, GetErrorKind(nil))
+}
+
+func TestReadResource_Forbidden(t *testing.T) {
+	client, server := newFixtureClient(t, "read_resource_forbidden")
+	defer server.Close()
+
+	_, err := client.ReadResource("190233581346752", nil)
+	assert.Error(t, err)
+	assert.True(t, PermissionDeniedError(err))
+	assert.False(t, NotFoundError(err))
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "FORBIDDEN", apiErr.Code)
+	assert.Equal(t, http.StatusOK, apiErr.StatusCode)
+	assert.Equal(t, "5f3e1c0a-forbidden", apiErr.RequestId)
+	assert.Equal(t, "error reading resource: graphql: Not Found: resource 190233581346752 (request id: 5f3e1c0a-forbidden)", err.Error())
+
+	// a resource which cannot be read due to permissions is not reported as not existing
+	client, server = newFixtureClient(t, "read_resource_forbidden")
+	defer server.Close()
+	_, err = client.ResourceExists("190233581346752")
+	assert.Error(t, err)
+}
@@ -0,0 +1,153 @@
+package apiClient
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/machinebox/graphql"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// a graphql request and the response recorded for it
+// fixtures are stored in testdata/<name>.json as a list of interactions, which are replayed in order
+type fixtureInteraction struct {
+	Request struct {
+		// a fragment of the query which identifies the request, e.g. "createResource(input: $input)"
+		// whitespace is ignored when matching
+		Match string
+		// if set, the request variables must be equal to these
+		Variables map[string]interface{}
+	}
+	Response struct {
+		// defaults to 200
+		Status  int
+		Headers map[string]string
+		// the response body - a JSON string is written as is, so invalid JSON responses can be recorded
+		Body json.RawMessage
+	}
+}
+
+// a graphql request received by the fixture server
+type fixtureRequest struct {
+	Query     string
+	Variables map[string]interface{}
+	Header    http.Header
+}
+
+// an httptest server which replays the interactions of a fixture
+type fixtureServer struct {
+	*httptest.Server
+	t            *testing.T
+	name         string
+	interactions []fixtureInteraction
+	requests     []fixtureRequest
+	lock         sync.Mutex
+}
+
+// retry quickly, so tests of throttled requests are fast
+var testRetryPolicy = RetryPolicy{
+	MaxRetries:   2,
+	RetryWaitMin: time.Millisecond,
+	RetryWaitMax: time.Millisecond,
+}
+
+// start a server replaying the given fixture and create a client which sends requests to it
+// the caller must call Close on the server, which fails the test if any interactions were not replayed
+func newFixtureClient(t *testing.T, name string) (*Client, *fixtureServer) {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", name+".json"))
+	if err != nil {
+		t.Fatalf("failed to read fixture %s: %s", name, err.Error())
+	}
+	server := &fixtureServer{t: t, name: name}
+	if err := json.Unmarshal(data, &server.interactions); err != nil {
+		t.Fatalf("failed to parse fixture %s: %s", name, err.Error())
+	}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.handle))
+
+	client := &Client{
+		AccessKey: "test-access-key",
+		SecretKey: "test-secret-key",
+		Graphql:   graphql.NewClient(server.URL, graphql.WithHTTPClient(newHttpClient(testRetryPolicy, nil, nil))),
+	}
+	return client, server
+}
+
+func (s *fixtureServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var body struct {
+		Query     string
+		Variables map[string]interface{}
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		s.fail(w, "failed to decode request: %s", err.Error())
+		return
+	}
+	s.requests = append(s.requests, fixtureRequest{Query: body.Query, Variables: body.Variables, Header: r.Header})
+
+	index := len(s.requests) - 1
+	if index >= len(s.interactions) {
+		s.fail(w, "unexpected request %d:\n%s", index+1, body.Query)
+		return
+	}
+	interaction := s.interactions[index]
+	if !strings.Contains(normalizeQuery(body.Query), normalizeQuery(interaction.Request.Match)) {
+		s.fail(w, "request %d does not match '%s':\n%s", index+1, interaction.Request.Match, body.Query)
+		return
+	}
+	if interaction.Request.Variables != nil && !jsonEqual(interaction.Request.Variables, body.Variables) {
+		s.fail(w, "request %d variables do not match\nexpected: %v\nactual: %v", index+1, interaction.Request.Variables, body.Variables)
+		return
+	}
+
+	for name, value := range interaction.Response.Headers {
+		w.Header().Set(name, value)
+	}
+	status := interaction.Response.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	var raw string
+	if json.Unmarshal(interaction.Response.Body, &raw) == nil {
+		w.Write([]byte(raw))
+		return
+	}
+	w.Write(interaction.Response.Body)
+}
+
+func (s *fixtureServer) fail(w http.ResponseWriter, format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	s.t.Errorf("fixture %s: %s", s.name, message)
+	http.Error(w, message, http.StatusBadRequest)
+}
+
+// stop the server, failing the test if any interactions were not replayed
+func (s *fixtureServer) Close() {
+	s.Server.Close()
+	if len(s.requests) < len(s.interactions) {
+		s.t.Errorf("fixture %s: expected %d requests, got %d", s.name, len(s.interactions), len(s.requests))
+	}
+}
+
+func normalizeQuery(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
+
+// compare values by their JSON representation, so numeric and map types do not need to match
+func jsonEqual(expected, actual interface{}) bool {
+	var expectedValue, actualValue interface{}
+	expectedBytes, _ := json.Marshal(expected)
+	actualBytes, _ := json.Marshal(actual)
+	json.Unmarshal(expectedBytes, &expectedValue)
+	json.Unmarshal(actualBytes, &actualValue)
+	return reflect.DeepEqual(expectedValue, actualValue)
+}
@@ -25,7 +25,7 @@ func (client *Client) CreateFolder(input map[string]interface{}) (*Folder, error
 
 	// execute api call
 	if err := client.doRequest(query, variables, responseData); err != nil {
-		return nil, fmt.Errorf("error creating folder: %s", err.Error())
+		return nil, fmt.Errorf("error creating folder: %w", err)
 	}
 	return &responseData.Resource, nil
 }
@@ -38,7 +38,7 @@ func (client *Client) ReadFolder(id string) (*Folder, error) {
 
 	// execute api call
 	if err := client.doRequest(query, nil, responseData); err != nil {
-		return nil, fmt.Errorf("error reading folder: %s", err.Error())
+		return nil, fmt.Errorf("error reading folder: %w", err)
 	}
 	return &responseData.Resource, nil
 }
@@ -52,7 +52,7 @@ func (client *Client) UpdateFolder(input map[string]interface{}) (*Folder, error
 
 	// execute api call
 	if err := client.doRequest(query, variables, responseData); err != nil {
-		return nil, fmt.Errorf("error updating folder: %s", err.Error())
+		return nil, fmt.Errorf("error updating folder: %w", err)
 	}
 	return &responseData.Resource, nil
 }
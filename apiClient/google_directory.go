@@ -26,7 +26,7 @@ func (client *Client) CreateGoogleDirectoryLegacy(input map[string]interface{})
 	}
 	// execute api call
 	if err := client.doRequest(query, variables, responseData); err != nil {
-		return nil, fmt.Errorf("error creating google directory: %s", err.Error())
+		return nil, fmt.Errorf("error creating google directory: %w", err)
 	}
 	return &responseData.Resource.Turbot, nil
 }
@@ -40,7 +40,7 @@ func (client *Client) UpdateGoogleDirectoryLegacy(input map[string]interface{})
 
 	// execute api call
 	if err := client.doRequest(query, variables, responseData); err != nil {
-		return nil, fmt.Errorf("error updating google directory: %s", err.Error())
+		return nil, fmt.Errorf("error updating google directory: %w", err)
 	}
 	return &responseData.Resource.Turbot, nil
 }
@@ -57,7 +57,7 @@ func (client *Client) ReadGoogleDirectory(id string) (*GoogleDirectory, error) {
 
 	// execute api call
 	if err := client.doRequest(query, nil, responseData); err != nil {
-		return nil, fmt.Errorf("error reading google directory: %s", err.Error())
+		return nil, fmt.Errorf("error reading google directory: %w", err)
 	}
 	return &responseData.Directory, nil
 }
@@ -70,7 +70,7 @@ func (client *Client) CreateGoogleDirectory(input map[string]interface{}) (*Turb
 	}
 	// execute api call
 	if err := client.doRequest(query, variables, responseData); err != nil {
-		return nil, fmt.Errorf("error creating google directory: %s", err.Error())
+		return nil, fmt.Errorf("error creating google directory: %w", err)
 	}
 	return &responseData.Resource.Turbot, nil
 }
@@ -84,7 +84,7 @@ func (client *Client) UpdateGoogleDirectory(input map[string]interface{}) (*Turb
 
 	// execute api call
 	if err := client.doRequest(query, variables, responseData); err != nil {
-		return nil, fmt.Errorf("error updating google directory: %s", err.Error())
+		return nil, fmt.Errorf("error updating google directory: %w", err)
 	}
 	return &responseData.Resource.Turbot, nil
 }
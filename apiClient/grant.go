@@ -14,7 +14,7 @@ func (client *Client) CreateGrant(input map[string]interface{}) (*TurbotGrantMet
 
 	// execute api call
 	if err := client.doRequest(query, variables, responseData); err != nil {
-		return nil, fmt.Errorf("error creating grant: %s", err.Error())
+		return nil, fmt.Errorf("error creating grant: %w", err)
 	}
 	return &responseData.Grants.Turbot, nil
 }
@@ -25,11 +25,63 @@ func (client *Client) ReadGrant(id string) (*Grant, error) {
 
 	// execute api call
 	if err := client.doRequest(query, nil, responseData); err != nil {
-		return nil, fmt.Errorf("error reading grant: %s", err.Error())
+		return nil, fmt.Errorf("error reading grant: %w", err)
 	}
 	return &responseData.Grant, nil
 }
 
+// find the grant of a permission type and level to a profile on a resource
+// the profile, resource, permission type and permission level may be specified by id or aka
+func (client *Client) FindGrant(profileAka, resourceAka, permissionTypeAka, permissionLevelAka string) (*Grant, error) {
+	// resolve the akas into ids
+	var ids []string
+	for _, aka := range []string{profileAka, resourceAka, permissionTypeAka, permissionLevelAka} {
+		resource, err := client.ReadResource(aka, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error finding grant: %w", err)
+		}
+		ids = append(ids, resource.Turbot.Id)
+	}
+	profileId, resourceId, permissionTypeId, permissionLevelId := ids[0], ids[1], ids[2], ids[3]
+
+	query := findGrantQuery(profileId, resourceId, permissionTypeId, permissionLevelId)
+	responseData := &FindGrantResponse{}
+
+	// execute api call
+	if err := client.doRequest(query, nil, responseData); err != nil {
+		return nil, fmt.Errorf("error finding grant: %w", err)
+	}
+	for _, grant := range responseData.Grants.Items {
+		if grant.Turbot.ProfileId == profileId && grant.Turbot.ResourceId == resourceId &&
+			grant.PermissionTypeId == permissionTypeId && grant.PermissionLevelId == permissionLevelId {
+			return &grant, nil
+		}
+	}
+	return nil, fmt.Errorf("error finding grant: grant of permission type %s, level %s to profile %s on resource %s not found", permissionTypeAka, permissionLevelAka, profileAka, resourceAka)
+}
+
+// read the grants matching the filter, fetching each page of results in turn
+func (client *Client) ReadGrantList(filter string) ([]Grant, error) {
+	var grants []Grant
+	paging := ""
+	for {
+		query := readGrantListQuery(filter, paging)
+		responseData := &GrantListResponse{}
+
+		// execute api call
+		if err := client.doRequest(query, nil, responseData); err != nil {
+			return nil, fmt.Errorf("error reading grant list: %w", err)
+		}
+		grants = append(grants, responseData.Grants.Items...)
+		// if there are no more pages, we are done
+		paging = responseData.Grants.Paging.Next
+		if paging == "" {
+			break
+		}
+	}
+	return grants, nil
+}
+
 func (client *Client) DeleteGrant(id string) error {
 	query := deleteGrantMutation()
 	var responseData interface{}
@@ -41,7 +93,7 @@ func (client *Client) DeleteGrant(id string) error {
 
 	// execute api call
 	if err := client.doRequest(query, variables, &responseData); err != nil {
-		return fmt.Errorf("error deleting grant: %s", err.Error())
+		return fmt.Errorf("error deleting grant: %w", err)
 	}
 	return nil
 }
@@ -49,8 +101,23 @@ func (client *Client) DeleteGrant(id string) error {
 func (client *Client) GrantExists(id string) (bool, error) {
 	grant, err := client.ReadGrant(id)
 	if err != nil {
+		if NotFoundError(err) {
+			return false, nil
+		}
 		return false, err
 	}
 	exists := grant.Turbot.Id != ""
 	return exists, nil
 }
+
+// read the permission types (matching the filter) and the permission levels available in the workspace
+func (client *Client) ReadPermissionTypes(filter string) (*PermissionTypesResponse, error) {
+	query := readPermissionTypesQuery(filter)
+	responseData := &PermissionTypesResponse{}
+
+	// execute api call
+	if err := client.doRequest(query, nil, responseData); err != nil {
+		return nil, fmt.Errorf("error reading permission types: %w", err)
+	}
+	return responseData, nil
+}
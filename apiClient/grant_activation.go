@@ -14,7 +14,7 @@ func (client *Client) CreateGrantActivation(input map[string]interface{}) (*Turb
 
 	// execute api call
 	if err := client.doRequest(query, variables, responseData); err != nil {
-		return nil, fmt.Errorf("error creating grant activation: %s", err.Error())
+		return nil, fmt.Errorf("error creating grant activation: %w", err)
 	}
 	return &responseData.GrantActivate.Turbot, nil
 }
@@ -24,11 +24,33 @@ func (client *Client) ReadGrantActivation(id string) (*ActiveGrant, error) {
 	responseData := &ReadActiveGrantResponse{}
 	// execute api call
 	if err := client.doRequest(query, nil, responseData); err != nil {
-		return nil, fmt.Errorf("error reading grant activation: %s", err.Error())
+		return nil, fmt.Errorf("error reading grant activation: %w", err)
 	}
 	return &responseData.ActiveGrant, nil
 }
 
+// read the activations of a grant, fetching each page of results in turn
+func (client *Client) ReadGrantActivationList(grantId string) ([]ActiveGrant, error) {
+	var activations []ActiveGrant
+	paging := ""
+	for {
+		query := readActiveGrantListQuery(fmt.Sprintf("grantId:%s", grantId), paging)
+		responseData := &ActiveGrantListResponse{}
+
+		// execute api call
+		if err := client.doRequest(query, nil, responseData); err != nil {
+			return nil, fmt.Errorf("error reading grant activation list: %w", err)
+		}
+		activations = append(activations, responseData.ActiveGrants.Items...)
+		// if there are no more pages, we are done
+		paging = responseData.ActiveGrants.Paging.Next
+		if paging == "" {
+			break
+		}
+	}
+	return activations, nil
+}
+
 func (client *Client) DeleteGrantActivation(id string) error {
 	query := deactivateGrantMutation()
 	var responseData interface{}
@@ -41,7 +63,7 @@ func (client *Client) DeleteGrantActivation(id string) error {
 
 	// execute api call
 	if err := client.doRequest(query, variables, &responseData); err != nil {
-		return fmt.Errorf("error deleting grant activation: %s", err.Error())
+		return fmt.Errorf("error deleting grant activation: %w", err)
 	}
 	return nil
 }
@@ -49,6 +71,9 @@ func (client *Client) DeleteGrantActivation(id string) error {
 func (client *Client) GrantActivationExists(id string) (bool, error) {
 	grantActivate, err := client.ReadGrantActivation(id)
 	if err != nil {
+		if NotFoundError(err) {
+			return false, nil
+		}
 		return false, err
 	}
 	exists := grantActivate.Turbot.Id != ""
@@ -0,0 +1,32 @@
+package apiClient
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestReadGrantList_Paging(t *testing.T) {
+	client, server := newFixtureClient(t, "read_grant_list_paging")
+	defer server.Close()
+
+	grants, err := client.ReadGrantList("resourceId:190233581346700 level:self")
+	assert.NoError(t, err)
+	var ids []string
+	for _, grant := range grants {
+		ids = append(ids, grant.Turbot.Id)
+	}
+	assert.Equal(t, []string{"190233581346801", "190233581346802"}, ids)
+	assert.Equal(t, "170759063660212", grants[1].PermissionLevelId)
+	assert.Equal(t, "170759063660234", grants[1].Turbot.ProfileId)
+}
+
+func TestReadGrantActivationList(t *testing.T) {
+	client, server := newFixtureClient(t, "read_grant_activation_list")
+	defer server.Close()
+
+	activations, err := client.ReadGrantActivationList("190233581346801")
+	assert.NoError(t, err)
+	assert.Len(t, activations, 1)
+	assert.Equal(t, "190233581346901", activations[0].Turbot.Id)
+	assert.Equal(t, "190233581346700", activations[0].Turbot.ResourceId)
+}
@@ -0,0 +1,52 @@
+package apiClient
+
+import (
+	"fmt"
+)
+
+var groupProfileProperties = []interface{}{
+	map[string]string{"parent": "turbot.parentId"},
+	"title",
+	"status",
+	"groupProfileId",
+	"directoryPoolId",
+}
+
+func (client *Client) CreateGroupProfile(input map[string]interface{}) (*GroupProfile, error) {
+	query := createResourceMutation(groupProfileProperties)
+	responseData := &GroupProfileResponse{}
+	// set type in input data
+	input["type"] = "tmod:@turbot/turbot-iam#/resource/types/groupProfile"
+	variables := map[string]interface{}{
+		"input": input,
+	}
+	// execute api call
+	if err := client.doRequest(query, variables, responseData); err != nil {
+		return nil, fmt.Errorf("error creating group profile: %w", err)
+	}
+	return &responseData.Resource, nil
+}
+
+func (client *Client) ReadGroupProfile(id string) (*GroupProfile, error) {
+	query := readResourceQuery(id, groupProfileProperties)
+	responseData := &GroupProfileResponse{}
+
+	// execute api call
+	if err := client.doRequest(query, nil, responseData); err != nil {
+		return nil, fmt.Errorf("error reading group profile: %w", err)
+	}
+	return &responseData.Resource, nil
+}
+
+func (client *Client) UpdateGroupProfile(input map[string]interface{}) (*GroupProfile, error) {
+	query := updateResourceMutation(groupProfileProperties)
+	responseData := &GroupProfileResponse{}
+	variables := map[string]interface{}{
+		"input": input,
+	}
+	// execute api call
+	if err := client.doRequest(query, variables, responseData); err != nil {
+		return nil, fmt.Errorf("error updating group profile: %w", err)
+	}
+	return &responseData.Resource, nil
+}
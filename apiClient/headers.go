@@ -0,0 +1,71 @@
+package apiClient
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// request header used to link changes in the Turbot activity log to the change which made them
+const changeReferenceHeader = "X-Turbot-Change-Reference"
+
+// request header identifying the provider process which sent the request, so the requests of a run can be grouped
+const correlationIdHeader = "X-Turbot-Correlation-Id"
+
+// the headers set by the client itself (or by the graphql client), which extra headers may not replace
+var reservedHeaders = []string{"Authorization", "Content-Type", "Accept", "Cache-Control", "User-Agent", "Prefer", "If-None-Match", changeReferenceHeader, correlationIdHeader}
+
+// the characters allowed in a header name (RFC 7230 token)
+var headerNameRegex = regexp.MustCompile("^[!#$%&'*+\\-.^_`|~0-9A-Za-z]+$")
+
+// NewCorrelationId returns a random id, used as the correlation id if none is configured
+func NewCorrelationId() string {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(bytes)
+}
+
+// check the extra headers are valid header names and values, and do not replace a header the client sets
+func validateExtraHeaders(headers map[string]string) error {
+	var names []string
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !headerNameRegex.MatchString(name) {
+			return fmt.Errorf("invalid header name '%s'", name)
+		}
+		for _, reserved := range reservedHeaders {
+			if strings.EqualFold(name, reserved) {
+				return fmt.Errorf("header '%s' is set by the provider and may not be overridden", name)
+			}
+		}
+		if strings.ContainsAny(headers[name], "\r\n") {
+			return fmt.Errorf("the value of header '%s' must not contain line breaks", name)
+		}
+	}
+	return nil
+}
+
+// set the headers which identify the client on a request
+func (client *Client) setClientHeaders(header http.Header) {
+	for name, value := range client.ExtraHeaders {
+		header.Set(name, value)
+	}
+	if client.UserAgent != "" {
+		header.Set("User-Agent", client.UserAgent)
+	}
+	if client.CorrelationId != "" {
+		header.Set(correlationIdHeader, client.CorrelationId)
+	}
+	if client.ChangeReference != "" {
+		header.Set(changeReferenceHeader, client.ChangeReference)
+	}
+}
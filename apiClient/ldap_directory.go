@@ -0,0 +1,76 @@
+package apiClient
+
+import (
+	"fmt"
+)
+
+// create a map of the properties we want the graphql query to return
+// NOTE: the password is write-only and is not returned
+var ldapDirectoryProperties = []interface{}{
+	map[string]string{"parent": "turbot.parentId"},
+	"title",
+	"description",
+	"status",
+	"directoryType",
+	"profileIdTemplate",
+	"groupProfileIdTemplate",
+	"url",
+	"distinguishedName",
+	"base",
+	"userObjectFilter",
+	"disabledUserFilter",
+	"userMatchFilter",
+	"userSearchFilter",
+	"userSearchAttributes",
+	"userCanonicalNameAttribute",
+	"userEmailAttribute",
+	"userDisplayNameAttribute",
+	"userGivenNameAttribute",
+	"userFamilyNameAttribute",
+	"groupObjectFilter",
+	"groupSearchFilter",
+	"groupSyncFilter",
+	"connectivityTestFilter",
+	"tlsEnabled",
+	"tlsServerCertificate",
+	"rejectUnauthorized",
+}
+
+func (client *Client) ReadLdapDirectory(id string) (*LdapDirectory, error) {
+	query := readResourceQuery(id, ldapDirectoryProperties)
+	responseData := &LdapDirectoryResponse{}
+
+	// execute api call
+	if err := client.doRequest(query, nil, responseData); err != nil {
+		return nil, fmt.Errorf("error reading ldap directory: %w", err)
+	}
+	return &responseData.Resource, nil
+}
+
+func (client *Client) CreateLdapDirectory(input map[string]interface{}) (*LdapDirectory, error) {
+	query := createLdapDirectoryMutation(ldapDirectoryProperties)
+	responseData := &LdapDirectoryResponse{}
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	// execute api call
+	if err := client.doRequest(query, variables, responseData); err != nil {
+		return nil, fmt.Errorf("error creating ldap directory: %w", err)
+	}
+	return &responseData.Resource, nil
+}
+
+func (client *Client) UpdateLdapDirectory(input map[string]interface{}) (*LdapDirectory, error) {
+	query := updateLdapDirectoryMutation(ldapDirectoryProperties)
+	responseData := &LdapDirectoryResponse{}
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	// execute api call
+	if err := client.doRequest(query, variables, responseData); err != nil {
+		return nil, fmt.Errorf("error updating ldap directory: %w", err)
+	}
+	return &responseData.Resource, nil
+}
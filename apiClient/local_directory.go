@@ -20,7 +20,7 @@ func (client *Client) ReadLocalDirectory(id string) (*LocalDirectory, error) {
 
 	// execute api call
 	if err := client.doRequest(query, nil, responseData); err != nil {
-		return nil, fmt.Errorf("error reading local directory: %s", err.Error())
+		return nil, fmt.Errorf("error reading local directory: %w", err)
 	}
 	return &responseData.Resource, nil
 }
@@ -34,7 +34,7 @@ func (client *Client) CreateLocalDirectory(input map[string]interface{}) (*Local
 
 	// execute api call
 	if err := client.doRequest(query, variables, responseData); err != nil {
-		return nil, fmt.Errorf("error creating local directory: %s", err.Error())
+		return nil, fmt.Errorf("error creating local directory: %w", err)
 	}
 	return &responseData.Resource, nil
 }
@@ -48,7 +48,7 @@ func (client *Client) UpdateLocalDirectory(input map[string]interface{}) (*Local
 
 	// execute api call
 	if err := client.doRequest(query, variables, responseData); err != nil {
-		return nil, fmt.Errorf("error updating local directory: %s", err.Error())
+		return nil, fmt.Errorf("error updating local directory: %w", err)
 	}
 	return &responseData.Resource, nil
 }
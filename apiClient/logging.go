@@ -0,0 +1,83 @@
+package apiClient
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/hashicorp/terraform/helper/logging"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+	"regexp"
+	"strings"
+)
+
+// variables and response fields whose names match this are never logged
+var sensitiveFieldPattern = regexp.MustCompile(`(?i)secret|password|token|privatekey|credential`)
+
+const redactedValue = "<redacted>"
+
+// the maximum length of the query summary logged for each request when graphql_logging is not enabled
+const querySummaryLength = 120
+
+// request logging uses the terraform log level (TF_LOG) and the transport log level - if debug logging is not
+// enabled for both, there is nothing to log, so we avoid the cost of building the log messages
+func (client *Client) requestLoggingEnabled() bool {
+	return logging.IsDebugOrHigher() && client.LogLevels.Enabled(helpers.LogTransport, "DEBUG")
+}
+
+// log a message of a subsystem, at the level of the "[LEVEL]" prefix of the format, if the subsystem log level allows it
+func (client *Client) Logf(subsystem, format string, v ...interface{}) {
+	client.LogLevels.Logf(subsystem, format, v...)
+}
+
+// the query on a single line, truncated - enough to identify the request without logging the whole query
+func summariseQuery(query string) string {
+	summary := strings.Join(strings.Fields(query), " ")
+	if len(summary) > querySummaryLength {
+		summary = summary[:querySummaryLength] + "..."
+	}
+	return summary
+}
+
+// return the JSON of the value with the value of any sensitive fields replaced
+func redactedJson(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return redactedValue
+	}
+	return redactedJsonBytes(data)
+}
+
+func redactedJsonBytes(data []byte) string {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		// not valid JSON, so we can't tell which parts are sensitive
+		return redactedValue
+	}
+	// don't escape HTML characters, so the log is readable
+	var redacted bytes.Buffer
+	encoder := json.NewEncoder(&redacted)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(redactFields(value))
+	return strings.TrimSuffix(redacted.String(), "\n")
+}
+
+func redactFields(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for key, fieldValue := range v {
+			if sensitiveFieldPattern.MatchString(key) {
+				redacted[key] = redactedValue
+			} else {
+				redacted[key] = redactFields(fieldValue)
+			}
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = redactFields(item)
+		}
+		return redacted
+	}
+	return value
+}
@@ -0,0 +1,90 @@
+package apiClient
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRedactedJson(t *testing.T) {
+	vars := map[string]interface{}{
+		"input": map[string]interface{}{
+			"title":    "ldap",
+			"password": "bind-password",
+			"registryCredentials": map[string]string{
+				"accessKey": "registry-access-key",
+				"secretKey": "registry-secret-key",
+			},
+			"users": []interface{}{
+				map[string]interface{}{"email": "a@example.com", "clientSecret": "client-secret"},
+			},
+		},
+	}
+	assert.Equal(t,
+		`{"input":{"password":"<redacted>","registryCredentials":"<redacted>","title":"ldap","users":[{"clientSecret":"<redacted>","email":"a@example.com"}]}}`,
+		redactedJson(vars))
+	// the body of an invalid response can't be redacted, so is not logged
+	assert.Equal(t, redactedValue, redactedJsonBytes([]byte("<html>Bad Gateway</html>")))
+}
+
+func TestSummariseQuery(t *testing.T) {
+	assert.Equal(t, "mutation CreateFolder($input: CreateFolderInput!) { folder: createFolder(input: $input) { turbot { id } } }",
+		summariseQuery("mutation CreateFolder($input: CreateFolderInput!) {\n\tfolder: createFolder(input: $input) {\n\t\tturbot {\n\t\t\tid\n\t\t}\n\t}\n}"))
+	summary := summariseQuery(strings.Repeat("field ", 50))
+	assert.Len(t, summary, querySummaryLength+len("..."))
+}
+
+func TestGraphqlLogging(t *testing.T) {
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+	os.Setenv("TF_LOG", "DEBUG")
+	defer os.Unsetenv("TF_LOG")
+
+	client, server := newFixtureClient(t, "install_mod")
+	defer server.Close()
+	client.GraphqlLogging = true
+	client.RegistryCredentials = RegistryCredentials{AccessKey: "registry-access-key", SecretKey: "registry-secret-key"}
+
+	_, err := client.InstallMod(map[string]interface{}{
+		"parent":  "tmod:@turbot/turbot#/",
+		"org":     "turbot",
+		"mod":     "aws",
+		"version": "5.1.0",
+	})
+	assert.NoError(t, err)
+	logged := output.String()
+	assert.Contains(t, logged, "mutation InstallMod($input: InstallModInput!)")
+	assert.Contains(t, logged, `"registryCredentials":"<redacted>"`)
+	assert.NotContains(t, logged, "registry-secret-key")
+	assert.Contains(t, logged, `Turbot API response: {"data":{"mod":{"build":"5.1.0-20200301120000"`)
+	assert.Contains(t, logged, "Turbot API request succeeded in")
+}
+
+func TestLogRetries(t *testing.T) {
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+
+	client, server := newFixtureClient(t, "read_control_throttled")
+	defer server.Close()
+
+	operationClient := client.ForOperation()
+	_, err := operationClient.ReadControl(`id: "190233581346770"`)
+	assert.NoError(t, err)
+	// the client the operation client was copied from is not counting retries, so logs nothing
+	client.LogRetries("turbot_mod create 190233581346760")
+	operationClient.LogRetries("turbot_mod create 190233581346760")
+	assert.Equal(t, 1, strings.Count(output.String(), "succeeded only after retrying"))
+	assert.Contains(t, output.String(), "[INFO] turbot_mod create 190233581346760: 1 requests to the Turbot API succeeded only after retrying (1 retries)")
+
+	// requests which succeed first time are not counted
+	operationClient = client.ForOperation()
+	operationClient.retries.record(0)
+	requests, retries := operationClient.retries.get()
+	assert.Equal(t, 0, requests)
+	assert.Equal(t, 0, retries)
+}
@@ -8,6 +8,13 @@ import (
 func (client *Client) InstallMod(input map[string]interface{}) (*InstallModData, error) {
 	query := installModMutation()
 	responseData := &InstallModResponse{}
+	// if registry credentials were provided, pass them so private mods can be installed
+	if client.RegistryCredentials.AccessKey != "" && client.RegistryCredentials.SecretKey != "" {
+		input["registryCredentials"] = map[string]string{
+			"accessKey": client.RegistryCredentials.AccessKey,
+			"secretKey": client.RegistryCredentials.SecretKey,
+		}
+	}
 
 	variables := map[string]interface{}{
 		"input": input,
@@ -15,7 +22,7 @@ func (client *Client) InstallMod(input map[string]interface{}) (*InstallModData,
 
 	// execute api call
 	if err := client.doRequest(query, variables, responseData); err != nil {
-		return nil, fmt.Errorf("error installing mod: %s", err.Error())
+		return nil, fmt.Errorf("error installing mod: %w", err)
 	}
 	return &responseData.Mod, nil
 }
@@ -26,7 +33,7 @@ func (client *Client) ReadMod(id string) (*Mod, error) {
 
 	// execute api call
 	if err := client.doRequest(query, nil, responseData); err != nil {
-		return nil, fmt.Errorf("error reading mod: %s", err.Error())
+		return nil, fmt.Errorf("error reading mod: %w", err)
 	}
 
 	// convert uri into org and mod
@@ -36,6 +43,30 @@ func (client *Client) ReadMod(id string) (*Mod, error) {
 	return &responseData.Mod, nil
 }
 
+// the resource type of installed mods
+const modResourceType = "tmod:@turbot/turbot#/resource/types/mod"
+
+// read all installed mods - the version of a mod which is still installing is empty
+func (client *Client) ReadModList() ([]Mod, error) {
+	resources, err := client.ReadResourceList(fmt.Sprintf("resourceType:%s", modResourceType), map[string]string{"version": "version"})
+	if err != nil {
+		return nil, fmt.Errorf("error reading mod list: %w", err)
+	}
+	var mods []Mod
+	for _, resource := range resources {
+		mod := Mod{
+			Version: resource.GetString("version"),
+			Parent:  resource.Turbot.ParentId,
+		}
+		if len(resource.Turbot.Akas) > 0 {
+			mod.Uri = resource.Turbot.Akas[0]
+		}
+		mod.Org, mod.Mod = ParseModUri(mod.Uri)
+		mods = append(mods, mod)
+	}
+	return mods, nil
+}
+
 func ParseModUri(uri string) (org, mod string) {
 	if uri == "" {
 		org = ""
@@ -45,7 +76,9 @@ func ParseModUri(uri string) (org, mod string) {
 	// uri will be of form "tmod:@<org>/<mod>"
 	segments := strings.Split(strings.TrimPrefix(uri, "tmod:@"), "/")
 	org = segments[0]
-	mod = segments[1]
+	if len(segments) > 1 {
+		mod = segments[1]
+	}
 	return
 }
 
@@ -61,7 +94,7 @@ func (client *Client) UninstallMod(modId string) error {
 
 	// execute api call
 	if err := client.doRequest(query, variables, responseData); err != nil {
-		return fmt.Errorf("error uninstalling mod: %s", err.Error())
+		return fmt.Errorf("error uninstalling mod: %w", err)
 	}
 	if !responseData.UninstallMod.Success {
 		return fmt.Errorf(" uninstallMod mutation ran with no errors but failed to uninstall the mod")
@@ -76,8 +109,62 @@ func (client *Client) GetModVersions(org, mod string) ([]ModRegistryVersion, err
 
 	// execute api call
 	if err := client.doRequest(query, nil, responseData); err != nil {
-		return nil, fmt.Errorf("error fetching mod versions mod: %s", err.Error())
+		return nil, fmt.Errorf("error fetching mod versions mod: %w", err)
 	}
 
 	return responseData.Versions.Items, nil
 }
+
+// get the policy types defined by a specific version of a mod
+func (client *Client) GetModVersionPolicyTypes(org, mod, version string) ([]PolicyType, error) {
+	query := modVersionPolicyTypesQuery(org, mod, version)
+	responseData := &ModVersionPolicyTypesResponse{}
+
+	// execute api call
+	if err := client.doRequest(query, nil, responseData); err != nil {
+		return nil, fmt.Errorf("error fetching policy types for mod version: %w", err)
+	}
+
+	return responseData.ModVersion.PolicyTypes.Items, nil
+}
+
+// get the peer dependencies of a specific version of a mod, as a map of mod name to version range
+func (client *Client) GetModVersionDependencies(org, mod, version string) (map[string]string, error) {
+	query := modVersionDependenciesQuery(org, mod, version)
+	responseData := &ModVersionDependenciesResponse{}
+
+	// execute api call
+	if err := client.doRequest(query, nil, responseData); err != nil {
+		return nil, fmt.Errorf("error fetching dependencies for mod version: %w", err)
+	}
+
+	return responseData.ModVersion.PeerDependencies, nil
+}
+
+// get the peer dependencies of the installed version of each of the mods, reading the versions of up to maxBatchSize
+// mods in a single query. the result has the dependencies of each mod in the same order as the mods
+func (client *Client) GetModListDependencies(mods []Mod) ([]map[string]string, error) {
+	var result []map[string]string
+	for start := 0; start < len(mods); start += maxBatchSize {
+		end := start + maxBatchSize
+		if end > len(mods) {
+			end = len(mods)
+		}
+		query := modListDependenciesQuery(mods[start:end])
+		responseData := map[string]ModVersionDependencies{}
+
+		// execute api call
+		if err := client.doRequest(query, nil, &responseData); err != nil {
+			return nil, fmt.Errorf("error fetching dependencies for mod versions: %w", err)
+		}
+		for i := range mods[start:end] {
+			result = append(result, responseData[modVersionAlias(i)].PeerDependencies)
+		}
+	}
+	return result, nil
+}
+
+// the alias of the i'th mod version in a query reading several mod versions
+func modVersionAlias(i int) string {
+	return fmt.Sprintf("modVersion%d", i)
+}
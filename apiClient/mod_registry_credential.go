@@ -0,0 +1,55 @@
+package apiClient
+
+import (
+	"fmt"
+)
+
+// NOTE: the secret key is never returned by the API
+var modRegistryCredentialProperties = []interface{}{
+	map[string]string{"parent": "turbot.parentId"},
+	"title",
+	"registry",
+	"org",
+	"accessKey",
+}
+
+func (client *Client) CreateModRegistryCredential(input map[string]interface{}) (*ModRegistryCredential, error) {
+	query := createResourceMutation(modRegistryCredentialProperties)
+	responseData := &ModRegistryCredentialResponse{}
+	// set type in input data
+	input["type"] = "tmod:@turbot/turbot#/resource/types/modRegistryCredential"
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	// execute api call
+	if err := client.doRequest(query, variables, responseData); err != nil {
+		return nil, fmt.Errorf("error creating mod registry credential: %w", err)
+	}
+	return &responseData.Resource, nil
+}
+
+func (client *Client) ReadModRegistryCredential(id string) (*ModRegistryCredential, error) {
+	query := readResourceQuery(id, modRegistryCredentialProperties)
+	responseData := &ModRegistryCredentialResponse{}
+
+	// execute api call
+	if err := client.doRequest(query, nil, responseData); err != nil {
+		return nil, fmt.Errorf("error reading mod registry credential: %w", err)
+	}
+	return &responseData.Resource, nil
+}
+
+func (client *Client) UpdateModRegistryCredential(input map[string]interface{}) (*ModRegistryCredential, error) {
+	query := updateResourceMutation(modRegistryCredentialProperties)
+	responseData := &ModRegistryCredentialResponse{}
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	// execute api call
+	if err := client.doRequest(query, variables, responseData); err != nil {
+		return nil, fmt.Errorf("error updating mod registry credential: %w", err)
+	}
+	return &responseData.Resource, nil
+}
@@ -0,0 +1,69 @@
+package apiClient
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestInstallMod(t *testing.T) {
+	client, server := newFixtureClient(t, "install_mod")
+	defer server.Close()
+	// registry credentials are passed in the input, so private mods can be installed
+	client.RegistryCredentials = RegistryCredentials{AccessKey: "registry-access-key", SecretKey: "registry-secret-key"}
+
+	mod, err := client.InstallMod(map[string]interface{}{
+		"parent":  "tmod:@turbot/turbot#/",
+		"org":     "turbot",
+		"mod":     "aws",
+		"version": "5.1.0",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "190233581346760", mod.Turbot.Id)
+	assert.Equal(t, "5.1.0-20200301120000", mod.Build)
+}
+
+func TestReadMod(t *testing.T) {
+	client, server := newFixtureClient(t, "read_mod")
+	defer server.Close()
+
+	mod, err := client.ReadMod("190233581346760")
+	assert.NoError(t, err)
+	assert.Equal(t, &Mod{
+		Org:     "turbot",
+		Mod:     "aws",
+		Version: "5.1.0",
+		Parent:  "162167737977850",
+		Uri:     "tmod:@turbot/aws",
+	}, mod)
+}
+
+func TestReadModList(t *testing.T) {
+	client, server := newFixtureClient(t, "read_mod_list")
+	defer server.Close()
+
+	mods, err := client.ReadModList()
+	assert.NoError(t, err)
+	assert.Equal(t, []Mod{
+		{Org: "turbot", Mod: "aws", Version: "5.1.0", Parent: "162167737977850", Uri: "tmod:@turbot/aws"},
+		{Org: "turbot", Mod: "aws-s3", Version: "5.0.2", Parent: "162167737977850", Uri: "tmod:@turbot/aws-s3"},
+		// still installing
+		{Org: "turbot", Mod: "aws-iam", Version: "", Parent: "162167737977850", Uri: "tmod:@turbot/aws-iam"},
+	}, mods)
+}
+
+func TestGetModListDependencies(t *testing.T) {
+	client, server := newFixtureClient(t, "get_mod_list_dependencies")
+	defer server.Close()
+
+	dependencies, err := client.GetModListDependencies([]Mod{
+		{Org: "turbot", Mod: "aws-s3", Version: "5.0.2"},
+		{Org: "turbot", Mod: "aws-iam", Version: "5.0.1"},
+	})
+	assert.NoError(t, err)
+	// a single query reads the dependencies of every mod
+	assert.Len(t, server.requests, 1)
+	assert.Equal(t, []map[string]string{
+		{"@turbot/aws": ">=5.0.0", "@turbot/turbot": ">=5.0.0"},
+		{"@turbot/turbot": ">=5.0.0"},
+	}, dependencies)
+}
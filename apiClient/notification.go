@@ -0,0 +1,51 @@
+package apiClient
+
+import (
+	"fmt"
+)
+
+func (client *Client) ReadNotifications(filter string) ([]Notification, error) {
+	query := readNotificationsQuery(filter)
+	responseData := &NotificationsResponse{}
+
+	// execute api call
+	if err := client.doRequest(query, nil, responseData); err != nil {
+		return nil, fmt.Errorf("error reading notifications: %w", err)
+	}
+	return responseData.Notifications.Items, nil
+}
+
+func (client *Client) ReadResourceActivity(filter string) ([]ResourceActivity, error) {
+	query := readResourceActivityQuery(filter)
+	responseData := &ResourceActivityResponse{}
+
+	// execute api call
+	if err := client.doRequest(query, nil, responseData); err != nil {
+		return nil, fmt.Errorf("error reading resource activity: %w", err)
+	}
+	return responseData.Notifications.Items, nil
+}
+
+// the notifications filter for the most recent versions of a resource, most recent first
+func resourceVersionsFilter(resourceAka string, limit int) string {
+	return fmt.Sprintf("resource:%s level:self notificationClass:resource sort:-createTimestamp limit:%d", resourceAka, limit)
+}
+
+// read the most recent versions of a resource, most recent first
+func (client *Client) ReadResourceVersions(resourceAka string, limit int) ([]ResourceVersion, error) {
+	query := readResourceVersionsQuery(resourceVersionsFilter(resourceAka, limit))
+	responseData := &ResourceVersionsResponse{}
+
+	// execute api call
+	if err := client.doRequest(query, nil, responseData); err != nil {
+		return nil, fmt.Errorf("error reading resource versions: %w", err)
+	}
+	// deleted notifications do not create a version
+	var versions []ResourceVersion
+	for _, version := range responseData.Notifications.Items {
+		if version.Turbot.ResourceNewVersionId != "" {
+			versions = append(versions, version)
+		}
+	}
+	return versions, nil
+}
@@ -0,0 +1,17 @@
+package apiClient
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestReadResourceVersions(t *testing.T) {
+	client, server := newFixtureClient(t, "read_resource_versions")
+	defer server.Close()
+
+	versions, err := client.ReadResourceVersions("190233581346752", 10)
+	assert.NoError(t, err)
+	assert.Len(t, versions, 2)
+	assert.Equal(t, "20200301120000000", versions[1].Turbot.ResourceNewVersionId)
+	assert.Equal(t, map[string]interface{}{"foo": "created", "bar": "removed"}, versions[1].Resource.Data)
+}
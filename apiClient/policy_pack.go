@@ -0,0 +1,92 @@
+package apiClient
+
+import (
+	"fmt"
+)
+
+func (client *Client) CreatePolicyPack(input map[string]interface{}) (*PolicyPack, error) {
+	query := createPolicyPackMutation()
+	responseData := &PolicyPackResponse{}
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	// execute api call
+	if err := client.doRequest(query, variables, responseData); err != nil {
+		return nil, fmt.Errorf("error creating policy pack: %w", err)
+	}
+	return &responseData.PolicyPack, nil
+}
+
+// the number of versions of a policy pack read with it
+const policyPackVersionsLimit = 10
+
+func (client *Client) ReadPolicyPack(id string) (*PolicyPack, error) {
+	query := readPolicyPackQuery(id, policyPackVersionsLimit)
+	responseData := &PolicyPackResponse{}
+
+	// execute api call
+	if err := client.doRequest(query, nil, responseData); err != nil {
+		return nil, fmt.Errorf("error reading policy pack: %w", err)
+	}
+	responseData.PolicyPack.PolicySettingCount = responseData.PolicySettings.Metadata.Stats.Total
+	responseData.PolicyPack.Revision = responseData.Versions.Metadata.Stats.Total
+	responseData.PolicyPack.Versions = responseData.Versions.Items
+	return &responseData.PolicyPack, nil
+}
+
+func (client *Client) UpdatePolicyPack(input map[string]interface{}) (*PolicyPack, error) {
+	query := updatePolicyPackMutation()
+	responseData := &PolicyPackResponse{}
+	variables := map[string]interface{}{
+		"input": input,
+	}
+	// execute api call
+	if err := client.doRequest(query, variables, responseData); err != nil {
+		return nil, fmt.Errorf("error updating policy pack: %w", err)
+	}
+	return &responseData.PolicyPack, nil
+}
+
+// is the resource attached to the policy pack - resource may be the id or any aka of the resource
+func (policyPack *PolicyPack) IsAttached(resource string) bool {
+	for _, attachedResource := range policyPack.AttachedResources.Items {
+		if resource == attachedResource.Turbot.Id {
+			return true
+		}
+		for _, aka := range attachedResource.Turbot.Akas {
+			if aka == resource {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (client *Client) CreatePolicyPackAttachment(input map[string]interface{}) (*TurbotResourceMetadata, error) {
+	query := createPolicyPackAttachmentMutation()
+	responseData := &CreatePolicyPackAttachResponse{}
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	// execute api call
+	if err := client.doRequest(query, variables, responseData); err != nil {
+		return nil, fmt.Errorf("error creating policy pack attachment: %w", err)
+	}
+	return &responseData.AttachPolicyPacks.Turbot, nil
+}
+
+func (client *Client) DeletePolicyPackAttachment(input map[string]interface{}) error {
+	query := detachPolicyPackAttachmentMutation()
+	var responseData interface{}
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	// execute api call
+	if err := client.doRequest(query, variables, &responseData); err != nil {
+		return fmt.Errorf("error deleting policy pack attachment: %w", err)
+	}
+	return nil
+}
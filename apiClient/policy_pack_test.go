@@ -0,0 +1,21 @@
+package apiClient
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestReadPolicyPack(t *testing.T) {
+	client, server := newFixtureClient(t, "read_policy_pack")
+	defer server.Close()
+
+	policyPack, err := client.ReadPolicyPack("190233581346800")
+	assert.NoError(t, err)
+	assert.Equal(t, "Policy Pack updated", policyPack.Description)
+	assert.Equal(t, 4, policyPack.PolicySettingCount)
+	assert.Equal(t, 3, policyPack.Revision)
+	assert.Len(t, policyPack.Versions, 2)
+	assert.Equal(t, "20200301120000000", policyPack.Versions[1].Turbot.ResourceNewVersionId)
+	assert.True(t, policyPack.IsAttached("arn:aws:::123456789012"))
+	assert.False(t, policyPack.IsAttached("190233581346700"))
+}
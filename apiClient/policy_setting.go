@@ -13,7 +13,7 @@ func (client *Client) CreatePolicySetting(input map[string]interface{}) (*Policy
 
 	// execute api call
 	if err := client.doRequest(query, variables, responseData); err != nil {
-		return nil, fmt.Errorf("error creating policy: %s", err.Error())
+		return nil, fmt.Errorf("error creating policy: %w", err)
 	}
 	return &responseData.PolicySetting, nil
 }
@@ -24,7 +24,7 @@ func (client *Client) ReadPolicySetting(id string) (*PolicySetting, error) {
 
 	// execute api call
 	if err := client.doRequest(query, nil, responseData); err != nil {
-		return nil, fmt.Errorf("error reading policy setting: %s", err.Error())
+		return nil, fmt.Errorf("error reading policy setting: %w", err)
 	}
 	return &responseData.PolicySetting, nil
 }
@@ -38,7 +38,7 @@ func (client *Client) UpdatePolicySetting(input map[string]interface{}) (*Policy
 	}
 	// execute api call
 	if err := client.doRequest(query, variables, responseData); err != nil {
-		return nil, fmt.Errorf("error updating policy: %s", err.Error())
+		return nil, fmt.Errorf("error updating policy: %w", err)
 	}
 	return &responseData.PolicySetting, nil
 }
@@ -53,7 +53,7 @@ func (client *Client) DeletePolicySetting(id string) error {
 	}
 	// execute api call
 	if err := client.doRequest(query, variables, responseData); err != nil {
-		return fmt.Errorf("error deleting policy: %s", err.Error())
+		return fmt.Errorf("error deleting policy: %w", err)
 	}
 	return nil
 }
@@ -65,7 +65,7 @@ func (client *Client) FindPolicySetting(policyTypeUri, resourceAka string) (Poli
 
 	// execute api call
 	if err := client.doRequest(query, nil, &responseData); err != nil {
-		return PolicySetting{}, fmt.Errorf("error reading policy setting: %s", err.Error())
+		return PolicySetting{}, fmt.Errorf("error reading policy setting: %w", err)
 	}
 
 	for _, setting := range responseData.PolicySettings.Items {
@@ -75,3 +75,25 @@ func (client *Client) FindPolicySetting(policyTypeUri, resourceAka string) (Poli
 	}
 	return PolicySetting{}, nil
 }
+
+// read the policy settings matching the filter, fetching each page of results in turn
+func (client *Client) ReadPolicySettingList(filter string) ([]PolicySetting, error) {
+	var settings []PolicySetting
+	paging := ""
+	for {
+		query := readPolicySettingListQuery(filter, paging)
+		responseData := &PolicySettingListResponse{}
+
+		// execute api call
+		if err := client.doRequest(query, nil, responseData); err != nil {
+			return nil, fmt.Errorf("error reading policy setting list: %w", err)
+		}
+		settings = append(settings, responseData.PolicySettings.Items...)
+		// if there are no more pages, we are done
+		paging = responseData.PolicySettings.Paging.Next
+		if paging == "" {
+			break
+		}
+	}
+	return settings, nil
+}
@@ -0,0 +1,22 @@
+package apiClient
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestReadPolicySettingList_Paging(t *testing.T) {
+	client, server := newFixtureClient(t, "read_policy_setting_list_paging")
+	defer server.Close()
+
+	settings, err := client.ReadPolicySettingList("resource:190233581346700 level:self")
+	assert.NoError(t, err)
+	var ids []string
+	for _, setting := range settings {
+		ids = append(ids, setting.Turbot.Id)
+	}
+	assert.Equal(t, []string{"190233581346901", "190233581346902"}, ids)
+	assert.Equal(t, "tmod:@turbot/aws#/policy/types/accountStack", settings[1].Type.Uri)
+	assert.Equal(t, "RECOMMENDED", settings[1].Precedence)
+	assert.Equal(t, "190233581346700", settings[1].Turbot.ResourceId)
+}
@@ -9,7 +9,7 @@ func (client *Client) ReadPolicyValue(policyTypeUri, resourceAka string) (*Polic
 	responseData := &PolicyValueResponse{}
 	// execute api call
 	if err := client.doRequest(query, nil, responseData); err != nil {
-		return nil, fmt.Errorf("error reading policy value: %s", err.Error())
+		return nil, fmt.Errorf("error reading policy value: %w", err)
 	}
 
 	return &responseData.PolicyValue, nil
@@ -30,7 +30,7 @@ func (client *Client) CreateProfile(input map[string]interface{}) (*Profile, err
 	}
 	// execute api call
 	if err := client.doRequest(query, variables, responseData); err != nil {
-		return nil, fmt.Errorf("error creating profile: %s", err.Error())
+		return nil, fmt.Errorf("error creating profile: %w", err)
 	}
 	return &responseData.Resource, nil
 }
@@ -43,7 +43,7 @@ func (client *Client) ReadProfile(id string) (*Profile, error) {
 
 	// execute api call
 	if err := client.doRequest(query, nil, responseData); err != nil {
-		return nil, fmt.Errorf("error reading profile: %s", err.Error())
+		return nil, fmt.Errorf("error reading profile: %w", err)
 	}
 	return &responseData.Resource, nil
 }
@@ -56,7 +56,7 @@ func (client *Client) UpdateProfile(input map[string]interface{}) (*Profile, err
 	}
 	// execute api call
 	if err := client.doRequest(query, variables, responseData); err != nil {
-		return nil, fmt.Errorf("error updating profile: %s", err.Error())
+		return nil, fmt.Errorf("error updating profile: %w", err)
 	}
 	return &responseData.Resource, nil
 }
@@ -168,6 +168,35 @@ func findPolicySettingQuery(policyTypeUri, resourceAka string) string {
 `, policyTypeUri, resourceAka)
 }
 
+// read a page of the policy settings matching a filter
+func readPolicySettingListQuery(filter, paging string) string {
+	return fmt.Sprintf(`{
+	policySettings: policySettingList(filter: "%s", paging: "%s") {
+		items {
+			type {
+				uri
+			}
+			value: secretValue
+			valueSource: secretValueSource
+			template
+			default
+			precedence
+			templateInput
+			note
+			validFromTimestamp
+			validToTimestamp
+			turbot {
+				id
+				resourceId
+			}
+		}
+		paging {
+			next
+		}
+	}
+}`, filter, paging)
+}
+
 // policy value
 func readPolicyValueQuery(policyTypeUri string, resourceId string) string {
 	return fmt.Sprintf(`{
@@ -219,9 +248,21 @@ func readSmartFolderQuery(id string) string {
 			items{
 				turbot: get(path:"turbot")
 			}
+			metadata {
+				stats {
+					total
+				}
+			}
 		}
 	}
-}`, id)
+	policySettings: policySettingList(filter: "resource:%s level:self") {
+		metadata {
+			stats {
+				total
+			}
+		}
+	}
+}`, id, id)
 }
 
 func updateSmartFolderMutation() string {
@@ -256,6 +297,99 @@ func detachSmartFolderAttachment() string {
 	}`)
 }
 
+// policy pack
+func createPolicyPackMutation() string {
+	return `mutation CreatePolicyPack($input: CreatePolicyPackInput!) {
+		policyPack: createPolicyPack(input: $input) {
+			turbot {
+				id
+				parentId
+				akas
+				title
+			}
+		}
+	}`
+}
+
+// the versions of the policy pack are read from the same notifications as their total, so the revision and the
+// listed versions always agree
+func readPolicyPackQuery(id string, versionsLimit int) string {
+	return fmt.Sprintf(`{
+	policyPack: resource(id:"%s") {
+		title: get(path:"turbot.title")
+		description: get(path:"description")
+		filters: get(path:"filters")
+		parent:	get(path:"turbot.parentId")
+		turbot: get(path:"turbot")
+		attachedResources{
+			items{
+				turbot: get(path:"turbot")
+			}
+			metadata {
+				stats {
+					total
+				}
+			}
+		}
+	}
+	policySettings: policySettingList(filter: "resource:%s level:self") {
+		metadata {
+			stats {
+				total
+			}
+		}
+	}
+	versions: notifications(filter: "%s") {
+		items {
+			turbot {
+				createTimestamp
+				resourceNewVersionId
+			}
+			resource {
+				data
+			}
+		}
+		metadata {
+			stats {
+				total
+			}
+		}
+	}
+}`, id, id, resourceVersionsFilter(id, versionsLimit))
+}
+
+func updatePolicyPackMutation() string {
+	return `mutation UpdatePolicyPack($input: UpdatePolicyPackInput!) {
+		policyPack: updatePolicyPack(input: $input) {
+			turbot {
+				id
+				parentId
+				akas
+			}
+		}
+	}`
+}
+
+func createPolicyPackAttachmentMutation() string {
+	return `mutation AttachPolicyPack($input: AttachPolicyPackInput!) {
+		attachPolicyPacks(input: $input) {
+			turbot {
+				id
+			}
+		}
+	}`
+}
+
+func detachPolicyPackAttachmentMutation() string {
+	return `mutation DetachPolicyPack($input: DetachPolicyPackInput!) {
+		detachPolicyPack: detachPolicyPacks(input: $input) {
+			turbot {
+				id
+			}
+		}
+	}`
+}
+
 // mod
 func installModMutation() string {
 	return `mutation InstallMod($input: InstallModInput!) {
@@ -276,6 +410,7 @@ func readModQuery(modId string) string {
 		uri: get(path: "turbot.akas.0")
 		parent: get(path: "turbot.parentId")
 		version: get(path: "version")
+		turbot: get(path: "turbot")
 	}
 }`, modId)
 }
@@ -299,6 +434,42 @@ func modVersionsQuery(org, mod string) string {
 }`, org, mod)
 }
 
+func modVersionPolicyTypesQuery(org, mod, version string) string {
+	return fmt.Sprintf(`{
+	modVersion(orgName: "%s", modName: "%s", version: "%s") {
+		policyTypes {
+			items {
+				uri
+				title
+				schema
+				defaultTemplate
+			}
+		}
+	}
+}`, org, mod, version)
+}
+
+func modVersionDependenciesQuery(org, mod, version string) string {
+	return fmt.Sprintf(`{
+	modVersion(orgName: "%s", modName: "%s", version: "%s") {
+		peerDependencies
+	}
+}`, org, mod, version)
+}
+
+// read the peer dependencies of the installed version of several mods, aliasing each mod version read
+func modListDependenciesQuery(mods []Mod) string {
+	var modVersionsString bytes.Buffer
+	for i, mod := range mods {
+		modVersionsString.WriteString(fmt.Sprintf(`	%s: modVersion(orgName: "%s", modName: "%s", version: "%s") {
+		peerDependencies
+	}
+`, modVersionAlias(i), mod.Org, mod.Mod, mod.Version))
+	}
+	return fmt.Sprintf(`{
+%s}`, modVersionsString.String())
+}
+
 // resource
 func createResourceMutation(properties []interface{}) string {
 	return fmt.Sprintf(`mutation CreateResource($input: CreateResourceInput!) {
@@ -339,6 +510,23 @@ func readResourceQuery(aka string, properties []interface{}) string {
 }`, aka, buildResourceProperties(properties))
 }
 
+// read multiple resources in a single query, aliasing each resource read
+func batchReadResourceQuery(requests []*batchRequest) string {
+	var resourcesString bytes.Buffer
+	for i, request := range requests {
+		resourcesString.WriteString(fmt.Sprintf(`	%s: resource(id:"%s") {
+		type {
+			uri
+		}
+%s
+		turbot: get(path:"turbot")
+	}
+`, batchAlias(i), request.aka, buildResourceProperties([]interface{}{request.properties})))
+	}
+	return fmt.Sprintf(`{
+%s}`, resourcesString.String())
+}
+
 func getResourceTypeIdQuery(aka string) string {
 	return fmt.Sprintf(`{
 	resource(id:"%s") {
@@ -349,7 +537,8 @@ func getResourceTypeIdQuery(aka string) string {
 }`, aka)
 }
 
-func readResourceListQuery(filter string, properties map[string]string) string {
+// paging is the 'next' token returned by the previous page, or empty for the first page
+func readResourceListQuery(filter string, properties map[string]string, paging string) string {
 	var propertiesString bytes.Buffer
 	if properties != nil {
 		for alias, propertyPath := range properties {
@@ -357,13 +546,124 @@ func readResourceListQuery(filter string, properties map[string]string) string {
 		}
 	}
 	return fmt.Sprintf(`{
-	resourceList(filter:"%s") {
+	resourceList(filter:"%s", paging:"%s") {
 		items{
 %s
+			type {
+				uri
+			}
 			turbot: get(path:"turbot")
 		}
+		paging {
+			next
+		}
 	}
-}`, filter, propertiesString.String())
+}`, filter, paging, propertiesString.String())
+}
+
+func readNotificationsQuery(filter string) string {
+	return fmt.Sprintf(`{
+	notifications(filter:"%s") {
+		items {
+			notificationType
+			control {
+				state
+				reason
+				type {
+					uri
+				}
+			}
+			turbot {
+				id
+				createTimestamp
+				resourceId
+				controlId
+			}
+		}
+	}
+}`, filter)
+}
+
+// the resource notifications (created/updated/deleted), including the identity which made the change
+func readResourceActivityQuery(filter string) string {
+	return fmt.Sprintf(`{
+	notifications(filter:"%s") {
+		items {
+			notificationType
+			actor {
+				identity {
+					turbot {
+						id
+						title
+					}
+				}
+			}
+			turbot {
+				id
+				createTimestamp
+				resourceId
+				resourceNewVersionId
+				resourceOldVersionId
+			}
+		}
+	}
+}`, filter)
+}
+
+// the versions of a resource, from the resource notifications - the resource of each notification is the version
+// created by the change
+func readResourceVersionsQuery(filter string) string {
+	return fmt.Sprintf(`{
+	notifications(filter:"%s") {
+		items {
+			turbot {
+				createTimestamp
+				resourceNewVersionId
+			}
+			resource {
+				data
+			}
+		}
+	}
+}`, filter)
+}
+
+// list the permission types and permission levels available in the workspace
+func readPermissionTypesQuery(filter string) string {
+	return fmt.Sprintf(`{
+	permissionTypes: permissionTypeList(filter:"%s") {
+		items {
+			uri
+			title
+			description
+		}
+	}
+	permissionLevels: permissionLevelList {
+		items {
+			uri
+			title
+			description
+		}
+	}
+}`, filter)
+}
+
+// list the cloud accounts of a given resource type, e.g. AWS accounts
+// accountIdPath is the path of the cloud provider's id for the account, e.g. Id for an AWS account
+func readCloudAccountListQuery(resourceType, accountIdPath, paging string) string {
+	return fmt.Sprintf(`{
+	resourceList(filter:"resourceType:%s", paging:"%s") {
+		items{
+			accountId: get(path:"%s")
+			title: get(path:"turbot.title")
+			parent: get(path:"turbot.parentId")
+			turbot: get(path:"turbot")
+		}
+		paging {
+			next
+		}
+	}
+}`, resourceType, paging, accountIdPath)
 }
 
 func readFullResourceQuery(aka string) string {
@@ -406,6 +706,34 @@ func readGrantQuery(aka string) string {
   }`, aka, turbotGrantMetadataFragment("\t\t"))
 }
 
+func findGrantQuery(profileId, resourceId, permissionTypeId, permissionLevelId string) string {
+	return fmt.Sprintf(`{
+	grants: grantList(filter: "profileId:%s resourceId:%s permissionTypeId:%s permissionLevelId:%s") {
+		items {
+			permissionTypeId
+			permissionLevelId
+%s
+		}
+	}
+}`, profileId, resourceId, permissionTypeId, permissionLevelId, turbotGrantMetadataFragment("\t\t\t"))
+}
+
+// read a page of the grants matching a filter
+func readGrantListQuery(filter, paging string) string {
+	return fmt.Sprintf(`{
+	grants: grantList(filter: "%s", paging: "%s") {
+		items {
+			permissionTypeId
+			permissionLevelId
+%s
+		}
+		paging {
+			next
+		}
+	}
+}`, filter, paging, turbotGrantMetadataFragment("\t\t\t"))
+}
+
 func createGrantMutation() string {
 	return fmt.Sprintf(`mutation CreateGrant($input: CreateGrantInput!) {
 	grants: createGrant(input: $input) {
@@ -431,6 +759,19 @@ func readActiveGrantQuery(aka string) string {
 }`, aka, turbotActiveGrantMetadataFragment("\t\t"))
 }
 
+func readActiveGrantListQuery(filter, paging string) string {
+	return fmt.Sprintf(`{
+	activeGrants: activeGrantList(filter: "%s", paging: "%s") {
+		items {
+%s
+		}
+		paging {
+			next
+		}
+	}
+}`, filter, paging, turbotActiveGrantMetadataFragment("\t\t\t"))
+}
+
 func activateGrantMutation() string {
 	return fmt.Sprintf(`mutation ActivateGrant($input: ActivateGrantInput!) {
 	grantActivate: activateGrant(input: $input) {
@@ -541,7 +882,26 @@ func updateSamlDirectoryMutation(properties []interface{}) string {
 }`, buildResourceProperties(properties))
 }
 
-//control
+// ldap directory
+func createLdapDirectoryMutation(properties []interface{}) string {
+	return fmt.Sprintf(`mutation createLdapDirectory($input: CreateLdapDirectoryInput!) {
+  		resource: createLdapDirectory(input: $input){
+%s
+    	turbot:get(path:"turbot")
+  }
+}`, buildResourceProperties(properties))
+}
+
+func updateLdapDirectoryMutation(properties []interface{}) string {
+	return fmt.Sprintf(`mutation updateLdapDirectory($input: UpdateLdapDirectoryInput!) {
+  		resource: updateLdapDirectory(input: $input){
+%s
+    	turbot:get(path:"turbot")
+  }
+}`, buildResourceProperties(properties))
+}
+
+// control
 func readControlQuery(args string) string {
 	return fmt.Sprintf(`{
 control(%s){
@@ -554,25 +914,113 @@ control(%s){
 	turbot {
 		id
 		resourceId
+		updateTimestamp
 	}
 }
 }`, args)
 }
 
+// count the controls matching a filter
+func readControlCountQuery(filter string) string {
+	return fmt.Sprintf(`{
+	controlList(filter:"%s") {
+		metadata {
+			stats {
+				total
+			}
+		}
+	}
+}`, filter)
+}
+
+// read a page of the controls matching a filter
+func readControlListQuery(filter, paging string) string {
+	return fmt.Sprintf(`{
+	controlList(filter:"%s", paging:"%s") {
+		items {
+			type {
+				uri
+			}
+			state
+			reason
+			details
+			turbot {
+				id
+				resourceId
+				createTimestamp
+				updateTimestamp
+			}
+		}
+		paging {
+			next
+		}
+	}
+}`, filter, paging)
+}
+
+// resource watch
+func createResourceWatchMutation() string {
+	return `mutation CreateWatch($input: CreateWatchInput!) {
+	watch: createWatch(input: $input) {
+		turbot {
+			id
+			resourceId
+			favoriteId
+		}
+	}
+}`
+}
+
+func readResourceWatchQuery(id string) string {
+	return fmt.Sprintf(`{
+	watch(id:"%s") {
+		action
+		filters
+		turbot {
+			id
+			resourceId
+			favoriteId
+		}
+	}
+}`, id)
+}
+
+func updateResourceWatchMutation() string {
+	return `mutation UpdateWatch($input: UpdateWatchInput!) {
+	watch: updateWatch(input: $input) {
+		turbot {
+			id
+			resourceId
+			favoriteId
+		}
+	}
+}`
+}
+
+func deleteResourceWatchMutation() string {
+	return `mutation DeleteWatch($input: DeleteWatchInput!) {
+	watch: deleteWatch(input: $input) {
+		turbot {
+			id
+		}
+	}
+}`
+}
+
 // get turbot workspace version
 func (client *Client) GetTurbotWorkspaceVersion() (*semver.Version, error) {
 	query := readPolicyValueQuery("tmod:@turbot/turbot#/policy/types/workspaceVersion", "tmod:@turbot/turbot#/")
 	responseData := &PolicyValueResponse{}
 	// execute api call
 	if err := client.doRequest(query, nil, responseData); err != nil {
-		return nil, fmt.Errorf("error reading policy value: %s", err.Error())
+		return nil, fmt.Errorf("error reading policy value: %w", err)
 	}
 	// convert interface {} to string
 	versionValue := fmt.Sprintf("%v", responseData.PolicyValue.Value)
 	// convert version value to semver value
 	version, err := semver.New(versionValue)
 	if err != nil {
-		return nil, fmt.Errorf("error reading turbot workspace version value: %s", err.Error())
+		return nil, fmt.Errorf("error reading turbot workspace version value: %w", err)
 	}
 	return version, nil
 }
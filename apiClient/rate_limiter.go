@@ -0,0 +1,70 @@
+package apiClient
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket limiting the rate of requests sent to the API
+// Terraform runs operations in parallel, so the limiter is shared by all goroutines using the client
+type rateLimiter struct {
+	lock sync.Mutex
+	// tokens added per second
+	rate float64
+	// the maximum number of tokens - the number of requests which may be sent at once after a quiet period
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// returns nil (no limit) if requestsPerSecond is not greater than zero
+// the burst is one second of requests, so a limit below one request per second never sends requests together
+func newRateLimiter(requestsPerSecond float64) *rateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	burst := math.Max(1, math.Floor(requestsPerSecond))
+	return &rateLimiter{
+		rate:   requestsPerSecond,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// wait until a request may be sent, or the context is cancelled
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	delay := l.reserve(time.Now())
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// take a token, returning how long to wait before it is available
+// the tokens may go negative, so concurrent requests are queued in the order they reserve a token
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = math.Min(l.burst, l.tokens+elapsed.Seconds()*l.rate)
+		l.last = now
+	}
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
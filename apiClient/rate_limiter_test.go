@@ -0,0 +1,57 @@
+package apiClient
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Reserve(t *testing.T) {
+	limiter := newRateLimiter(2)
+	now := limiter.last
+
+	// the burst is one second of requests
+	assert.Equal(t, time.Duration(0), limiter.reserve(now))
+	assert.Equal(t, time.Duration(0), limiter.reserve(now))
+	// then requests are queued, half a second apart
+	assert.Equal(t, 500*time.Millisecond, limiter.reserve(now))
+	assert.Equal(t, time.Second, limiter.reserve(now))
+	// tokens are added as time passes, up to the burst
+	assert.Equal(t, 500*time.Millisecond, limiter.reserve(now.Add(time.Second)))
+	assert.Equal(t, time.Duration(0), limiter.reserve(now.Add(10*time.Second)))
+	assert.Equal(t, time.Duration(0), limiter.reserve(now.Add(10*time.Second)))
+	assert.Equal(t, 500*time.Millisecond, limiter.reserve(now.Add(10*time.Second)))
+}
+
+func TestRateLimiter_NoLimit(t *testing.T) {
+	assert.Nil(t, newRateLimiter(0))
+	// a nil limiter never waits
+	var limiter *rateLimiter
+	assert.NoError(t, limiter.wait(context.Background()))
+}
+
+func TestRateLimiter_Concurrent(t *testing.T) {
+	limiter := newRateLimiter(100)
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 150; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, limiter.wait(context.Background()))
+		}()
+	}
+	wg.Wait()
+	// 100 requests are sent at once, the remaining 50 over half a second
+	assert.True(t, time.Since(start) >= 450*time.Millisecond, "requests were not rate limited")
+}
+
+func TestRateLimiter_Cancelled(t *testing.T) {
+	limiter := newRateLimiter(1)
+	assert.NoError(t, limiter.wait(context.Background()))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, limiter.wait(ctx))
+}
@@ -15,20 +15,56 @@ func (client *Client) CreateResource(input map[string]interface{}) (*TurbotResou
 	}
 	// execute api call
 	if err := client.doRequest(query, variables, responseData); err != nil {
-		return nil, fmt.Errorf("error creating resource: %s", err.Error())
+		return nil, fmt.Errorf("error creating resource: %w", err)
 	}
 	return &responseData.Resource.Turbot, nil
 }
 
 // properties is a map of terraform property name to turbot property path - it is used to add 'get' resolvers to the query
+// concurrent reads are batched into a single query (see resourceBatcher)
 func (client *Client) ReadResource(resourceAka string, properties map[string]string) (*Resource, error) {
+	if client.batcher != nil {
+		return client.batcher.readResource(resourceAka, properties)
+	}
+	return client.readResource(resourceAka, properties)
+}
+
+// read only the given property paths of a resource, e.g. "settings.connection.region"
+// each path is fetched with its own 'get' resolver, and the results are assembled into the nested resource data,
+// so server-populated properties alongside the requested ones are not returned
+func (client *Client) ReadResourcePaths(resourceAka string, paths []string) (*Resource, error) {
+	if len(paths) == 0 {
+		return client.ReadResource(resourceAka, nil)
+	}
+	// paths may contain characters which are not valid in a graphql alias, so alias each path by its index
+	properties := map[string]string{}
+	for i, path := range paths {
+		properties[propertyPathAlias(i)] = path
+	}
+	resource, err := client.ReadResource(resourceAka, properties)
+	if err != nil {
+		return nil, err
+	}
+	data := map[string]interface{}{}
+	for i, path := range paths {
+		helpers.SetPropertyPath(data, path, resource.Data[propertyPathAlias(i)])
+	}
+	resource.Data = data
+	return resource, nil
+}
+
+func propertyPathAlias(index int) string {
+	return fmt.Sprintf("path%d", index)
+}
+
+func (client *Client) readResource(resourceAka string, properties map[string]string) (*Resource, error) {
 	var propertiesArray = []interface{}{properties}
 	query := readResourceQuery(resourceAka, propertiesArray)
 	var responseData = &ReadResourceResponse{}
 
 	// execute api call
 	if err := client.doRequest(query, nil, responseData); err != nil {
-		return nil, fmt.Errorf("error reading resource: %s", err.Error())
+		return nil, fmt.Errorf("error reading resource: %w", err)
 	}
 
 	resource, err := client.AssignResourceResults(responseData.Resource, properties)
@@ -45,7 +81,7 @@ func (client *Client) ReadFullResource(resourceAka string) (*Resource, error) {
 
 	// execute api call
 	if err := client.doRequest(query, nil, responseData); err != nil {
-		return nil, fmt.Errorf("error reading resource: %s", err.Error())
+		return nil, fmt.Errorf("error reading resource: %w", err)
 	}
 
 	resource, err := client.AssignResourceResults(responseData.Resource, nil)
@@ -57,9 +93,10 @@ func (client *Client) ReadFullResource(resourceAka string) (*Resource, error) {
 }
 
 // read a resource including all properties, then convert into a 'serializable' resource, consisting of simple types and string maps
-func (client *Client) ReadSerializableResource(resourceAka string) (*SerializableResource, error) {
+// if properties is not empty, only these properties are included in the data
+func (client *Client) ReadSerializableResource(resourceAka string, properties []string) (*SerializableResource, error) {
 	// read the resource, passing an empty string as the property path in the properties map to force a full read
-	properties := []interface{}{
+	queryProperties := []interface{}{
 		map[string]string{
 			"data": "",
 			"akas": "turbot.akas",
@@ -67,19 +104,28 @@ func (client *Client) ReadSerializableResource(resourceAka string) (*Serializabl
 		},
 	}
 
-	query := readResourceQuery(resourceAka, properties)
+	query := readResourceQuery(resourceAka, queryProperties)
 	var responseData = &ReadSerializableResourceResponse{}
 
 	// execute api call
 	err := client.doRequest(query, nil, responseData)
 	if err != nil {
-		return nil, fmt.Errorf("error reading resource: %s", err.Error())
+		return nil, fmt.Errorf("error reading resource: %w", err)
 	}
 	resource := responseData.Resource
 
 	// convert the data to JSON
 	// (NOTE: remove the 'turbot' properties as this has been read separately)
 	delete(resource.Data, "turbot")
+	if len(properties) > 0 {
+		selectedData := map[string]interface{}{}
+		for _, property := range properties {
+			if value, ok := resource.Data[property]; ok {
+				selectedData[property] = value
+			}
+		}
+		resource.Data = selectedData
+	}
 	dataJson, err := helpers.MapToJsonString(resource.Data)
 	if err != nil {
 		return nil, err
@@ -99,20 +145,52 @@ func (client *Client) ReadSerializableResource(resourceAka string) (*Serializabl
 		Akas:     resource.Akas,
 		Metadata: turbotStringMap["custom"],
 	}
+	if err := mapstructure.Decode(resource.Turbot, &result.TurbotMetadata); err != nil {
+		return nil, err
+	}
 
 	return &result, nil
 }
 
-func (client *Client) ReadResourceList(filter string, properties map[string]string) ([]Resource, error) {
-	query := readResourceListQuery(filter, properties)
-	var responseData = &ReadResourceListResponse{}
+// read the create and update schemas of a resource type
+func (client *Client) ReadResourceTypeSchema(resourceTypeAka string) (*ResourceSchema, error) {
+	query := readResourceQuery(resourceTypeAka, []interface{}{"createSchema", "updateSchema"})
+	var responseData = &ResourceSchema{}
 
 	// execute api call
 	if err := client.doRequest(query, nil, responseData); err != nil {
-		return nil, fmt.Errorf("error fetching resource list: %s", err.Error())
+		return nil, fmt.Errorf("error reading resource type schema: %w", err)
 	}
+	return responseData, nil
+}
 
-	return responseData.ResourceList.Items, nil
+// read all resources matching the filter, fetching each page of results in turn
+// the selected properties are written into the Data map of each resource
+func (client *Client) ReadResourceList(filter string, properties map[string]string) ([]Resource, error) {
+	var resources []Resource
+	paging := ""
+	for {
+		query := readResourceListQuery(filter, properties, paging)
+		var responseData = &ReadResourceListResponse{}
+
+		// execute api call
+		if err := client.doRequest(query, nil, responseData); err != nil {
+			return nil, fmt.Errorf("error fetching resource list: %w", err)
+		}
+		for _, item := range responseData.ResourceList.Items {
+			resource, err := client.AssignResourceResults(item, properties)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, *resource)
+		}
+		// if there are no more pages, we are done
+		paging = responseData.ResourceList.Paging.Next
+		if paging == "" {
+			break
+		}
+	}
+	return resources, nil
 }
 
 func (client *Client) UpdateResource(input map[string]interface{}) (*TurbotResourceMetadata, error) {
@@ -123,11 +201,19 @@ func (client *Client) UpdateResource(input map[string]interface{}) (*TurbotResou
 	}
 	// execute api call
 	if err := client.doRequest(query, variables, responseData); err != nil {
-		return nil, fmt.Errorf("error updating resource: %s", err.Error())
+		return nil, fmt.Errorf("error updating resource: %w", err)
 	}
 	return &responseData.Resource.Turbot, nil
 }
 
+// set the tags of any type of resource - tags which are not included are unchanged, so to remove a tag set it to nil
+func (client *Client) UpdateResourceTags(id string, tags map[string]interface{}) (*TurbotResourceMetadata, error) {
+	return client.UpdateResource(map[string]interface{}{
+		"id":   id,
+		"tags": tags,
+	})
+}
+
 func (client *Client) DeleteResource(aka string) error {
 	query := deleteResourceMutation()
 	// we do not care about the response
@@ -141,7 +227,7 @@ func (client *Client) DeleteResource(aka string) error {
 
 	// execute api call
 	if err := client.doRequest(query, variables, &responseData); err != nil {
-		return fmt.Errorf("error deleting resource: %s", err.Error())
+		return fmt.Errorf("error deleting resource: %w", err)
 	}
 	return nil
 }
@@ -159,6 +245,7 @@ func (client *Client) ResourceExists(id string) (bool, error) {
 	return exists, nil
 }
 
+// the akas are read with ReadResource, so concurrent lookups are batched with other resource reads
 func (client *Client) GetResourceAkas(resourceAka string) ([]string, error) {
 	resource, err := client.ReadResource(resourceAka, nil)
 	if err != nil {
@@ -176,24 +263,28 @@ func (client *Client) GetResourceAkas(resourceAka string) ([]string, error) {
 // assign the ReadResource results into a Resource object, based on the 'properties' map
 func (client *Client) AssignResourceResults(responseData interface{}, properties map[string]string) (*Resource, error) {
 	var resource Resource
+	response, err := getResponseObject(responseData)
+	if err != nil {
+		return nil, err
+	}
 	// initialise map
 	resource.Data = make(map[string]interface{})
 	// convert turbot property to structure
-	if err := mapstructure.Decode(responseData.(map[string]interface{})["turbot"], &resource.Turbot); err != nil {
+	if err := mapstructure.Decode(response["turbot"], &resource.Turbot); err != nil {
 		return nil, err
 	}
 	// convert type property
-	if err := mapstructure.Decode(responseData.(map[string]interface{})["type"], &resource.Type); err != nil {
+	if err := mapstructure.Decode(response["type"], &resource.Type); err != nil {
 		return nil, err
 	}
 	// convert object property to structure
-	if err := mapstructure.Decode(responseData.(map[string]interface{})["data"], &resource.Data); err != nil {
+	if err := mapstructure.Decode(response["data"], &resource.Data); err != nil {
 		return nil, err
 	}
 	// write properties into a map
 	if properties != nil {
 		for p := range properties {
-			resource.Data[p] = responseData.(map[string]interface{})[p]
+			resource.Data[p] = response[p]
 		}
 	}
 
@@ -0,0 +1,83 @@
+package apiClient
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCreateResource(t *testing.T) {
+	client, server := newFixtureClient(t, "create_resource")
+	defer server.Close()
+
+	metadata, err := client.CreateResource(map[string]interface{}{
+		"parent": "tmod:@turbot/turbot#/",
+		"type":   "tmod:@turbot/turbot#/resource/types/folder",
+		"data": map[string]interface{}{
+			"title":       "provider_test",
+			"description": "test resource",
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "190233581346752", metadata.Id)
+	assert.Equal(t, "162167737977850", metadata.ParentId)
+	assert.Equal(t, []string{"tmod:@turbot/turbot#/folder/190233581346752"}, metadata.Akas)
+	assert.Equal(t, map[string]interface{}{"env": "test"}, metadata.Tags)
+}
+
+func TestCreateResource_ValidationError(t *testing.T) {
+	client, server := newFixtureClient(t, "create_resource_validation_error")
+	defer server.Close()
+
+	_, err := client.CreateResource(map[string]interface{}{"data": map[string]interface{}{"title": 123}})
+	assert.Error(t, err)
+	assert.True(t, FailedValidationError(err))
+	assert.Equal(t, "error creating resource: graphql: Data validation failed. data.title: should be string (request id: 5f3e1c0a-create)", err.Error())
+}
+
+func TestReadResource_NotFound(t *testing.T) {
+	client, server := newFixtureClient(t, "read_resource_not_found")
+	defer server.Close()
+
+	_, err := client.ReadResource("190233581346752", nil)
+	assert.Error(t, err)
+	assert.True(t, NotFoundError(err))
+
+	// ResourceExists reads the resource again - the fixture only has one interaction, so use a new server
+	client, server = newFixtureClient(t, "read_resource_not_found")
+	defer server.Close()
+	exists, err := client.ResourceExists("190233581346752")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestReadResourceList_Paging(t *testing.T) {
+	client, server := newFixtureClient(t, "read_resource_list_paging")
+	defer server.Close()
+
+	resources, err := client.ReadResourceList("resourceType:folder", map[string]string{"title": "title"})
+	assert.NoError(t, err)
+	var titles, ids []string
+	for _, resource := range resources {
+		titles = append(titles, resource.Data["title"].(string))
+		ids = append(ids, resource.Turbot.Id)
+	}
+	assert.Equal(t, []string{"Folder 1", "Folder 2", "Folder 3"}, titles)
+	assert.Equal(t, []string{"190233581346752", "190233581346753", "190233581346754"}, ids)
+	assert.Equal(t, "tmod:@turbot/turbot#/resource/types/folder", resources[2].Type.Uri)
+}
+
+func TestReadResourcePaths(t *testing.T) {
+	client, server := newFixtureClient(t, "read_resource_paths")
+	defer server.Close()
+
+	resource, err := client.ReadResourcePaths("190233581346752", []string{"title", "settings.connection.region", "settings.connection.port"})
+	assert.NoError(t, err)
+	expected := map[string]interface{}{
+		"title": "provider_test",
+		"settings": map[string]interface{}{
+			"connection": map[string]interface{}{"region": "us-east-1", "port": nil},
+		},
+	}
+	assert.Equal(t, expected, resource.Data)
+	assert.Equal(t, "190233581346752", resource.Turbot.Id)
+}
@@ -0,0 +1,61 @@
+package apiClient
+
+import (
+	"fmt"
+)
+
+// a resource watch sends the events of a resource which match its filters to an action, e.g. to notify a team
+func (client *Client) CreateResourceWatch(input map[string]interface{}) (*ResourceWatch, error) {
+	query := createResourceWatchMutation()
+	responseData := &ResourceWatchResponse{}
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	// execute api call
+	if err := client.doRequest(query, variables, responseData); err != nil {
+		return nil, fmt.Errorf("error creating watch: %w", err)
+	}
+	return &responseData.Watch, nil
+}
+
+func (client *Client) ReadResourceWatch(id string) (*ResourceWatch, error) {
+	query := readResourceWatchQuery(id)
+	responseData := &ResourceWatchResponse{}
+
+	// execute api call
+	if err := client.doRequest(query, nil, responseData); err != nil {
+		return nil, fmt.Errorf("error reading watch: %w", err)
+	}
+	return &responseData.Watch, nil
+}
+
+func (client *Client) UpdateResourceWatch(input map[string]interface{}) (*ResourceWatch, error) {
+	query := updateResourceWatchMutation()
+	responseData := &ResourceWatchResponse{}
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	// execute api call
+	if err := client.doRequest(query, variables, responseData); err != nil {
+		return nil, fmt.Errorf("error updating watch: %w", err)
+	}
+	return &responseData.Watch, nil
+}
+
+func (client *Client) DeleteResourceWatch(id string) error {
+	query := deleteResourceWatchMutation()
+	var responseData interface{}
+	variables := map[string]interface{}{
+		"input": map[string]string{
+			"id": id,
+		},
+	}
+
+	// execute api call
+	if err := client.doRequest(query, variables, &responseData); err != nil {
+		return fmt.Errorf("error deleting watch: %w", err)
+	}
+	return nil
+}
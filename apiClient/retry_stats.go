@@ -0,0 +1,50 @@
+package apiClient
+
+import (
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+	"sync"
+)
+
+// the requests of an operation which succeeded only after retrying, and the total retries they needed
+// requests which failed are reported by their error
+type operationRetries struct {
+	lock     sync.Mutex
+	requests int
+	retries  int
+}
+
+func (r *operationRetries) record(retries int) {
+	// the client is not counting the retries of an operation
+	if r == nil || retries == 0 {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.requests++
+	r.retries += retries
+}
+
+func (r *operationRetries) get() (requests, retries int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.requests, r.retries
+}
+
+// ForOperation returns a copy of the client which counts the retries of its requests, so an operation which was
+// slowed by throttling or transient failures can be reported at its end (see LogRetries)
+// batched reads are shared by several operations, so their retries are not counted
+func (client *Client) ForOperation() *Client {
+	operationClient := *client
+	operationClient.retries = &operationRetries{}
+	return &operationClient
+}
+
+// LogRetries logs the number of requests of the operation which succeeded only after retrying, if any
+func (client *Client) LogRetries(operation string) {
+	if client.retries == nil {
+		return
+	}
+	if requests, retries := client.retries.get(); retries > 0 {
+		client.Logf(helpers.LogTransport, "[INFO] %s: %d requests to the Turbot API succeeded only after retrying (%d retries), the workspace may be throttling or overloaded", operation, requests, retries)
+	}
+}
@@ -33,7 +33,7 @@ func (client *Client) ReadSamlDirectory(id string) (*SamlDirectory, error) {
 
 	// execute api call
 	if err := client.doRequest(query, nil, responseData); err != nil {
-		return nil, fmt.Errorf("error saml directory: %s", err.Error())
+		return nil, fmt.Errorf("error saml directory: %w", err)
 	}
 	return &responseData.Resource, nil
 }
@@ -47,7 +47,7 @@ func (client *Client) CreateSamlDirectory(input map[string]interface{}) (*SamlDi
 
 	// execute api call
 	if err := client.doRequest(query, variables, responseData); err != nil {
-		return nil, fmt.Errorf("error creating saml directory: %s", err.Error())
+		return nil, fmt.Errorf("error creating saml directory: %w", err)
 	}
 	return &responseData.Resource, nil
 }
@@ -61,7 +61,7 @@ func (client *Client) UpdateSamlDirectory(input map[string]interface{}) (*SamlDi
 
 	// execute api call
 	if err := client.doRequest(query, variables, responseData); err != nil {
-		return nil, fmt.Errorf("error updating saml directory: %s", err.Error())
+		return nil, fmt.Errorf("error updating saml directory: %w", err)
 	}
 	return &responseData.Resource, nil
 }
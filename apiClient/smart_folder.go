@@ -13,7 +13,7 @@ func (client *Client) CreateSmartFolder(input map[string]interface{}) (*SmartFol
 
 	// execute api call
 	if err := client.doRequest(query, variables, responseData); err != nil {
-		return nil, fmt.Errorf("error creating smart folder: %s", err.Error())
+		return nil, fmt.Errorf("error creating smart folder: %w", err)
 	}
 	return &responseData.SmartFolder, nil
 }
@@ -24,8 +24,9 @@ func (client *Client) ReadSmartFolder(id string) (*SmartFolder, error) {
 
 	// execute api call
 	if err := client.doRequest(query, nil, responseData); err != nil {
-		return nil, fmt.Errorf("error reading smart folder: %s", err.Error())
+		return nil, fmt.Errorf("error reading smart folder: %w", err)
 	}
+	responseData.SmartFolder.PolicySettingCount = responseData.PolicySettings.Metadata.Stats.Total
 	return &responseData.SmartFolder, nil
 }
 
@@ -37,7 +38,7 @@ func (client *Client) UpdateSmartFolder(input map[string]interface{}) (*SmartFol
 	}
 	// execute api call
 	if err := client.doRequest(query, variables, responseData); err != nil {
-		return nil, fmt.Errorf("error updating smart folder: %s", err.Error())
+		return nil, fmt.Errorf("error updating smart folder: %w", err)
 	}
 	return &responseData.SmartFolder, nil
 }
@@ -0,0 +1,269 @@
+package apiClient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+	"io/ioutil"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// response headers which may contain the id the Turbot API assigned to a request
+var requestIdHeaders = []string{"X-Turbot-Request-Id", "X-Request-Id", "X-Amzn-RequestId"}
+
+// throttled (429) and transient (502, 503, 504) failures are retried, waiting for the delay requested by the server
+// if one is given (Retry-After header or retryAfter graphql extension), otherwise using exponential backoff
+// mutations are only retried if they were throttled or could not be sent (see shouldRetry)
+const (
+	defaultMaxRetries   = 5
+	defaultRetryWaitMin = 1 * time.Second
+	defaultRetryWaitMax = 60 * time.Second
+)
+
+var retryableStatusCodes = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// RetryPolicy controls how failed requests are retried
+type RetryPolicy struct {
+	MaxRetries int
+	// the minimum and maximum wait between retries - the wait doubles with each retry
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:   defaultMaxRetries,
+		RetryWaitMin: defaultRetryWaitMin,
+		RetryWaitMax: defaultRetryWaitMax,
+	}
+}
+
+type contextKey string
+
+const responseInfoKey contextKey = "responseInfo"
+
+// details of the http response for a graphql request
+// doRequest adds an empty responseInfo to the request context and the transport populates it
+// (the graphql client does not give us access to the http response)
+type responseInfo struct {
+	// set by doRequest if the request is a mutation, which must not be sent twice
+	Mutation   bool
+	StatusCode int
+	RequestId  string
+	// the 'code' extension of the first graphql error in the response, if any
+	// (the graphql client only returns the message of the first error)
+	ErrorCode string
+	// the number of times the request was retried before this response
+	Retries int
+	// if CaptureBody is set, the transport stores the response body, so it can be logged
+	CaptureBody bool
+	Body        []byte
+	// set by doRequest if the request is a read of a watch, which records the response
+	Watch *Watch
+}
+
+// the connection pool shared by all clients - with a high -parallelism, the default of 2 idle connections per host
+// causes connections to be closed and reopened for most requests
+var sharedTransport = &http.Transport{
+	Proxy: http.ProxyFromEnvironment,
+	DialContext: (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}).DialContext,
+	ForceAttemptHTTP2:     true,
+	MaxIdleConns:          100,
+	MaxIdleConnsPerHost:   32,
+	IdleConnTimeout:       90 * time.Second,
+	TLSHandshakeTimeout:   10 * time.Second,
+	ExpectContinueTimeout: 1 * time.Second,
+}
+
+// http.RoundTripper used by the graphql client
+type turbotTransport struct {
+	transport   http.RoundTripper
+	retryPolicy RetryPolicy
+	// nil if requests are not rate limited
+	limiter   *rateLimiter
+	logLevels helpers.LogLevels
+}
+
+func newHttpClient(retryPolicy RetryPolicy, limiter *rateLimiter, logLevels helpers.LogLevels) *http.Client {
+	return &http.Client{
+		Transport: &turbotTransport{transport: sharedTransport, retryPolicy: retryPolicy, limiter: limiter, logLevels: logLevels},
+	}
+}
+
+func (t *turbotTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.send(req)
+	attempt := 0
+	for ; shouldRetry(req, res, err) && attempt < t.retryPolicy.MaxRetries; attempt++ {
+		delay := t.retryPolicy.getDelay(res, attempt)
+		if err != nil {
+			t.logLevels.Logf(helpers.LogTransport, "[WARN] request to Turbot API failed: %s, retrying in %s (attempt %d of %d)", err.Error(), delay, attempt+1, t.retryPolicy.MaxRetries)
+		} else {
+			t.logLevels.Logf(helpers.LogTransport, "[WARN] Turbot API returned %s, retrying in %s (attempt %d of %d)", res.Status, delay, attempt+1, t.retryPolicy.MaxRetries)
+			res.Body.Close()
+		}
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+		// the request body has been consumed - get a new copy
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		res, err = t.send(req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if info, ok := req.Context().Value(responseInfoKey).(*responseInfo); ok {
+		if info.Watch != nil {
+			if res, err = info.Watch.readResponse(res); err != nil {
+				return nil, err
+			}
+		}
+		info.StatusCode = res.StatusCode
+		info.Retries = attempt
+		if err := readResponseInfo(res, info); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// send the request once the rate limiter allows - retries are rate limited too
+func (t *turbotTransport) send(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.transport.RoundTrip(req)
+}
+
+// read the request id and graphql error code of the response - the request id is read from the response headers,
+// falling back to the graphql response extensions
+func readResponseInfo(res *http.Response, info *responseInfo) error {
+	// read the body and then restore it so the graphql client can decode it
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if info.CaptureBody {
+		info.Body = body
+	}
+
+	var response struct {
+		Errors []struct {
+			Extensions struct {
+				Code string
+			}
+		}
+		Extensions struct {
+			RequestId string
+		}
+	}
+	// ignore errors - the graphql client will report invalid responses
+	json.Unmarshal(body, &response)
+	if len(response.Errors) > 0 {
+		info.ErrorCode = response.Errors[0].Extensions.Code
+	}
+	info.RequestId = response.Extensions.RequestId
+	for _, header := range requestIdHeaders {
+		if requestId := res.Header.Get(header); requestId != "" {
+			info.RequestId = requestId
+			break
+		}
+	}
+	return nil
+}
+
+// retry network errors and throttled or transient failures, unless the request has been cancelled
+// a mutation which failed with a network error or transient failure may still have been applied, so retrying it could
+// apply it twice - it is only retried if it was throttled, or the connection could not be made so it was never sent
+func shouldRetry(req *http.Request, res *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	mutation := isMutationRequest(req)
+	if err != nil {
+		return !mutation || isDialError(err)
+	}
+	if mutation {
+		return res.StatusCode == http.StatusTooManyRequests
+	}
+	return retryableStatusCodes[res.StatusCode]
+}
+
+func isMutationRequest(req *http.Request) bool {
+	info, ok := req.Context().Value(responseInfoKey).(*responseInfo)
+	return ok && info.Mutation
+}
+
+// did the request fail because a connection to the API (or proxy) could not be made
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && (opErr.Op == "dial" || opErr.Op == "proxyconnect")
+}
+
+// get the time to wait before retrying a failed request
+func (p RetryPolicy) getDelay(res *http.Response, attempt int) time.Duration {
+	if res != nil {
+		if delay, ok := getRetryAfter(res); ok {
+			if delay > p.RetryWaitMax {
+				return p.RetryWaitMax
+			}
+			return delay
+		}
+	}
+	// exponential backoff
+	delay := time.Duration(math.Pow(2, float64(attempt))) * p.RetryWaitMin
+	if delay > p.RetryWaitMax {
+		return p.RetryWaitMax
+	}
+	return delay
+}
+
+// get the delay requested by the server, from either the Retry-After header (seconds or http date)
+// or the retryAfter (seconds) graphql response extension
+func getRetryAfter(res *http.Response) (time.Duration, bool) {
+	if retryAfter := res.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			if delay := time.Until(date); delay > 0 {
+				return delay, true
+			}
+			return 0, true
+		}
+	}
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return 0, false
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+	var response struct {
+		Extensions struct {
+			RetryAfter *float64
+		}
+	}
+	if json.Unmarshal(body, &response) == nil && response.Extensions.RetryAfter != nil && *response.Extensions.RetryAfter >= 0 {
+		return time.Duration(*response.Extensions.RetryAfter * float64(time.Second)), true
+	}
+	return 0, false
+}
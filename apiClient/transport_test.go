@@ -0,0 +1,184 @@
+package apiClient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/machinebox/graphql"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// use a client with the given retry policy instead of testRetryPolicy
+func setRetryPolicy(client *Client, server *fixtureServer, policy RetryPolicy) {
+	client.Graphql = graphql.NewClient(server.URL, graphql.WithHTTPClient(newHttpClient(policy, nil, nil)))
+}
+
+func TestRoundTrip_TransientErrors(t *testing.T) {
+	client, server := newFixtureClient(t, "read_control_transient_errors")
+	defer server.Close()
+
+	// the 502 and 504 responses are retried
+	control, err := client.ReadControl(`id: "190233581346770"`)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", control.State)
+	assert.Len(t, server.requests, 3)
+}
+
+func TestRoundTrip_RetriesExhausted(t *testing.T) {
+	client, server := newFixtureClient(t, "read_control_retries_exhausted")
+	defer server.Close()
+
+	// the request is sent once, then retried MaxRetries times
+	_, err := client.ReadControl(`id: "190233581346770"`)
+	assert.Error(t, err)
+	assert.Len(t, server.requests, testRetryPolicy.MaxRetries+1)
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+	assert.Equal(t, "5f3e1c0a-unavailable", apiErr.RequestId)
+}
+
+func TestRoundTrip_NoRetries(t *testing.T) {
+	client, server := newFixtureClient(t, "read_control_retries_exhausted")
+	server.interactions = server.interactions[:1]
+	defer server.Close()
+	setRetryPolicy(client, server, RetryPolicy{MaxRetries: 0})
+
+	_, err := client.ReadControl(`id: "190233581346770"`)
+	assert.Error(t, err)
+	assert.Len(t, server.requests, 1)
+}
+
+func TestRoundTrip_RetryAfterHeader(t *testing.T) {
+	client, server := newFixtureClient(t, "read_control_throttled_retry_after")
+	defer server.Close()
+	// the server asks for a wait of 1 second, which is limited to RetryWaitMax - without the header, the wait
+	// would be RetryWaitMin
+	setRetryPolicy(client, server, RetryPolicy{MaxRetries: 1, RetryWaitMin: time.Millisecond, RetryWaitMax: 200 * time.Millisecond})
+
+	start := time.Now()
+	control, err := client.ReadControl(`id: "190233581346770"`)
+	elapsed := time.Since(start)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", control.State)
+	assert.True(t, elapsed >= 200*time.Millisecond, "waited %s", elapsed)
+	assert.True(t, elapsed < time.Second, "waited %s", elapsed)
+}
+
+func TestRoundTrip_RetryAfterExtension(t *testing.T) {
+	client, server := newFixtureClient(t, "read_control_throttled_extension")
+	defer server.Close()
+	setRetryPolicy(client, server, RetryPolicy{MaxRetries: 1, RetryWaitMin: time.Millisecond, RetryWaitMax: time.Second})
+
+	start := time.Now()
+	control, err := client.ReadControl(`id: "190233581346770"`)
+	elapsed := time.Since(start)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", control.State)
+	assert.True(t, elapsed >= 100*time.Millisecond, "waited %s", elapsed)
+}
+
+func TestRoundTrip_MutationNotRetried(t *testing.T) {
+	client, server := newFixtureClient(t, "create_resource_unavailable")
+	defer server.Close()
+
+	// the resource may have been created even though the request failed, so it must not be sent again
+	_, err := client.CreateResource(map[string]interface{}{"data": map[string]interface{}{"title": "provider_test"}})
+	assert.Error(t, err)
+	assert.Len(t, server.requests, 1)
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+}
+
+func TestRoundTrip_MutationThrottled(t *testing.T) {
+	client, server := newFixtureClient(t, "create_resource_throttled")
+	defer server.Close()
+
+	// a throttled request was not applied, so a mutation is retried
+	metadata, err := client.CreateResource(map[string]interface{}{
+		"parent": "tmod:@turbot/turbot#/",
+		"type":   "tmod:@turbot/turbot#/resource/types/folder",
+		"data": map[string]interface{}{
+			"title":       "provider_test",
+			"description": "test resource",
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "190233581346752", metadata.Id)
+	assert.Len(t, server.requests, 2)
+}
+
+func TestShouldRetry(t *testing.T) {
+	request := func(mutation bool) *http.Request {
+		ctx := context.WithValue(context.Background(), responseInfoKey, &responseInfo{Mutation: mutation})
+		req, _ := http.NewRequest(http.MethodPost, "https://example.turbot.com/api/latest/graphql", nil)
+		return req.WithContext(ctx)
+	}
+	response := func(status int) *http.Response {
+		return &http.Response{StatusCode: status}
+	}
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	readErr := &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+
+	type test struct {
+		name     string
+		mutation bool
+		res      *http.Response
+		err      error
+		expected bool
+	}
+	tests := []test{
+		{"query throttled", false, response(http.StatusTooManyRequests), nil, true},
+		{"query unavailable", false, response(http.StatusServiceUnavailable), nil, true},
+		{"query server error", false, response(http.StatusInternalServerError), nil, false},
+		{"query dial error", false, nil, dialErr, true},
+		{"query read error", false, nil, readErr, true},
+		{"mutation throttled", true, response(http.StatusTooManyRequests), nil, true},
+		{"mutation unavailable", true, response(http.StatusServiceUnavailable), nil, false},
+		{"mutation gateway timeout", true, response(http.StatusGatewayTimeout), nil, false},
+		{"mutation dial error", true, nil, dialErr, true},
+		{"mutation read error", true, nil, readErr, false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, shouldRetry(request(test.mutation), test.res, test.err), test.name)
+	}
+}
+
+func TestGetDelay(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 5, RetryWaitMin: time.Second, RetryWaitMax: 10 * time.Second}
+	response := func(header http.Header, body string) *http.Response {
+		return &http.Response{Header: header, Body: ioutil.NopCloser(bytes.NewBufferString(body))}
+	}
+
+	// exponential backoff, limited to RetryWaitMax
+	var delays []time.Duration
+	for attempt := 0; attempt < 5; attempt++ {
+		delays = append(delays, policy.getDelay(nil, attempt))
+	}
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second}, delays)
+
+	// the delay requested by the server takes precedence
+	assert.Equal(t, 3*time.Second, policy.getDelay(response(http.Header{"Retry-After": {"3"}}, ""), 0))
+	assert.Equal(t, 10*time.Second, policy.getDelay(response(http.Header{"Retry-After": {"120"}}, ""), 0))
+	assert.Equal(t, time.Duration(0), policy.getDelay(response(http.Header{"Retry-After": {"Mon, 02 Mar 2020 12:00:00 GMT"}}, ""), 3))
+	assert.Equal(t, 1500*time.Millisecond, policy.getDelay(response(http.Header{}, `{"extensions":{"retryAfter":1.5}}`), 0))
+
+	// an invalid delay falls back to backoff
+	assert.Equal(t, 4*time.Second, policy.getDelay(response(http.Header{"Retry-After": {"soon"}}, "Too Many Requests"), 2))
+	assert.Equal(t, 2*time.Second, policy.getDelay(response(http.Header{}, `{"extensions":{"retryAfter":-1}}`), 1))
+}
+
+func TestGetRetryAfter_RestoresBody(t *testing.T) {
+	res := &http.Response{Header: http.Header{}, Body: ioutil.NopCloser(bytes.NewBufferString(`{"extensions":{"retryAfter":2}}`))}
+	delay, ok := getRetryAfter(res)
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Second, delay)
+	body, _ := ioutil.ReadAll(res.Body)
+	assert.Equal(t, `{"extensions":{"retryAfter":2}}`, string(body))
+}
@@ -23,7 +23,7 @@ func (client *Client) CreateTurbotDirectory(input map[string]interface{}) (*Turb
 
 	// execute api call
 	if err := client.doRequest(query, variables, responseData); err != nil {
-		return nil, fmt.Errorf("error creating turbot directory: %s", err.Error())
+		return nil, fmt.Errorf("error creating turbot directory: %w", err)
 	}
 	return &responseData.Resource, nil
 }
@@ -34,7 +34,7 @@ func (client *Client) ReadTurbotDirectory(id string) (*TurbotDirectory, error) {
 	responseData := &TurbotDirectoryResponse{}
 	// execute api call
 	if err := client.doRequest(query, nil, &responseData); err != nil {
-		return nil, fmt.Errorf("error reading turbot directory: %s", err.Error())
+		return nil, fmt.Errorf("error reading turbot directory: %w", err)
 	}
 	return &responseData.Resource, nil
 }
@@ -48,7 +48,7 @@ func (client *Client) UpdateTurbotDirectory(input map[string]interface{}) (*Turb
 
 	// execute api call
 	if err := client.doRequest(query, variables, &responseData); err != nil {
-		return nil, fmt.Errorf("error updating turbot directory: %s", err.Error())
+		return nil, fmt.Errorf("error updating turbot directory: %w", err)
 	}
 	return &responseData.Resource, nil
 }
@@ -19,9 +19,13 @@ type ReadResourceResponse struct {
 	Resource interface{}
 }
 
+// the items are decoded using AssignResourceResults, so the selected properties can be read
 type ReadResourceListResponse struct {
 	ResourceList struct {
-		Items []Resource
+		Items  []interface{}
+		Paging struct {
+			Next string
+		}
 	}
 }
 
@@ -63,6 +67,8 @@ type SerializableResource struct {
 	Tags     map[string]string
 	Akas     []string
 	Turbot   map[string]string
+	// the turbot metadata, decoded into its typed form
+	TurbotMetadata TurbotResourceMetadata
 }
 
 // Validation response
@@ -89,7 +95,22 @@ type Control struct {
 	Type        struct {
 		Uri string
 	}
-	Turbot map[string]string
+	Turbot TurbotControlMetadata
+}
+
+type ControlCountResponse struct {
+	ControlList struct {
+		Metadata ListMetadata
+	}
+}
+
+type ControlListResponse struct {
+	ControlList struct {
+		Items  []Control
+		Paging struct {
+			Next string
+		}
+	}
 }
 
 // is the validation response successful?
@@ -118,6 +139,15 @@ type FindPolicySettingResponse struct {
 	}
 }
 
+type PolicySettingListResponse struct {
+	PolicySettings struct {
+		Items  []PolicySetting
+		Paging struct {
+			Next string
+		}
+	}
+}
+
 type PolicySetting struct {
 	Type struct {
 		Uri string
@@ -175,6 +205,30 @@ type ModVersionResponse struct {
 	}
 }
 
+type ModVersionPolicyTypesResponse struct {
+	ModVersion struct {
+		PolicyTypes struct {
+			Items []PolicyType
+		}
+	}
+}
+
+// map of peer dependency ("@<org>/<mod>") to the required version range
+type ModVersionDependenciesResponse struct {
+	ModVersion ModVersionDependencies
+}
+
+type ModVersionDependencies struct {
+	PeerDependencies map[string]string
+}
+
+type PolicyType struct {
+	Uri             string
+	Title           string
+	Schema          interface{}
+	DefaultTemplate string
+}
+
 type UninstallModResponse struct {
 	UninstallMod struct {
 		Success bool
@@ -187,6 +241,21 @@ type Mod struct {
 	Version string
 	Parent  string
 	Uri     string
+	Turbot  TurbotResourceMetadata
+}
+
+// Mod registry credential
+type ModRegistryCredentialResponse struct {
+	Resource ModRegistryCredential
+}
+
+type ModRegistryCredential struct {
+	Turbot    TurbotResourceMetadata
+	Title     string
+	Parent    string
+	Registry  string
+	Org       string
+	AccessKey string
 }
 
 // Grant
@@ -200,6 +269,21 @@ type ReadGrantResponse struct {
 	Grant Grant
 }
 
+type FindGrantResponse struct {
+	Grants struct {
+		Items []Grant
+	}
+}
+
+type GrantListResponse struct {
+	Grants struct {
+		Items  []Grant
+		Paging struct {
+			Next string
+		}
+	}
+}
+
 type Grant struct {
 	Turbot            TurbotGrantMetadata
 	PermissionTypeId  string
@@ -221,6 +305,15 @@ type ActiveGrant struct {
 	Turbot TurbotActiveGrantMetadata
 }
 
+type ActiveGrantListResponse struct {
+	ActiveGrants struct {
+		Items  []ActiveGrant
+		Paging struct {
+			Next string
+		}
+	}
+}
+
 // Folder
 type FolderResponse struct {
 	Resource Folder
@@ -255,10 +348,27 @@ type Profile struct {
 	LastLoginTimestamp string
 }
 
+// Group profile - a group of a directory, which permissions may be granted to
+type GroupProfileResponse struct {
+	Resource GroupProfile
+}
+
+type GroupProfile struct {
+	Turbot          TurbotResourceMetadata
+	Title           string
+	Parent          string
+	Status          string
+	GroupProfileId  string
+	DirectoryPoolId string
+}
+
 // Smart folder
 
 type SmartFolderResponse struct {
-	SmartFolder SmartFolder
+	SmartFolder    SmartFolder
+	PolicySettings struct {
+		Metadata ListMetadata
+	}
 }
 
 type SmartFolder struct {
@@ -271,7 +381,10 @@ type SmartFolder struct {
 		Items []struct {
 			Turbot TurbotResourceMetadata
 		}
+		Metadata ListMetadata
 	}
+	// populated from a separate policySettingList query
+	PolicySettingCount int
 }
 
 // Smart folder attachment
@@ -289,6 +402,45 @@ type CreateSmartFolderAttachResponse struct {
 	}
 }
 
+// Policy pack
+type PolicyPackResponse struct {
+	PolicyPack     PolicyPack
+	PolicySettings struct {
+		Metadata ListMetadata
+	}
+	// the created and updated notifications of the policy pack, each of which is a version of it
+	Versions struct {
+		Items    []ResourceVersion
+		Metadata ListMetadata
+	}
+}
+
+type PolicyPack struct {
+	Turbot            TurbotResourceMetadata
+	Title             string
+	Description       string
+	Filters           []string
+	Parent            string
+	AttachedResources struct {
+		Items []struct {
+			Turbot TurbotResourceMetadata
+		}
+		Metadata ListMetadata
+	}
+	// populated from a separate policySettingList query
+	PolicySettingCount int
+	// the number of versions of the policy pack, populated from its notifications
+	Revision int
+	// the most recent versions of the policy pack, most recent first, populated from the same notifications
+	Versions []ResourceVersion
+}
+
+type CreatePolicyPackAttachResponse struct {
+	AttachPolicyPacks struct {
+		Turbot TurbotResourceMetadata
+	}
+}
+
 // Local directory
 type LocalDirectoryResponse struct {
 	Resource LocalDirectory
@@ -350,6 +502,42 @@ type SamlDirectory struct {
 	GroupFilter            string
 }
 
+// Ldap directory
+type LdapDirectoryResponse struct {
+	Resource LdapDirectory
+}
+
+type LdapDirectory struct {
+	Turbot                     TurbotResourceMetadata
+	Title                      string
+	Description                string
+	Parent                     string
+	Status                     string
+	DirectoryType              string
+	ProfileIdTemplate          string
+	GroupProfileIdTemplate     string
+	Url                        string
+	DistinguishedName          string
+	Base                       string
+	UserObjectFilter           string
+	DisabledUserFilter         string
+	UserMatchFilter            string
+	UserSearchFilter           string
+	UserSearchAttributes       []string
+	UserCanonicalNameAttribute string
+	UserEmailAttribute         string
+	UserDisplayNameAttribute   string
+	UserGivenNameAttribute     string
+	UserFamilyNameAttribute    string
+	GroupObjectFilter          string
+	GroupSearchFilter          string
+	GroupSyncFilter            string
+	ConnectivityTestFilter     string
+	TlsEnabled                 bool
+	TlsServerCertificate       string
+	RejectUnauthorized         bool
+}
+
 // Google directory
 type ReadGoogleDirectoryResponse struct {
 	Directory GoogleDirectory
@@ -385,7 +573,109 @@ type TurbotDirectoryResponse struct {
 	Resource TurbotDirectory
 }
 
+// Notification
+type NotificationsResponse struct {
+	Notifications struct {
+		Items []Notification
+	}
+}
+
+type Notification struct {
+	NotificationType string
+	Control          struct {
+		State  string
+		Reason string
+		Type   struct {
+			Uri string
+		}
+	}
+	Turbot TurbotNotificationMetadata
+}
+
+type ResourceActivityResponse struct {
+	Notifications struct {
+		Items []ResourceActivity
+	}
+}
+
+type ResourceActivity struct {
+	NotificationType string
+	Actor            struct {
+		Identity struct {
+			Turbot struct {
+				Id    string
+				Title string
+			}
+		}
+	}
+	Turbot TurbotNotificationMetadata
+}
+
+type ResourceVersionsResponse struct {
+	Notifications struct {
+		Items []ResourceVersion
+	}
+}
+
+type ResourceVersion struct {
+	Turbot   TurbotNotificationMetadata
+	Resource struct {
+		Data map[string]interface{}
+	}
+}
+
+// Resource watch
+type ResourceWatchResponse struct {
+	Watch ResourceWatch
+}
+
+type ResourceWatch struct {
+	Action  string
+	Filters []string
+	Turbot  TurbotWatchMetadata
+}
+
+// Permission types
+type PermissionTypesResponse struct {
+	PermissionTypes struct {
+		Items []PermissionType
+	}
+	PermissionLevels struct {
+		Items []PermissionType
+	}
+}
+
+// a permission type or permission level
+type PermissionType struct {
+	Uri         string
+	Title       string
+	Description string
+}
+
+// Cloud account
+type CloudAccountListResponse struct {
+	ResourceList struct {
+		Items  []CloudAccount
+		Paging struct {
+			Next string
+		}
+	}
+}
+
+type CloudAccount struct {
+	Turbot    TurbotResourceMetadata
+	AccountId string
+	Title     string
+	Parent    string
+}
+
 // Metadata
+type ListMetadata struct {
+	Stats struct {
+		Total int
+	}
+}
+
 type TurbotResourceMetadata struct {
 	Id                string
 	ParentId          string
@@ -422,8 +712,30 @@ type TurbotGrantMetadata struct {
 	ResourceId string
 }
 
+type TurbotControlMetadata struct {
+	Id              string
+	ResourceId      string
+	CreateTimestamp string
+	UpdateTimestamp string
+}
+
+type TurbotNotificationMetadata struct {
+	Id                   string
+	CreateTimestamp      string
+	ResourceId           string
+	ControlId            string
+	ResourceNewVersionId string
+	ResourceOldVersionId string
+}
+
 type TurbotActiveGrantMetadata struct {
 	Id         string
 	GrantId    string
 	ResourceId string
 }
+
+type TurbotWatchMetadata struct {
+	Id         string
+	ResourceId string
+	FavoriteId string
+}
@@ -0,0 +1,135 @@
+package apiClient
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"time"
+)
+
+// the longest time the server may hold a long polling read
+const DefaultWatchWait = 20 * time.Second
+
+// Watch reads the same query repeatedly while waiting for its result to change, e.g. for a control to reach a state
+// each read asks the server to hold the request until the result differs from the previous read (using the
+// Prefer: wait and If-None-Match headers). if the workspace supports long polling, it applies the preference and
+// returns an ETag, and if the result is still unchanged when the wait ends, responds 304 Not Modified - the previous
+// result is then returned again. otherwise each read returns immediately and the caller must wait between reads
+// (see LongPolling). a watch must only be used for one query, by one goroutine
+type Watch struct {
+	// the longest time the server may hold a read
+	Wait        time.Duration
+	etag        string
+	body        []byte
+	longPolling bool
+}
+
+func NewWatch() *Watch {
+	return &Watch{Wait: DefaultWatchWait}
+}
+
+// was the previous read of the watch held by the server - if false, the caller should wait between reads
+func (w *Watch) LongPolling() bool {
+	return w != nil && w.longPolling
+}
+
+func (w *Watch) setHeaders(header http.Header) {
+	// the wait is in whole seconds - round up, so a short wait does not become 0
+	header.Set("Prefer", fmt.Sprintf("wait=%d", int(math.Ceil(w.Wait.Seconds()))))
+	if w.etag != "" {
+		header.Set("If-None-Match", w.etag)
+	}
+}
+
+// record the ETag and body of the response to a read, so the next read is only answered when the result changes
+// if the result has not changed since the previous read (304 Not Modified), the response is replaced by the previous one
+func (w *Watch) readResponse(res *http.Response) (*http.Response, error) {
+	if res.StatusCode == http.StatusNotModified && w.body != nil {
+		res.Body.Close()
+		res.StatusCode = http.StatusOK
+		res.Status = "200 OK"
+		res.Body = ioutil.NopCloser(bytes.NewReader(w.body))
+		res.ContentLength = int64(len(w.body))
+		return res, nil
+	}
+	w.reset()
+	if res.StatusCode != http.StatusOK {
+		return res, nil
+	}
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+	// the workspace does not support long polling - the caller falls back to waiting between reads
+	if res.Header.Get("Preference-Applied") == "" || res.Header.Get("ETag") == "" {
+		return res, nil
+	}
+	w.longPolling = true
+	w.etag = res.Header.Get("ETag")
+	w.body = body
+	return res, nil
+}
+
+// forget the previous read, e.g. if it failed, so the next read is answered immediately
+func (w *Watch) reset() {
+	w.longPolling = false
+	w.etag = ""
+	w.body = nil
+}
+
+// read a control, waiting for it to change since the previous read of the watch
+// if w is nil, this is the same as ReadControl
+func (client *Client) WatchControl(w *Watch, args string) (*Control, error) {
+	query := readControlQuery(args)
+	var responseData = &ReadControlResponse{}
+
+	// execute api call
+	if err := client.doWatchedRequest(w, query, nil, responseData); err != nil {
+		return nil, fmt.Errorf("error reading control: %w", err)
+	}
+	return &responseData.Control, nil
+}
+
+// read a resource, waiting for it to change since the previous read of the watch
+// reads of a watch are never batched. if w is nil, this is the same as ReadResource
+func (client *Client) WatchResource(w *Watch, resourceAka string, properties map[string]string) (*Resource, error) {
+	if w == nil {
+		return client.ReadResource(resourceAka, properties)
+	}
+	query := readResourceQuery(resourceAka, []interface{}{properties})
+	var responseData = &ReadResourceResponse{}
+
+	// execute api call
+	if err := client.doWatchedRequest(w, query, nil, responseData); err != nil {
+		return nil, fmt.Errorf("error reading resource: %w", err)
+	}
+	return client.AssignResourceResults(responseData.Resource, properties)
+}
+
+// read the first page of resources matching the filter, waiting for it to change since the previous read of the watch
+// if w is nil, all pages are read, as ReadResourceList
+func (client *Client) WatchResourceList(w *Watch, filter string, properties map[string]string) ([]Resource, error) {
+	if w == nil {
+		return client.ReadResourceList(filter, properties)
+	}
+	query := readResourceListQuery(filter, properties, "")
+	var responseData = &ReadResourceListResponse{}
+
+	// execute api call
+	if err := client.doWatchedRequest(w, query, nil, responseData); err != nil {
+		return nil, fmt.Errorf("error fetching resource list: %w", err)
+	}
+	var resources []Resource
+	for _, item := range responseData.ResourceList.Items {
+		resource, err := client.AssignResourceResults(item, properties)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, *resource)
+	}
+	return resources, nil
+}
@@ -0,0 +1,81 @@
+package apiClient
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestWatchControl_LongPoll(t *testing.T) {
+	client, server := newFixtureClient(t, "watch_control_long_poll")
+	defer server.Close()
+	watch := NewWatch()
+	watch.Wait = 1500 * time.Millisecond
+
+	control, err := client.WatchControl(watch, `id: "190233581346770"`)
+	assert.NoError(t, err)
+	assert.Equal(t, "tbd", control.State)
+	assert.True(t, watch.LongPolling())
+
+	// the result has not changed - the previous control is returned again
+	control, err = client.WatchControl(watch, `id: "190233581346770"`)
+	assert.NoError(t, err)
+	assert.Equal(t, "tbd", control.State)
+	assert.True(t, watch.LongPolling())
+
+	control, err = client.WatchControl(watch, `id: "190233581346770"`)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", control.State)
+
+	// the wait is rounded up to whole seconds, and each read waits for a change since the previous one
+	assert.Equal(t, "wait=2", server.requests[0].Header.Get("Prefer"))
+	assert.Equal(t, "", server.requests[0].Header.Get("If-None-Match"))
+	assert.Equal(t, `"1"`, server.requests[1].Header.Get("If-None-Match"))
+	assert.Equal(t, `"1"`, server.requests[2].Header.Get("If-None-Match"))
+}
+
+func TestWatchControl_NoLongPoll(t *testing.T) {
+	client, server := newFixtureClient(t, "watch_control_no_long_poll")
+	defer server.Close()
+	watch := NewWatch()
+
+	control, err := client.WatchControl(watch, `id: "190233581346770"`)
+	assert.NoError(t, err)
+	assert.Equal(t, "tbd", control.State)
+	// the workspace did not apply the wait preference - the caller must wait between reads
+	assert.False(t, watch.LongPolling())
+
+	control, err = client.WatchControl(watch, `id: "190233581346770"`)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", control.State)
+	assert.Equal(t, "", server.requests[1].Header.Get("If-None-Match"))
+}
+
+func TestWatchControl_Error(t *testing.T) {
+	client, server := newFixtureClient(t, "watch_control_error")
+	defer server.Close()
+	watch := NewWatch()
+
+	_, err := client.WatchControl(watch, `id: "190233581346770"`)
+	assert.NoError(t, err)
+	assert.True(t, watch.LongPolling())
+
+	_, err = client.WatchControl(watch, `id: "190233581346770"`)
+	assert.Error(t, err)
+	// the failed read is forgotten, so the next read is answered immediately
+	assert.False(t, watch.LongPolling())
+
+	control, err := client.WatchControl(watch, `id: "190233581346770"`)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", control.State)
+	assert.Equal(t, "", server.requests[2].Header.Get("If-None-Match"))
+}
+
+func TestWatchControl_NilWatch(t *testing.T) {
+	client, server := newFixtureClient(t, "read_control")
+	defer server.Close()
+
+	_, err := client.WatchControl(nil, `uri: "tmod:@turbot/turbot#/control/types/modInstalled", resourceId: "190233581346760"`)
+	assert.NoError(t, err)
+	assert.Equal(t, "", server.requests[0].Header.Get("Prefer"))
+}
@@ -1,9 +1,11 @@
 package helpers
 
 import (
+	"bytes"
 	"encoding/json"
 	"github.com/stretchr/testify/assert"
 	"log"
+	"os"
 	"testing"
 )
 
@@ -65,6 +67,13 @@ func TestRemoveProperties(t *testing.T) {
 	}
 }
 
+func TestRemovePropertiesFromMap_DoesNotModifyInput(t *testing.T) {
+	properties := map[string]string{"c": "C", "d": "D"}
+	result := RemovePropertiesFromMap(properties, []string{"c"})
+	assert.Equal(t, map[string]string{"d": "D"}, result)
+	assert.Equal(t, map[string]string{"c": "C", "d": "D"}, properties)
+}
+
 func TestGetNullProperties(t *testing.T) {
 	type test struct {
 		name       string
@@ -184,3 +193,418 @@ func TestGetNullProperties(t *testing.T) {
 		assert.ObjectsAreEqual(test.expected, excluded)
 	}
 }
+
+func TestValidateJsonSchema(t *testing.T) {
+	type test struct {
+		name     string
+		data     string
+		schema   string
+		expected []string
+	}
+	schema := `{
+	"allOf": [
+		{
+			"$ref": "#/definitions/folder"
+		},
+		{
+			"type": "object",
+			"properties": {
+				"title": {
+					"type": "string",
+					"maxLength": 10
+				},
+				"count": {
+					"type": "integer",
+					"minimum": 1
+				},
+				"mode": {
+					"type": "string",
+					"enum": ["check", "enforce"]
+				},
+				"regions": {
+					"type": "array",
+					"items": {
+						"type": "string"
+					}
+				}
+			},
+			"required": ["title"],
+			"additionalProperties": false
+		}
+	]
+}`
+	tests := []test{
+		test{
+			"Valid data",
+			`{"title": "folder", "count": 2, "mode": "check", "regions": ["us-east-1"]}`,
+			schema,
+			nil,
+		},
+		test{
+			"Missing required property",
+			`{"count": 2}`,
+			schema,
+			[]string{"data.title: required property is missing"},
+		},
+		test{
+			"Wrong types",
+			`{"title": 1, "count": 1.5, "regions": ["us-east-1", 2]}`,
+			schema,
+			[]string{
+				"data.count: expected type integer but got number",
+				"data.regions[1]: expected type string but got number",
+				"data.title: expected type string but got number",
+			},
+		},
+		test{
+			"Unknown property, enum and length violations",
+			`{"title": "a very long title", "titel": "typo", "mode": "audit", "count": 0}`,
+			schema,
+			[]string{
+				"data.count: value must be at least 1",
+				"data.mode: value audit is not one of the allowed values [check enforce]",
+				"data.titel: property is not allowed by the schema",
+				"data.title: length must be at most 10",
+			},
+		},
+	}
+	for _, test := range tests {
+		var data, schemaMap map[string]interface{}
+		if err := json.Unmarshal([]byte(test.data), &data); err != nil {
+			panic(err)
+		}
+		if err := json.Unmarshal([]byte(test.schema), &schemaMap); err != nil {
+			panic(err)
+		}
+		assert.Equal(t, test.expected, ValidateJsonSchema(data, schemaMap), test.name)
+	}
+}
+
+func TestUnknownJsonSchemaProperties(t *testing.T) {
+	type test struct {
+		name     string
+		data     string
+		schema   string
+		expected []string
+	}
+	schema := `{
+	"allOf": [
+		{
+			"type": "object",
+			"properties": {
+				"title": {"type": "string"},
+				"description": {"type": "string"}
+			}
+		},
+		{
+			"properties": {
+				"settings": {
+					"type": "object",
+					"properties": {
+						"region": {"type": "string"}
+					}
+				},
+				"labels": {"type": "object"}
+			}
+		}
+	]
+}`
+	tests := []test{
+		test{"Known properties", `{"title": "folder", "settings": {"region": "us-east-1"}, "labels": {"any": "value"}}`, schema, nil},
+		test{
+			"Misspelt properties",
+			`{"titel": "folder", "settings": {"regoin": "us-east-1"}, "owner": "me"}`,
+			schema,
+			[]string{
+				"data.owner: property is not defined by the schema",
+				"data.settings.regoin: property is not defined by the schema - did you mean 'region'?",
+				"data.titel: property is not defined by the schema - did you mean 'title'?",
+			},
+		},
+		test{"Schema with a $ref", `{"titel": "folder"}`, `{"allOf": [{"$ref": "#/definitions/folder"}, {"properties": {"title": {"type": "string"}}}]}`, nil},
+		test{"Additional properties allowed", `{"titel": "folder"}`, `{"properties": {"title": {"type": "string"}}, "additionalProperties": true}`, nil},
+		test{"No properties defined", `{"titel": "folder"}`, `{"type": "object"}`, nil},
+	}
+	for _, test := range tests {
+		var data, schemaMap map[string]interface{}
+		if err := json.Unmarshal([]byte(test.data), &data); err != nil {
+			panic(err)
+		}
+		if err := json.Unmarshal([]byte(test.schema), &schemaMap); err != nil {
+			panic(err)
+		}
+		assert.Equal(t, test.expected, UnknownJsonSchemaProperties(data, schemaMap), test.name)
+	}
+}
+
+func TestConvertToSchemaTypes(t *testing.T) {
+	type test struct {
+		name     string
+		values   map[string]interface{}
+		expected map[string]interface{}
+		err      bool
+	}
+	schema := map[string]interface{}{
+		"allOf": []interface{}{
+			map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"Id":      map[string]interface{}{"type": "string"},
+					"count":   map[string]interface{}{"type": "integer"},
+					"enabled": map[string]interface{}{"type": []interface{}{"boolean", "null"}},
+					"regions": map[string]interface{}{"type": "array"},
+				},
+			},
+		},
+	}
+	tests := []test{
+		test{
+			"Typed properties",
+			map[string]interface{}{"Id": "123456789012", "count": "2", "enabled": "true", "regions": `["us-east-1"]`},
+			map[string]interface{}{"Id": "123456789012", "count": float64(2), "enabled": true, "regions": []interface{}{"us-east-1"}},
+			false,
+		},
+		test{
+			"Properties not in the schema",
+			map[string]interface{}{"title": "true", "tags": `{"env":"prod"}`, "note": "[draft"},
+			map[string]interface{}{"title": "true", "tags": map[string]interface{}{"env": "prod"}, "note": "[draft"},
+			false,
+		},
+		test{
+			"Invalid value",
+			map[string]interface{}{"count": "two"},
+			nil,
+			true,
+		},
+	}
+	for _, test := range tests {
+		result, err := ConvertToSchemaTypes(test.values, schema)
+		assert.Equal(t, test.err, err != nil, test.name)
+		assert.Equal(t, test.expected, result, test.name)
+	}
+}
+
+func TestNormalizeWhitespace(t *testing.T) {
+	type test struct {
+		name     string
+		value    string
+		expected string
+	}
+	tests := []test{
+		test{"No change", "# Folder\n\nDescription", "# Folder\n\nDescription"},
+		test{"Trailing newline", "# Folder\n\nDescription\n", "# Folder\n\nDescription"},
+		test{"Trailing whitespace", "# Folder  \n\t\nDescription \n\n", "# Folder\n\nDescription"},
+		test{"Windows line endings", "# Folder\r\n\r\nDescription\r\n", "# Folder\n\nDescription"},
+		test{"Leading whitespace is preserved", "  - item 1\n  - item 2", "  - item 1\n  - item 2"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, NormalizeWhitespace(test.value), test.name)
+	}
+}
+
+func TestJsonOrYamlStringToMap(t *testing.T) {
+	type test struct {
+		name     string
+		value    string
+		expected map[string]interface{}
+		err      bool
+	}
+	tests := []test{
+		test{"JSON", `{"a": 1, "b": ["x"]}`, map[string]interface{}{"a": float64(1), "b": []interface{}{"x"}}, false},
+		test{"YAML", "a: 1\nb:\n  - x\n", map[string]interface{}{"a": float64(1), "b": []interface{}{"x"}}, false},
+		test{"Nested YAML", "a:\n  b:\n    c: true\n", map[string]interface{}{"a": map[string]interface{}{"b": map[string]interface{}{"c": true}}}, false},
+		test{"Not an object", "- x\n- y\n", nil, true},
+		test{"Invalid", "a: [b", nil, true},
+	}
+	for _, test := range tests {
+		result, err := JsonOrYamlStringToMap(test.value)
+		if test.err {
+			assert.Error(t, err, test.name)
+			continue
+		}
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, result, test.name)
+	}
+}
+
+func TestFormatJsonOrYaml(t *testing.T) {
+	assert.Equal(t, "{\n \"a\": 1,\n \"b\": \"c\"\n}", FormatJsonOrYaml("b: c\na: 1\n"))
+	assert.Equal(t, "{\n \"a\": 1\n}", FormatJsonOrYaml(`{"a":1}`))
+	assert.Equal(t, "a: [b", FormatJsonOrYaml("a: [b"))
+}
+
+func TestJsonOrYamlStringsAreEqual(t *testing.T) {
+	assert.True(t, JsonOrYamlStringsAreEqual(`{"a": 1, "b": {"c": "d"}}`, "b:\n  c: d\na: 1\n"))
+	assert.True(t, JsonOrYamlStringsAreEqual(`{"a":1}`, `{ "a": 1 }`))
+	assert.False(t, JsonOrYamlStringsAreEqual(`{"a": 1}`, "a: 2\n"))
+	assert.False(t, JsonOrYamlStringsAreEqual(`{"a": 1}`, "a: [b"))
+}
+
+func TestSplitTitlePath(t *testing.T) {
+	type test struct {
+		name     string
+		path     string
+		expected []string
+	}
+	tests := []test{
+		test{"Root", "/", nil},
+		test{"Single title", "/Prod", []string{"Prod"}},
+		test{"Nested titles", "/Prod/AWS", []string{"Prod", "AWS"}},
+		test{"No leading separator", "Prod/AWS", []string{"Prod", "AWS"}},
+		test{"Trailing and repeated separators", "/Prod//AWS/", []string{"Prod", "AWS"}},
+		test{"Titles with spaces", "/ Prod Accounts / AWS ", []string{"Prod Accounts", "AWS"}},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, SplitTitlePath(test.path), test.name)
+	}
+}
+
+func TestPropertyPathsFromJson(t *testing.T) {
+	type test struct {
+		name     string
+		body     string
+		expected []string
+	}
+	tests := []test{
+		test{"Empty", "", nil},
+		test{"Top level properties", `{"name": "a", "count": 1}`, []string{"count", "name"}},
+		test{"Nested properties", `{"name": "a", "settings": {"connection": {"region": "us-east-1", "port": 443}}}`, []string{"name", "settings.connection.port", "settings.connection.region"}},
+		test{"Arrays are leaves", `{"settings": {"regions": ["us-east-1", "us-east-2"]}}`, []string{"settings.regions"}},
+		test{"Empty objects are leaves", `{"settings": {}}`, []string{"settings"}},
+	}
+	for _, test := range tests {
+		paths, err := PropertyPathsFromJson(test.body)
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, paths, test.name)
+	}
+}
+
+func TestSetPropertyPath(t *testing.T) {
+	data := map[string]interface{}{"name": "a"}
+	SetPropertyPath(data, "settings.connection.region", "us-east-1")
+	SetPropertyPath(data, "settings.connection.port", 443)
+	SetPropertyPath(data, "settings.enabled", nil)
+	expected := map[string]interface{}{
+		"name": "a",
+		"settings": map[string]interface{}{
+			"connection": map[string]interface{}{"region": "us-east-1", "port": 443},
+			"enabled":    nil,
+		},
+	}
+	assert.Equal(t, expected, data)
+}
+
+func TestGetAndDeletePropertyPath(t *testing.T) {
+	data := map[string]interface{}{
+		"name": "a",
+		"settings": map[string]interface{}{
+			"connection": map[string]interface{}{"region": "us-east-1", "port": 443},
+		},
+	}
+	value, ok := GetPropertyPath(data, "settings.connection.region")
+	assert.True(t, ok)
+	assert.Equal(t, "us-east-1", value)
+	_, ok = GetPropertyPath(data, "settings.enabled")
+	assert.False(t, ok)
+	// a path through a property which is not an object does not exist
+	_, ok = GetPropertyPath(data, "name.first")
+	assert.False(t, ok)
+
+	DeletePropertyPath(data, "settings.connection.region")
+	DeletePropertyPath(data, "settings.missing.region")
+	DeletePropertyPath(data, "name.first")
+	expected := map[string]interface{}{
+		"name": "a",
+		"settings": map[string]interface{}{
+			"connection": map[string]interface{}{"port": 443},
+		},
+	}
+	assert.Equal(t, expected, data)
+}
+
+func TestSetLogLevel(t *testing.T) {
+	levels := LogLevels{}
+	assert.True(t, levels.Enabled(LogWaiters, "TRACE"))
+
+	assert.NoError(t, levels.Set(LogWaiters, "warn"))
+	assert.False(t, levels.Enabled(LogWaiters, "DEBUG"))
+	assert.True(t, levels.Enabled(LogWaiters, "WARN"))
+	assert.True(t, levels.Enabled(LogWaiters, "ERROR"))
+	// the level of other subsystems is unchanged
+	assert.True(t, levels.Enabled(LogTransport, "DEBUG"))
+	// as are the levels of other clients
+	assert.True(t, LogLevels{}.Enabled(LogWaiters, "DEBUG"))
+
+	assert.EqualError(t, levels.Set(LogWaiters, "VERBOSE"), "invalid log level 'VERBOSE', must be one of TRACE, DEBUG, INFO, WARN, ERROR")
+	assert.NoError(t, levels.Set(LogWaiters, ""))
+	assert.True(t, levels.Enabled(LogWaiters, "DEBUG"))
+	// a client with no levels logs everything
+	assert.True(t, LogLevels(nil).Enabled(LogWaiters, "TRACE"))
+}
+
+func TestLogf(t *testing.T) {
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+
+	levels := LogLevels{}
+	assert.NoError(t, levels.Set(LogTransport, "INFO"))
+	levels.Logf(LogTransport, "[DEBUG] request %d", 1)
+	levels.Logf(LogTransport, "[WARN] retrying request %d", 2)
+	levels.Logf(LogResources, "[DEBUG] reading %s", "folder")
+	// messages with no level are not filtered
+	levels.Logf(LogTransport, "request %d", 3)
+
+	logged := output.String()
+	assert.NotContains(t, logged, "request 1")
+	assert.Contains(t, logged, "[WARN] retrying request 2")
+	assert.Contains(t, logged, "[DEBUG] reading folder")
+	assert.Contains(t, logged, "request 3")
+}
+
+func TestHashValue(t *testing.T) {
+	salt, err := NewHashSalt()
+	assert.NoError(t, err)
+	hashed := HashValue("Enforce: Enabled", salt)
+
+	parsedSalt, ok := HashSalt(hashed)
+	assert.True(t, ok)
+	assert.Equal(t, salt, parsedSalt)
+	assert.NotContains(t, hashed, "Enforce")
+	assert.True(t, HashedValueMatches(hashed, "Enforce: Enabled"))
+	assert.False(t, HashedValueMatches(hashed, "Check: Enabled"))
+
+	// the same value has a different hash with a different salt
+	otherSalt, err := NewHashSalt()
+	assert.NoError(t, err)
+	assert.NotEqual(t, hashed, HashValue("Enforce: Enabled", otherSalt))
+
+	// a plaintext value is not a hash
+	_, ok = HashSalt("Enforce: Enabled")
+	assert.False(t, ok)
+	assert.False(t, HashedValueMatches("Enforce: Enabled", "Enforce: Enabled"))
+}
+
+func TestInterfaceToScalarStringOrYaml(t *testing.T) {
+	type test struct {
+		name     string
+		value    interface{}
+		expected string
+	}
+	tests := []test{
+		{"nil", nil, ""},
+		{"string", "us-east-1", "us-east-1"},
+		{"bool", true, "true"},
+		{"int", 42, "42"},
+		// JSON numbers are decoded as float64
+		{"float", float64(30), "30"},
+		{"fraction", 1.5, "1.5"},
+		{"map", map[string]interface{}{"a": "b"}, "a: b\n"},
+		{"list", []interface{}{"us-east-1", "us-west-2"}, "- us-east-1\n- us-west-2\n"},
+	}
+	for _, test := range tests {
+		value, err := InterfaceToScalarStringOrYaml(test.value)
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, value, test.name)
+	}
+}
@@ -0,0 +1,338 @@
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform/helper/didyoumean"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// validate data against a JSON schema, returning a list of property level errors
+// NOTE: only the schema keywords used by Turbot resource type schemas are supported. Any other keywords
+// (e.g. $ref) are ignored, so we never reject a value which the API may accept
+func ValidateJsonSchema(data interface{}, schema map[string]interface{}) []string {
+	return validateSchemaValue("data", data, schema)
+}
+
+// validate a single property value against the schema of the property
+// errors are reported using the given property name as the path
+func ValidateJsonSchemaProperty(name string, value interface{}, schema map[string]interface{}) []string {
+	return validateSchemaValue(name, value, schema)
+}
+
+func validateSchemaValue(path string, value interface{}, schema map[string]interface{}) []string {
+	var errors []string
+
+	// allOf - value must be valid against every sub-schema
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		for _, s := range allOf {
+			if subSchema, ok := s.(map[string]interface{}); ok {
+				errors = append(errors, validateSchemaValue(path, value, subSchema)...)
+			}
+		}
+	}
+
+	if schemaType, ok := schema["type"]; ok {
+		if !jsonTypeMatches(value, schemaType) {
+			// if the type is wrong there is no point validating further
+			return append(errors, fmt.Sprintf("%s: expected type %s but got %s", path, schemaTypeString(schemaType), jsonTypeName(value)))
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		if !enumContains(enum, value) {
+			errors = append(errors, fmt.Sprintf("%s: value %v is not one of the allowed values %v", path, value, enum))
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		errors = append(errors, validateSchemaObject(path, v, schema)...)
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				errors = append(errors, validateSchemaValue(fmt.Sprintf("%s[%d]", path, i), item, items)...)
+			}
+		}
+	case string:
+		if minLength, ok := schema["minLength"].(float64); ok && float64(len(v)) < minLength {
+			errors = append(errors, fmt.Sprintf("%s: length must be at least %v", path, minLength))
+		}
+		if maxLength, ok := schema["maxLength"].(float64); ok && float64(len(v)) > maxLength {
+			errors = append(errors, fmt.Sprintf("%s: length must be at most %v", path, maxLength))
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			// ignore patterns which go cannot compile (e.g. lookaheads)
+			if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(v) {
+				errors = append(errors, fmt.Sprintf("%s: value '%s' does not match pattern %s", path, v, pattern))
+			}
+		}
+	case float64:
+		if minimum, ok := schema["minimum"].(float64); ok && v < minimum {
+			errors = append(errors, fmt.Sprintf("%s: value must be at least %v", path, minimum))
+		}
+		if maximum, ok := schema["maximum"].(float64); ok && v > maximum {
+			errors = append(errors, fmt.Sprintf("%s: value must be at most %v", path, maximum))
+		}
+	}
+	return errors
+}
+
+func validateSchemaObject(path string, object map[string]interface{}, schema map[string]interface{}) []string {
+	var errors []string
+	properties, _ := schema["properties"].(map[string]interface{})
+
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, r := range required {
+			if name, ok := r.(string); ok {
+				if _, present := object[name]; !present {
+					errors = append(errors, fmt.Sprintf("%s.%s: required property is missing", path, name))
+				}
+			}
+		}
+	}
+
+	// sort the keys so the errors are returned in a consistent order
+	var keys []string
+	for k := range object {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		propertyPath := fmt.Sprintf("%s.%s", path, k)
+		if propertySchema, ok := properties[k].(map[string]interface{}); ok {
+			errors = append(errors, validateSchemaValue(propertyPath, object[k], propertySchema)...)
+			continue
+		}
+		if additionalProperties, ok := schema["additionalProperties"].(bool); ok && !additionalProperties {
+			errors = append(errors, fmt.Sprintf("%s: property is not allowed by the schema", propertyPath))
+		}
+	}
+	return errors
+}
+
+// the schema type may be either a single type or a list of types
+func jsonTypeMatches(value interface{}, schemaType interface{}) bool {
+	switch t := schemaType.(type) {
+	case string:
+		return jsonValueIsType(value, t)
+	case []interface{}:
+		for _, element := range t {
+			if typeName, ok := element.(string); ok && jsonValueIsType(value, typeName) {
+				return true
+			}
+		}
+		return false
+	}
+	// unrecognised type definition - do not validate
+	return true
+}
+
+func jsonValueIsType(value interface{}, typeName string) bool {
+	switch typeName {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		v, ok := value.(float64)
+		return ok && math.Trunc(v) == v
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "null":
+		return value == nil
+	}
+	// unknown type name - do not validate
+	return true
+}
+
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	}
+	return reflect.TypeOf(value).String()
+}
+
+func schemaTypeString(schemaType interface{}) string {
+	if types, ok := schemaType.([]interface{}); ok {
+		var names []string
+		for _, t := range types {
+			names = append(names, fmt.Sprintf("%v", t))
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprintf("%v", schemaType)
+}
+
+func enumContains(enum []interface{}, value interface{}) bool {
+	for _, e := range enum {
+		if reflect.DeepEqual(e, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// convert a map of string values (e.g. a Terraform map) to typed values, using the types of the properties
+// defined by the schema. Values of properties which are not strings are parsed as JSON, so booleans, numbers
+// and (jsonencoded) objects and arrays are passed with the correct type.
+// if the schema does not define the type of a property, values which look like a JSON object or array are
+// parsed, and all other values are passed as strings
+func ConvertToSchemaTypes(values map[string]interface{}, schema map[string]interface{}) (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	for name, element := range values {
+		value := InterfaceToString(element)
+		types := schemaPropertyTypes(name, schema)
+		if len(types) == 0 || SliceContains(types, "string") {
+			trimmed := strings.TrimSpace(value)
+			var parsed interface{}
+			if len(types) == 0 && (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) && json.Unmarshal([]byte(trimmed), &parsed) == nil {
+				result[name] = parsed
+			} else {
+				result[name] = value
+			}
+			continue
+		}
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+			return nil, fmt.Errorf("%s: cannot convert '%s' to %s", name, value, strings.Join(types, " or "))
+		}
+		result[name] = parsed
+	}
+	return result, nil
+}
+
+// return the types of a top level property defined by the schema (or its allOf sub-schemas)
+func schemaPropertyTypes(name string, schema map[string]interface{}) []string {
+	if schema == nil {
+		return nil
+	}
+	if properties, ok := schema["properties"].(map[string]interface{}); ok {
+		if propertySchema, ok := properties[name].(map[string]interface{}); ok {
+			switch t := propertySchema["type"].(type) {
+			case string:
+				return []string{t}
+			case []interface{}:
+				var types []string
+				for _, element := range t {
+					if typeName, ok := element.(string); ok && typeName != "null" {
+						types = append(types, typeName)
+					}
+				}
+				return types
+			}
+		}
+	}
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		for _, s := range allOf {
+			if subSchema, ok := s.(map[string]interface{}); ok {
+				if types := schemaPropertyTypes(name, subSchema); len(types) > 0 {
+					return types
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// find the properties of the data which are not defined by the schema, e.g. a misspelt property name, which the API
+// would either ignore or reject. if a defined property has a similar name, it is suggested.
+// objects are only checked if the schema defines all of their properties - if it sets additionalProperties (false is
+// reported by ValidateJsonSchema) or uses a $ref, which is not supported, we can't tell whether a property is unknown
+func UnknownJsonSchemaProperties(data map[string]interface{}, schema map[string]interface{}) []string {
+	return unknownSchemaProperties("data", data, schema)
+}
+
+func unknownSchemaProperties(path string, object map[string]interface{}, schema map[string]interface{}) []string {
+	properties, complete := schemaObjectProperties(schema)
+	if !complete || len(properties) == 0 {
+		return nil
+	}
+	var names []string
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var keys []string
+	for k := range object {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var unknown []string
+	for _, k := range keys {
+		propertyPath := fmt.Sprintf("%s.%s", path, k)
+		propertySchema, ok := properties[k]
+		if !ok {
+			message := fmt.Sprintf("%s: property is not defined by the schema", propertyPath)
+			if suggestion := didyoumean.NameSuggestion(k, names); suggestion != "" {
+				message = fmt.Sprintf("%s - did you mean '%s'?", message, suggestion)
+			}
+			unknown = append(unknown, message)
+			continue
+		}
+		if nested, ok := object[k].(map[string]interface{}); ok {
+			unknown = append(unknown, unknownSchemaProperties(propertyPath, nested, propertySchema)...)
+		}
+	}
+	return unknown
+}
+
+// return the properties defined by the schema and its allOf sub-schemas, and whether these are all the properties
+// an object may have
+func schemaObjectProperties(schema map[string]interface{}) (map[string]map[string]interface{}, bool) {
+	result := map[string]map[string]interface{}{}
+	if _, ok := schema["$ref"]; ok {
+		return result, false
+	}
+	if _, ok := schema["additionalProperties"]; ok {
+		return result, false
+	}
+	if properties, ok := schema["properties"].(map[string]interface{}); ok {
+		for name, p := range properties {
+			propertySchema, _ := p.(map[string]interface{})
+			result[name] = propertySchema
+		}
+	}
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		for _, s := range allOf {
+			subSchema, ok := s.(map[string]interface{})
+			if !ok {
+				return result, false
+			}
+			subProperties, complete := schemaObjectProperties(subSchema)
+			if !complete {
+				return result, false
+			}
+			for name, propertySchema := range subProperties {
+				result[name] = propertySchema
+			}
+		}
+	}
+	return result, true
+}
@@ -0,0 +1,66 @@
+package helpers
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+)
+
+// the subsystems whose log verbosity can be set separately
+const (
+	// requests to the Turbot API, including retries and batching
+	LogTransport = "transport"
+	// create, read, update and delete of resources
+	LogResources = "resources"
+	// waiting for controls and mod installs
+	LogWaiters = "waiters"
+)
+
+// log levels, least to most severe - these are the levels of the terraform log (TF_LOG)
+var logLevels = []string{"TRACE", "DEBUG", "INFO", "WARN", "ERROR"}
+
+var logLevelPattern = regexp.MustCompile(`^\[(TRACE|DEBUG|INFO|WARN|ERROR)\]`)
+
+// the minimum level logged for each subsystem - a subsystem with no level set is filtered only by TF_LOG
+// the levels are set when the provider is configured and held by its client, so are not shared between providers
+type LogLevels map[string]int
+
+// set the minimum level of the messages logged by a subsystem. an empty level removes the limit
+// the level can only reduce the logging of a subsystem - messages below the TF_LOG level are never logged
+func (l LogLevels) Set(subsystem, level string) error {
+	if level == "" {
+		delete(l, subsystem)
+		return nil
+	}
+	index := logLevelIndex(strings.ToUpper(level))
+	if index == -1 {
+		return fmt.Errorf("invalid log level '%s', must be one of %s", level, strings.Join(logLevels, ", "))
+	}
+	l[subsystem] = index
+	return nil
+}
+
+// is a message of the given level logged by the subsystem
+func (l LogLevels) Enabled(subsystem, level string) bool {
+	minimum, ok := l[subsystem]
+	return !ok || logLevelIndex(level) >= minimum
+}
+
+// log a message of a subsystem, as log.Printf. the level is read from the "[LEVEL]" prefix of the format -
+// messages with no level are always logged
+func (l LogLevels) Logf(subsystem, format string, v ...interface{}) {
+	if match := logLevelPattern.FindStringSubmatch(format); match != nil && !l.Enabled(subsystem, match[1]) {
+		return
+	}
+	log.Printf(format, v...)
+}
+
+func logLevelIndex(level string) int {
+	for i, l := range logLevels {
+		if l == level {
+			return i
+		}
+	}
+	return -1
+}
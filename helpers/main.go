@@ -1,9 +1,16 @@
 package helpers
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"github.com/hashicorp/terraform/helper/encryption"
 	"reflect"
+	"sort"
+	"strings"
 )
 
 func MergeMaps(m1, m2 map[string]interface{}) {
@@ -33,10 +40,14 @@ func RemoveProperties(properties []interface{}, excluded []string) []interface{}
 
 // given a property list, remove the excluded properties
 func RemovePropertiesFromMap(propertyMap map[string]string, excluded []string) map[string]string {
-	for _, v := range excluded {
-		delete(propertyMap, v)
+	// return a copy - the property maps are shared by all resources, which may be updated concurrently
+	result := make(map[string]string, len(propertyMap))
+	for k, v := range propertyMap {
+		if !SliceContains(excluded, k) {
+			result[k] = v
+		}
 	}
-	return propertyMap
+	return result
 }
 
 // no native contains in golang :/
@@ -62,6 +73,42 @@ func EncryptValue(pgpKey, value string) (string, string, error) {
 	return fingerprint, encrypted, nil
 }
 
+// prefix of a hashed value, identifying the algorithm
+const hashedValuePrefix = "sha256:"
+
+// generate a random salt for HashValue
+func NewHashSalt() (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(salt), nil
+}
+
+// hash a value with a salt, returning "sha256:<salt>:<hash>" - the salt is included so the hash of a new value can be compared
+func HashValue(value, salt string) string {
+	hash := sha256.Sum256([]byte(salt + value))
+	return fmt.Sprintf("%s%s:%s", hashedValuePrefix, salt, hex.EncodeToString(hash[:]))
+}
+
+// return the salt of a value returned by HashValue, and false if the value is not a hash
+func HashSalt(hashed string) (string, bool) {
+	if !strings.HasPrefix(hashed, hashedValuePrefix) {
+		return "", false
+	}
+	parts := strings.Split(strings.TrimPrefix(hashed, hashedValuePrefix), ":")
+	if len(parts) != 2 {
+		return "", false
+	}
+	return parts[0], true
+}
+
+// is hashed the hash of the value
+func HashedValueMatches(hashed, value string) bool {
+	salt, ok := HashSalt(hashed)
+	return ok && subtle.ConstantTimeCompare([]byte(HashValue(value, salt)), []byte(hashed)) == 1
+}
+
 func MapToJsonString(data map[string]interface{}) (string, error) {
 	dataBytes, err := json.MarshalIndent(data, "", " ")
 	if err != nil {
@@ -111,6 +158,75 @@ func PropertyMapFromJson(body string) (map[string]string, error) {
 	return properties, nil
 }
 
+// given a json representation of an object, build a sorted list of the paths of its leaf properties, e.g. "settings.connection.region"
+// arrays and empty objects are treated as leaf properties
+func PropertyPathsFromJson(body string) ([]string, error) {
+	if body == "" {
+		return nil, nil
+	}
+	data := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		return nil, err
+	}
+	paths := propertyPaths(data, "")
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func propertyPaths(data map[string]interface{}, prefix string) []string {
+	var paths []string
+	for k, v := range data {
+		path := prefix + k
+		if child, ok := v.(map[string]interface{}); ok && len(child) > 0 {
+			paths = append(paths, propertyPaths(child, path+".")...)
+		} else {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// set the value of the property at the given path, creating any intermediate objects which do not exist
+func SetPropertyPath(data map[string]interface{}, path string, value interface{}) {
+	segments := strings.Split(path, ".")
+	for _, segment := range segments[:len(segments)-1] {
+		child, ok := data[segment].(map[string]interface{})
+		if !ok {
+			child = map[string]interface{}{}
+			data[segment] = child
+		}
+		data = child
+	}
+	data[segments[len(segments)-1]] = value
+}
+
+// get the value of the property at the given path, and whether it exists
+func GetPropertyPath(data map[string]interface{}, path string) (interface{}, bool) {
+	segments := strings.Split(path, ".")
+	for _, segment := range segments[:len(segments)-1] {
+		child, ok := data[segment].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		data = child
+	}
+	value, ok := data[segments[len(segments)-1]]
+	return value, ok
+}
+
+// remove the property at the given path, if it exists
+func DeletePropertyPath(data map[string]interface{}, path string) {
+	segments := strings.Split(path, ".")
+	for _, segment := range segments[:len(segments)-1] {
+		child, ok := data[segment].(map[string]interface{})
+		if !ok {
+			return
+		}
+		data = child
+	}
+	delete(data, segments[len(segments)-1])
+}
+
 // convert a map[string]interface{} to a map[string]string by json encoding any non string fields
 func ConvertToStringMap(data map[string]interface{}) (map[string]string, error) {
 	var outputMap = map[string]string{}
@@ -1,8 +1,11 @@
 package helpers
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/go-yaml/yaml"
+	"reflect"
+	"strings"
 )
 
 // parse given string in YAML format
@@ -46,6 +49,16 @@ func InterfaceToStringOrYaml(value interface{}) (string, error) {
 	return string(data), nil
 }
 
+// convert a structured value (a map or list) to its YAML representation, and format any other value as a string
+// unlike InterfaceToStringOrYaml, scalars are not YAML encoded, so a boolean is "true" rather than "true\n"
+func InterfaceToScalarStringOrYaml(value interface{}) (string, error) {
+	switch value.(type) {
+	case map[string]interface{}, map[interface{}]interface{}, []interface{}:
+		return InterfaceToStringOrYaml(value)
+	}
+	return InterfaceToString(value), nil
+}
+
 // implements a equal operation on 2 YAML strings, ignoring formatting differences
 func YamlStringsAreEqual(yaml1, yaml2 string) (bool, error) {
 	var yaml1intermediate, yaml2intermediate interface{}
@@ -73,3 +86,92 @@ func YamlStringsAreEqual(yaml1, yaml2 string) (bool, error) {
 	}
 	return false, nil
 }
+
+// parse a JSON or YAML object into a map
+// the YAML is converted to JSON first, so the map is the same as parsing the equivalent JSON (e.g. numbers are float64)
+func JsonOrYamlStringToMap(dataString string) (map[string]interface{}, error) {
+	if data, err := JsonStringToMap(dataString); err == nil {
+		return data, nil
+	}
+	var value interface{}
+	if err := yaml.Unmarshal([]byte(dataString), &value); err != nil {
+		return nil, err
+	}
+	jsonBytes, err := json.Marshal(yamlToJsonValue(value))
+	if err != nil {
+		return nil, err
+	}
+	data, err := JsonStringToMap(string(jsonBytes))
+	if err != nil || data == nil {
+		return nil, fmt.Errorf("value is not a JSON or YAML object")
+	}
+	return data, nil
+}
+
+// apply standard formatting to a JSON or YAML object, converting it to JSON
+// if the value is not a JSON or YAML object, it is returned unchanged
+func FormatJsonOrYaml(body string) string {
+	data, err := JsonOrYamlStringToMap(body)
+	if err != nil {
+		return body
+	}
+	formatted, err := MapToJsonString(data)
+	if err != nil {
+		return body
+	}
+	return formatted
+}
+
+// compare 2 JSON or YAML objects, ignoring formatting differences and the format used
+func JsonOrYamlStringsAreEqual(value1, value2 string) bool {
+	data1, err := JsonOrYamlStringToMap(value1)
+	if err != nil {
+		return false
+	}
+	data2, err := JsonOrYamlStringToMap(value2)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(data1, data2)
+}
+
+// YAML mappings are unmarshalled as map[interface{}]interface{}, which can't be marshalled to JSON
+func yamlToJsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[fmt.Sprintf("%v", key)] = yamlToJsonValue(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = yamlToJsonValue(item)
+		}
+		return result
+	}
+	return value
+}
+
+// normalize the whitespace of a (markdown) string so values which only differ by line endings, trailing whitespace
+// or trailing newlines (e.g. from a heredoc) compare as equal
+func NormalizeWhitespace(value string) string {
+	lines := strings.Split(strings.Replace(value, "\r\n", "\n", -1), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// split a title path, e.g. "/Prod/AWS", into its titles. Empty segments (from leading, trailing
+// or repeated separators) are ignored and whitespace around each title is trimmed
+func SplitTitlePath(path string) []string {
+	var titles []string
+	for _, segment := range strings.Split(path, "/") {
+		if title := strings.TrimSpace(segment); title != "" {
+			titles = append(titles, title)
+		}
+	}
+	return titles
+}
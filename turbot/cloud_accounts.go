@@ -0,0 +1,85 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+)
+
+// schema for a data source listing the cloud accounts of a resource type
+// idAttribute is the name of the attribute holding the cloud provider's id for the account, e.g. account_id
+func cloudAccountListSchema(listAttribute, idAttribute string) map[string]*schema.Schema {
+	return withOutputFileSchema(map[string]*schema.Schema{
+		listAttribute: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					idAttribute: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"title": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"parent": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"akas": {
+						Type:     schema.TypeList,
+						Computed: true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+		},
+		// the turbot ids of the accounts, for use with for_each
+		"ids": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	})
+}
+
+func readCloudAccountList(d *schema.ResourceData, meta interface{}, resourceType, accountIdPath, listAttribute, idAttribute string) error {
+	client := meta.(*apiClient.Client)
+	accounts, err := client.ReadCloudAccountList(resourceType, accountIdPath)
+	if err != nil {
+		return err
+	}
+
+	var accountList []map[string]interface{}
+	var ids []string
+	for _, account := range accounts {
+		accountList = append(accountList, map[string]interface{}{
+			"id":        account.Turbot.Id,
+			idAttribute: account.AccountId,
+			"title":     account.Title,
+			"parent":    account.Parent,
+			"akas":      account.Turbot.Akas,
+		})
+		ids = append(ids, account.Turbot.Id)
+	}
+
+	d.SetId(resourceType)
+	written, err := writeOutputFile(d, accountList, []string{"id", idAttribute, "title", "parent", "akas"})
+	if err != nil {
+		return err
+	}
+	if written {
+		accountList, ids = nil, nil
+	}
+	d.Set(listAttribute, accountList)
+	d.Set("ids", ids)
+	return nil
+}
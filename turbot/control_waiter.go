@@ -0,0 +1,148 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+	"sync"
+	"time"
+)
+
+// a control to wait for, identified either by id or by control type and resource
+type controlReference struct {
+	Id       string
+	Type     string
+	Resource string
+}
+
+func (c controlReference) queryArgs() string {
+	if c.Id != "" {
+		return fmt.Sprintf(`id: "%s"`, c.Id)
+	}
+	return fmt.Sprintf(`uri: "%s", resourceId: "%s"`, c.Type, c.Resource)
+}
+
+func (c controlReference) String() string {
+	if c.Id != "" {
+		return c.Id
+	}
+	return fmt.Sprintf("%s on %s", c.Type, c.Resource)
+}
+
+func (c controlReference) validate() error {
+	if c.Id != "" {
+		if c.Type != "" || c.Resource != "" {
+			return fmt.Errorf("if 'id' is set, 'type' and 'resource' must not be set")
+		}
+		return nil
+	}
+	if c.Type == "" || c.Resource == "" {
+		return fmt.Errorf("either 'id' or 'type' AND 'resource' must be set")
+	}
+	return nil
+}
+
+// wait for the given controls to all be in the 'ok' state - each control is waited for in parallel
+// controls which are not found are retried, as the control may not have been created yet.
+// other errors are retried maxErrorRetries times
+func waitForControlsOk(controls []controlReference, timeout time.Duration, client *apiClient.Client) error {
+	for _, c := range controls {
+		if err := c.validate(); err != nil {
+			return err
+		}
+	}
+	errs := make([]error, len(controls))
+	var wg sync.WaitGroup
+	for i, c := range controls {
+		wg.Add(1)
+		go func(i int, c controlReference) {
+			defer wg.Done()
+			control, ok, err := waitForControlState(c, []string{"ok"}, timeout, 500*time.Millisecond, 10*time.Second, client)
+			switch {
+			case err != nil:
+				errs[i] = err
+			case ok:
+			case control == nil:
+				errs[i] = fmt.Errorf("timeout after %s waiting for control %s: control not found", timeout, c)
+			default:
+				errs[i] = fmt.Errorf("timeout after %s waiting for control %s: control is in state '%s' (%s)", timeout, c, control.State, control.Reason)
+			}
+		}(i, c)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// wait for a control to reach one of the target states or the timeout to pass, returning the last control read
+// (nil if the control was never found) and whether a target state was reached. see pollWithWatch
+func waitForControlState(c controlReference, targetStates []string, timeout, minInterval, maxInterval time.Duration, client *apiClient.Client) (*apiClient.Control, bool, error) {
+	if err := c.validate(); err != nil {
+		return nil, false, err
+	}
+	errorCount := 0
+	maxErrorRetries := 5
+	var control *apiClient.Control
+	watch := apiClient.NewWatch()
+	reached, err := pollWithWatch(watch, timeout, minInterval, maxInterval, func() (bool, error) {
+		result, err := client.WatchControl(watch, c.queryArgs())
+		if err != nil {
+			// the control may not have been created yet
+			if !apiClient.NotFoundError(err) {
+				errorCount++
+				if errorCount == maxErrorRetries {
+					return false, fmt.Errorf("control %s: %w", c, err)
+				}
+			}
+			client.Logf(helpers.LogWaiters, "[DEBUG] waiting for control %s: %s", c, err.Error())
+			return false, nil
+		}
+		control = result
+		for _, state := range targetStates {
+			if control.State == state {
+				return true, nil
+			}
+		}
+		client.Logf(helpers.LogWaiters, "[DEBUG] waiting for control %s, state: %s, reason: %s", c, control.State, control.Reason)
+		return false, nil
+	})
+	return control, reached, err
+}
+
+// call poll until it returns true, returns an error or the timeout passes, returning whether it returned true.
+// if the previous read of the watch was long polled (see apiClient.Watch), the server has already waited for a change,
+// so poll is called again after minInterval. otherwise the interval between polls starts at minInterval and
+// doubles after each poll, up to maxInterval
+func pollWithWatch(watch *apiClient.Watch, timeout, minInterval, maxInterval time.Duration, poll func() (bool, error)) (bool, error) {
+	deadline := time.Now().Add(timeout)
+	interval := minInterval
+	for {
+		done, err := poll()
+		if err != nil || done {
+			return done, err
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return false, nil
+		}
+		wait := interval
+		if watch.LongPolling() {
+			// the server must not hold a read beyond the timeout
+			if watch.Wait > remaining {
+				watch.Wait = remaining
+			}
+			wait = minInterval
+		} else if interval *= 2; interval > maxInterval {
+			interval = maxInterval
+		}
+		if wait > remaining {
+			wait = remaining
+		}
+		time.Sleep(wait)
+	}
+}
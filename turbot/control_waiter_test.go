@@ -0,0 +1,45 @@
+package turbot
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestPollWithWatch(t *testing.T) {
+	type test struct {
+		name          string
+		results       []bool
+		err           error
+		expected      bool
+		expectedPolls int
+	}
+	tests := []test{
+		{"done on first poll", []bool{true}, nil, true, 1},
+		{"done after polling", []bool{false, false, true}, nil, true, 3},
+		{"timeout", nil, nil, false, 0},
+		{"error", []bool{false}, errors.New("failed"), false, 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			polls := 0
+			// no watch - the interval doubles from 1ms up to 4ms
+			done, err := pollWithWatch(nil, 20*time.Millisecond, time.Millisecond, 4*time.Millisecond, func() (bool, error) {
+				polls++
+				if polls > len(test.results) {
+					return false, nil
+				}
+				return test.results[polls-1], test.err
+			})
+			assert.Equal(t, test.expected, done)
+			assert.Equal(t, test.err, err)
+			if test.expectedPolls > 0 {
+				assert.Equal(t, test.expectedPolls, polls)
+			} else {
+				// polled until the timeout, at no less than the minimum interval
+				assert.True(t, polls > 1 && polls <= 20, "polls: %d", polls)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceTurbotAwsAccounts() *schema.Resource {
+	return &schema.Resource{
+		Read:   dataSourceTurbotAwsAccountsRead,
+		Schema: cloudAccountListSchema("accounts", "account_id"),
+	}
+}
+
+func dataSourceTurbotAwsAccountsRead(d *schema.ResourceData, meta interface{}) error {
+	return readCloudAccountList(d, meta, "tmod:@turbot/aws#/resource/types/account", "Id", "accounts", "account_id")
+}
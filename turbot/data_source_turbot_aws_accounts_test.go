@@ -0,0 +1,29 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/resource"
+	"testing"
+)
+
+func TestAccAwsAccountsDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAwsAccountsConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.turbot_aws_accounts.test", "accounts.#"),
+					resource.TestCheckResourceAttrSet("data.turbot_aws_accounts.test", "accounts.0.account_id"),
+					resource.TestCheckResourceAttrSet("data.turbot_aws_accounts.test", "accounts.0.parent"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAwsAccountsConfig() string {
+	return `
+data "turbot_aws_accounts" "test" {}
+`
+}
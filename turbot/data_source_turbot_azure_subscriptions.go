@@ -0,0 +1,16 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceTurbotAzureSubscriptions() *schema.Resource {
+	return &schema.Resource{
+		Read:   dataSourceTurbotAzureSubscriptionsRead,
+		Schema: cloudAccountListSchema("subscriptions", "subscription_id"),
+	}
+}
+
+func dataSourceTurbotAzureSubscriptionsRead(d *schema.ResourceData, meta interface{}) error {
+	return readCloudAccountList(d, meta, "tmod:@turbot/azure#/resource/types/subscription", "subscriptionId", "subscriptions", "subscription_id")
+}
@@ -0,0 +1,29 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/resource"
+	"testing"
+)
+
+func TestAccAzureSubscriptionsDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureSubscriptionsConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.turbot_azure_subscriptions.test", "subscriptions.#"),
+					resource.TestCheckResourceAttrSet("data.turbot_azure_subscriptions.test", "subscriptions.0.subscription_id"),
+					resource.TestCheckResourceAttrSet("data.turbot_azure_subscriptions.test", "subscriptions.0.parent"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAzureSubscriptionsConfig() string {
+	return `
+data "turbot_azure_subscriptions" "test" {}
+`
+}
@@ -66,9 +66,9 @@ func dataSourceTurbotControlRead(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
-	d.SetId(control.Turbot["id"])
+	d.SetId(control.Turbot.Id)
 	d.Set("type", control.Type.Uri)
-	d.Set("resource", control.Turbot["resourceId"])
+	d.Set("resource", control.Turbot.ResourceId)
 	d.Set("state", control.State)
 	d.Set("reason", control.Reason)
 	d.Set("details", control.Details)
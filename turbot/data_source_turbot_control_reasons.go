@@ -0,0 +1,133 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"sort"
+)
+
+// the alarmed controls of a scope grouped by reason, most common first - a report of the most common misconfigurations
+func dataSourceTurbotControlReasons() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTurbotControlReasonsRead,
+		Schema: map[string]*schema.Schema{
+			// the id or aka of the resource whose alarmed controls (and those of its descendants) are grouped
+			"scope": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "tmod:@turbot/turbot#/",
+			},
+			// an additional filter for the controls, e.g. "controlCategory:..."
+			"filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// the number of reasons to return
+			"top": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  10,
+			},
+			// the total number of alarmed controls, including those whose reason is not in 'reasons'
+			"total": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"reasons": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"reason": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						// the URIs of the types of the controls with this reason
+						"control_types": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type controlReason struct {
+	reason       string
+	count        int
+	controlTypes map[string]bool
+}
+
+func dataSourceTurbotControlReasonsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	top := d.Get("top").(int)
+	if top < 1 {
+		return attributeError("top", fmt.Errorf("must be at least 1, got %d", top))
+	}
+	// the controls are filtered by the scope in the query, so only the alarmed controls of the scope are read
+	filter := fmt.Sprintf("state:alarm resource:'%s' level:self,descendant", d.Get("scope").(string))
+	if extraFilter, ok := d.GetOk("filter"); ok {
+		filter = fmt.Sprintf("%s %s", filter, extraFilter.(string))
+	}
+
+	controls, err := client.ReadControlList(filter, 0)
+	if err != nil {
+		return err
+	}
+
+	reasons := groupControlReasons(controls)
+	if len(reasons) > top {
+		reasons = reasons[:top]
+	}
+	var reasonList []map[string]interface{}
+	for _, r := range reasons {
+		var controlTypes []string
+		for controlType := range r.controlTypes {
+			controlTypes = append(controlTypes, controlType)
+		}
+		sort.Strings(controlTypes)
+		reasonList = append(reasonList, map[string]interface{}{
+			"reason":        r.reason,
+			"count":         r.count,
+			"control_types": controlTypes,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%s_%d", filter, top))
+	d.Set("total", len(controls))
+	d.Set("reasons", reasonList)
+	return nil
+}
+
+// group the controls by reason, sorted by the number of controls (most first) and then by reason
+func groupControlReasons(controls []apiClient.Control) []*controlReason {
+	reasonMap := map[string]*controlReason{}
+	var reasons []*controlReason
+	for _, control := range controls {
+		r, ok := reasonMap[control.Reason]
+		if !ok {
+			r = &controlReason{reason: control.Reason, controlTypes: map[string]bool{}}
+			reasonMap[control.Reason] = r
+			reasons = append(reasons, r)
+		}
+		r.count++
+		r.controlTypes[control.Type.Uri] = true
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		if reasons[i].count != reasons[j].count {
+			return reasons[i].count > reasons[j].count
+		}
+		return reasons[i].reason < reasons[j].reason
+	})
+	return reasons
+}
@@ -0,0 +1,66 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"testing"
+)
+
+func TestGroupControlReasons(t *testing.T) {
+	control := func(controlType, reason string) apiClient.Control {
+		c := apiClient.Control{Reason: reason}
+		c.Type.Uri = controlType
+		return c
+	}
+	controls := []apiClient.Control{
+		control("tmod:@turbot/aws-s3#/control/types/bucketVersioning", "Versioning disabled"),
+		control("tmod:@turbot/aws-s3#/control/types/bucketEncryptionAtRest", "Encryption disabled"),
+		control("tmod:@turbot/aws-s3#/control/types/bucketVersioning", "Versioning disabled"),
+		control("tmod:@turbot/aws-s3#/control/types/bucketTags", "Tags missing"),
+		control("tmod:@turbot/aws-ec2#/control/types/instanceTags", "Tags missing"),
+		control("tmod:@turbot/aws-s3#/control/types/bucketTags", "Tags missing"),
+	}
+
+	reasons := groupControlReasons(controls)
+	var result []string
+	var counts []int
+	for _, r := range reasons {
+		result = append(result, r.reason)
+		counts = append(counts, r.count)
+	}
+	// most common first, then by reason
+	assert.Equal(t, []string{"Tags missing", "Versioning disabled", "Encryption disabled"}, result)
+	assert.Equal(t, []int{3, 2, 1}, counts)
+	assert.Equal(t, map[string]bool{
+		"tmod:@turbot/aws-s3#/control/types/bucketTags":    true,
+		"tmod:@turbot/aws-ec2#/control/types/instanceTags": true,
+	}, reasons[0].controlTypes)
+
+	assert.Empty(t, groupControlReasons(nil))
+}
+
+func TestAccControlReasonsDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccControlReasonsConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.turbot_control_reasons.test", "total"),
+					resource.TestCheckResourceAttrSet("data.turbot_control_reasons.test", "reasons.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccControlReasonsConfig() string {
+	return `
+data "turbot_control_reasons" "test" {
+	scope = "tmod:@turbot/turbot#/"
+	top   = 3
+}
+`
+}
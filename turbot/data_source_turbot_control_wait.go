@@ -0,0 +1,122 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"strings"
+	"time"
+)
+
+// wait for a control to reach one of the target states (by default 'ok'), polling with exponential backoff
+func dataSourceTurbotControlWait() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTurbotControlWaitRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"resource": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// the states to wait for - if not set, wait for 'ok'
+			"target_states": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// all durations are in seconds
+			"timeout": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  300,
+			},
+			"min_interval": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1,
+			},
+			"max_interval": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  30,
+			},
+			// if false, the final state is returned when the timeout passes rather than an error
+			"fail_on_timeout": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"reason": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"details": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"timed_out": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"elapsed_seconds": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceTurbotControlWaitRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	c := controlReference{
+		Id:       d.Get("id").(string),
+		Type:     d.Get("type").(string),
+		Resource: d.Get("resource").(string),
+	}
+	targetStates := []string{"ok"}
+	if states := d.Get("target_states").([]interface{}); len(states) > 0 {
+		targetStates = nil
+		for _, state := range states {
+			targetStates = append(targetStates, state.(string))
+		}
+	}
+	timeout := time.Duration(d.Get("timeout").(int)) * time.Second
+	minInterval := time.Duration(d.Get("min_interval").(int)) * time.Second
+	maxInterval := time.Duration(d.Get("max_interval").(int)) * time.Second
+	if minInterval <= 0 || maxInterval < minInterval {
+		return fmt.Errorf("min_interval must be greater than 0 and not greater than max_interval")
+	}
+
+	start := time.Now()
+	control, reached, err := waitForControlState(c, targetStates, timeout, minInterval, maxInterval, client)
+	if err != nil {
+		return err
+	}
+	if control == nil {
+		return fmt.Errorf("control %s was not found after %s", c, timeout)
+	}
+	if !reached && d.Get("fail_on_timeout").(bool) {
+		return fmt.Errorf("timed out after %s waiting for control %s to be in state %s - the control is in state '%s' (%s)", timeout, c, strings.Join(targetStates, " or "), control.State, control.Reason)
+	}
+
+	d.SetId(control.Turbot.Id)
+	d.Set("state", control.State)
+	d.Set("reason", control.Reason)
+	d.Set("details", control.Details)
+	d.Set("timed_out", !reached)
+	d.Set("elapsed_seconds", int(time.Since(start).Seconds()))
+	return nil
+}
@@ -0,0 +1,62 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/resource"
+	"testing"
+)
+
+func TestAccControlWaitDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccControlWaitConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.turbot_control_wait.test", "state", "ok"),
+					resource.TestCheckResourceAttr("data.turbot_control_wait.test", "timed_out", "false"),
+					resource.TestCheckResourceAttrSet("data.turbot_control_wait.test", "elapsed_seconds"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccControlWaitDataSource_NoFailOnTimeout(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccControlWaitTimeoutConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.turbot_control_wait.test", "state", "ok"),
+					resource.TestCheckResourceAttr("data.turbot_control_wait.test", "timed_out", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccControlWaitConfig() string {
+	return `
+data "turbot_control_wait" "test" {
+  type     = "tmod:@turbot/turbot#/control/types/turbotWorkspaceConfigured"
+  resource = "tmod:@turbot/turbot#/"
+  timeout  = 60
+}
+`
+}
+
+// the control is ok, so waiting for the 'alarm' state times out
+func testAccControlWaitTimeoutConfig() string {
+	return `
+data "turbot_control_wait" "test" {
+  type            = "tmod:@turbot/turbot#/control/types/turbotWorkspaceConfigured"
+  resource        = "tmod:@turbot/turbot#/"
+  target_states   = ["alarm"]
+  timeout         = 5
+  fail_on_timeout = false
+}
+`
+}
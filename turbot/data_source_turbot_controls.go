@@ -0,0 +1,110 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+)
+
+// the controls matching a filter, e.g. "state:alarm controlType:tmod:@turbot/aws-s3#/control/types/bucketVersioning"
+func dataSourceTurbotControls() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTurbotControlsRead,
+		Schema: withOutputFileSchema(map[string]*schema.Schema{
+			"filter": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// the maximum number of controls to return - if not set, all matching controls are returned
+			"max_results": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"controls": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"reason": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"details": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"create_timestamp": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"update_timestamp": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			// the ids of the resources the controls target
+			"resource_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		}),
+	}
+}
+
+func dataSourceTurbotControlsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	filter := d.Get("filter").(string)
+
+	controls, err := client.ReadControlList(filter, d.Get("max_results").(int))
+	if err != nil {
+		return err
+	}
+
+	var controlList []map[string]interface{}
+	var resourceIds []string
+	for _, control := range controls {
+		controlList = append(controlList, map[string]interface{}{
+			"id":               control.Turbot.Id,
+			"type":             control.Type.Uri,
+			"resource":         control.Turbot.ResourceId,
+			"state":            control.State,
+			"reason":           control.Reason,
+			"details":          control.Details,
+			"create_timestamp": control.Turbot.CreateTimestamp,
+			"update_timestamp": control.Turbot.UpdateTimestamp,
+		})
+		resourceIds = append(resourceIds, control.Turbot.ResourceId)
+	}
+
+	d.SetId(filter)
+	written, err := writeOutputFile(d, controlList, []string{"id", "type", "resource", "state", "reason", "details", "create_timestamp", "update_timestamp"})
+	if err != nil {
+		return err
+	}
+	if written {
+		controlList, resourceIds = nil, nil
+	}
+	d.Set("controls", controlList)
+	d.Set("resource_ids", resourceIds)
+	return nil
+}
@@ -0,0 +1,33 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/resource"
+	"testing"
+)
+
+func TestAccControlsDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccControlsConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.turbot_controls.test", "controls.#", "1"),
+					resource.TestCheckResourceAttr("data.turbot_controls.test", "controls.0.type", "tmod:@turbot/turbot#/control/types/controlInstalled"),
+					resource.TestCheckResourceAttrSet("data.turbot_controls.test", "controls.0.state"),
+					resource.TestCheckResourceAttrSet("data.turbot_controls.test", "controls.0.update_timestamp"),
+				),
+			},
+		},
+	})
+}
+
+func testAccControlsConfig() string {
+	return `
+data "turbot_controls" "test" {
+	filter      = "controlType:tmod:@turbot/turbot#/control/types/controlInstalled"
+	max_results = 1
+}
+`
+}
@@ -0,0 +1,100 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+	"strings"
+)
+
+// the base type of all directory resource types (local, saml, google, ldap and turbot directories)
+const directoryResourceType = "tmod:@turbot/turbot-iam#/resource/types/directory"
+
+func dataSourceTurbotDirectories() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTurbotDirectoriesRead,
+		Schema: map[string]*schema.Schema{
+			"directories": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"title": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						// the resource type uri, e.g. tmod:@turbot/turbot-iam#/resource/types/samlDirectory
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"directory_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"parent": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"akas": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			// the turbot ids of the directories, for use with for_each
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTurbotDirectoriesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	properties := map[string]string{
+		"title":         "title",
+		"directoryType": "directoryType",
+		"status":        "status",
+	}
+	directories, err := client.ReadResourceList("resourceType:"+directoryResourceType, properties)
+	if err != nil {
+		return err
+	}
+
+	var directoryList []map[string]interface{}
+	var ids []string
+	for _, directory := range directories {
+		directoryList = append(directoryList, map[string]interface{}{
+			"id":             directory.Turbot.Id,
+			"title":          helpers.InterfaceToString(directory.Data["title"]),
+			"type":           directory.Type.Uri,
+			"directory_type": helpers.InterfaceToString(directory.Data["directoryType"]),
+			"status":         strings.ToUpper(helpers.InterfaceToString(directory.Data["status"])),
+			"parent":         directory.Turbot.ParentId,
+			"akas":           directory.Turbot.Akas,
+		})
+		ids = append(ids, directory.Turbot.Id)
+	}
+
+	d.SetId(directoryResourceType)
+	d.Set("directories", directoryList)
+	d.Set("ids", ids)
+	return nil
+}
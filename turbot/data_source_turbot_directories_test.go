@@ -0,0 +1,38 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/resource"
+	"testing"
+)
+
+func TestAccDirectoriesDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDirectoriesConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.turbot_directories.test", "directories.#"),
+					resource.TestCheckResourceAttrSet("data.turbot_directories.test", "directories.0.type"),
+					resource.TestCheckResourceAttrSet("data.turbot_directories.test", "directories.0.status"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDirectoriesConfig() string {
+	return `
+resource "turbot_local_directory" "test" {
+	parent              = "tmod:@turbot/turbot#/"
+	title               = "provider_test_directories"
+	description         = "test directory"
+	profile_id_template = "{{profile.email}}"
+}
+
+data "turbot_directories" "test" {
+	depends_on = [turbot_local_directory.test]
+}
+`
+}
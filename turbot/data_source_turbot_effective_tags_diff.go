@@ -0,0 +1,106 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+)
+
+// compare the tags of a resource in Turbot with a set of desired tags, so tag compliance can be checked
+// (e.g. in an output or a precondition) without external scripting
+func dataSourceTurbotEffectiveTagsDiff() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTurbotEffectiveTagsDiffRead,
+		Schema: map[string]*schema.Schema{
+			// the id or aka of the resource
+			"resource": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// the tags of the resource in Turbot
+			"effective_tags": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// the desired tags which the resource does not have, with their desired values
+			"missing": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// the tags of the resource which are not desired, with their values
+			"extra": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// the desired tags which the resource has with a different value, with the value of the resource
+			"different": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// true if no tags are missing or different - extra tags are allowed
+			"compliant": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceTurbotEffectiveTagsDiffRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	resource, err := client.ReadResource(d.Get("resource").(string), nil)
+	if err != nil {
+		return err
+	}
+	missing, extra, different := diffTags(d.Get("tags").(map[string]interface{}), resource.Turbot.Tags)
+
+	d.SetId(resource.Turbot.Id)
+	d.Set("effective_tags", resource.Turbot.Tags)
+	d.Set("missing", missing)
+	d.Set("extra", extra)
+	d.Set("different", different)
+	d.Set("compliant", len(missing) == 0 && len(different) == 0)
+	return nil
+}
+
+// compare the desired tags with the effective tags of a resource, returning the desired tags which are missing
+// (with their desired values), and the effective tags which are extra or different (with their effective values)
+func diffTags(desired, effective map[string]interface{}) (missing, extra, different map[string]interface{}) {
+	missing = map[string]interface{}{}
+	extra = map[string]interface{}{}
+	different = map[string]interface{}{}
+	for key, value := range desired {
+		effectiveValue, ok := effective[key]
+		switch {
+		case !ok:
+			missing[key] = value
+		case helpers.InterfaceToString(effectiveValue) != helpers.InterfaceToString(value):
+			different[key] = effectiveValue
+		}
+	}
+	for key, value := range effective {
+		if _, ok := desired[key]; !ok {
+			extra[key] = value
+		}
+	}
+	return
+}
@@ -0,0 +1,52 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/resource"
+	"testing"
+)
+
+func TestAccEffectiveTagsDiffDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckFolderDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEffectiveTagsDiffConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					// the effective tags include the sweeper tag added by the test provider
+					resource.TestCheckResourceAttr("data.turbot_effective_tags_diff.test", "effective_tags.%", "3"),
+					resource.TestCheckResourceAttr("data.turbot_effective_tags_diff.test", "missing.%", "1"),
+					resource.TestCheckResourceAttr("data.turbot_effective_tags_diff.test", "missing.CostCentre", "1234"),
+					resource.TestCheckResourceAttr("data.turbot_effective_tags_diff.test", "extra.%", "2"),
+					resource.TestCheckResourceAttr("data.turbot_effective_tags_diff.test", "extra.Name", "Provider Test"),
+					resource.TestCheckResourceAttr("data.turbot_effective_tags_diff.test", "different.%", "1"),
+					resource.TestCheckResourceAttr("data.turbot_effective_tags_diff.test", "different.Environment", "foo"),
+					resource.TestCheckResourceAttr("data.turbot_effective_tags_diff.test", "compliant", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccEffectiveTagsDiffConfig() string {
+	return `
+resource "turbot_folder" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	title = "provider_test_tags_diff"
+	description = "test folder for turbot terraform provider"
+	tags = {
+		"Name" = "Provider Test"
+		"Environment" = "foo"
+	}
+}
+
+data "turbot_effective_tags_diff" "test" {
+	resource = turbot_folder.test.id
+	tags = {
+		"Environment" = "prod"
+		"CostCentre" = "1234"
+	}
+}
+`
+}
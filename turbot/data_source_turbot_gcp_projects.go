@@ -0,0 +1,16 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceTurbotGcpProjects() *schema.Resource {
+	return &schema.Resource{
+		Read:   dataSourceTurbotGcpProjectsRead,
+		Schema: cloudAccountListSchema("projects", "project_id"),
+	}
+}
+
+func dataSourceTurbotGcpProjectsRead(d *schema.ResourceData, meta interface{}) error {
+	return readCloudAccountList(d, meta, "tmod:@turbot/gcp#/resource/types/project", "projectId", "projects", "project_id")
+}
@@ -0,0 +1,29 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/resource"
+	"testing"
+)
+
+func TestAccGcpProjectsDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGcpProjectsConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.turbot_gcp_projects.test", "projects.#"),
+					resource.TestCheckResourceAttrSet("data.turbot_gcp_projects.test", "projects.0.project_id"),
+					resource.TestCheckResourceAttrSet("data.turbot_gcp_projects.test", "projects.0.parent"),
+				),
+			},
+		},
+	})
+}
+
+func testAccGcpProjectsConfig() string {
+	return `
+data "turbot_gcp_projects" "test" {}
+`
+}
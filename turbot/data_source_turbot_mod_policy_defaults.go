@@ -0,0 +1,97 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+)
+
+// the default values of the policy types defined by a mod version, so configurations can
+// compare their intended policy settings against the mod defaults
+func dataSourceTurbotModPolicyDefaults() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTurbotModPolicyDefaultsRead,
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"mod": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// map of policy type uri -> default value, for policy types whose schema has a static default
+			"defaults": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// map of policy type uri -> default template, for policy types whose default is calculated
+			"default_templates": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"policy_type_uris": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTurbotModPolicyDefaultsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	org := d.Get("org").(string)
+	modName := d.Get("mod").(string)
+	version := d.Get("version").(string)
+
+	policyTypes, err := client.GetModVersionPolicyTypes(org, modName, version)
+	if err != nil {
+		return err
+	}
+
+	defaults := map[string]interface{}{}
+	defaultTemplates := map[string]interface{}{}
+	var uris []string
+	for _, policyType := range policyTypes {
+		uris = append(uris, policyType.Uri)
+		if defaultValue, ok := policyTypeDefault(policyType); ok {
+			value, err := helpers.InterfaceToScalarStringOrYaml(defaultValue)
+			if err != nil {
+				return fmt.Errorf("error converting default value of policy type %s: %s", policyType.Uri, err.Error())
+			}
+			defaults[policyType.Uri] = value
+		} else if policyType.DefaultTemplate != "" {
+			defaultTemplates[policyType.Uri] = policyType.DefaultTemplate
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s_%s", buildModAka(org, modName), version))
+	d.Set("defaults", defaults)
+	d.Set("default_templates", defaultTemplates)
+	d.Set("policy_type_uris", uris)
+	return nil
+}
+
+// return the static default value from the schema of the policy type, if there is one
+func policyTypeDefault(policyType apiClient.PolicyType) (interface{}, bool) {
+	policySchema, ok := policyType.Schema.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	defaultValue, ok := policySchema["default"]
+	return defaultValue, ok && defaultValue != nil
+}
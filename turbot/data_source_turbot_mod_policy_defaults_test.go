@@ -0,0 +1,32 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/resource"
+	"testing"
+)
+
+func TestAccModPolicyDefaultsDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccModPolicyDefaultsConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.turbot_mod_policy_defaults.test", "policy_type_uris.#"),
+					resource.TestCheckResourceAttrSet("data.turbot_mod_policy_defaults.test", "defaults.%"),
+				),
+			},
+		},
+	})
+}
+
+func testAccModPolicyDefaultsConfig() string {
+	return `
+data "turbot_mod_policy_defaults" "test" {
+  org     = "turbot"
+  mod     = "aws-s3"
+  version = "5.1.0"
+}
+`
+}
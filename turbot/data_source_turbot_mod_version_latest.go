@@ -0,0 +1,62 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resolve a version requirement of a mod to the latest version in the registry which satisfies it, using the same
+// rules as turbot_mod - so pipelines can pin the exact version, e.g. in generated tfvars
+func dataSourceTurbotModVersionLatest() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTurbotModVersionLatestRead,
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"mod": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// a semver constraint, e.g. ">=5.0.0 <6.0.0"
+			"version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "*",
+			},
+			// the release channel the version is chosen from: "stable" or "beta"
+			"channel": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  modChannelStable,
+			},
+			"latest_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceTurbotModVersionLatestRead(d *schema.ResourceData, meta interface{}) error {
+	org := d.Get("org").(string)
+	modName := d.Get("mod").(string)
+	version := d.Get("version").(string)
+	channel := d.Get("channel").(string)
+	if channel != modChannelStable && channel != modChannelBeta {
+		return attributeError("channel", fmt.Errorf("invalid value '%s' - must be one of '%s' or '%s'", channel, modChannelStable, modChannelBeta))
+	}
+
+	latestVersion, err := getLatestCompatibleVersion(org, modName, version, channel, meta)
+	if err != nil {
+		return err
+	}
+	if latestVersion == "" {
+		return fmt.Errorf("no version of mod %s found which satisfies the version requirement '%s' (channel %s)", buildModAka(org, modName), version, channel)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s@%s", org, modName, version))
+	d.Set("latest_version", latestVersion)
+	return nil
+}
@@ -0,0 +1,44 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func TestAccModVersionLatestDataSource_Basic(t *testing.T) {
+	latestProviderTestVersion := "5.0.2"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccModVersionLatestConfig("<5.0.2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.turbot_mod_version_latest.test", "latest_version", "5.0.1"),
+				),
+			},
+			{
+				Config: testAccModVersionLatestConfig(">=5.0.0"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.turbot_mod_version_latest.test", "latest_version", latestProviderTestVersion),
+				),
+			},
+			{
+				Config:      testAccModVersionLatestConfig("<1.0.0"),
+				ExpectError: regexp.MustCompile("no version of mod"),
+			},
+		},
+	})
+}
+
+func testAccModVersionLatestConfig(version string) string {
+	return fmt.Sprintf(`
+data "turbot_mod_version_latest" "test" {
+  org     = "turbot"
+  mod     = "turbot-terraform-provider-test"
+  version = "%s"
+}
+`, version)
+}
@@ -0,0 +1,117 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+)
+
+// the notifications for a resource, most recent first
+// this is intended for debugging applies - the notifications are also written to the debug log
+func dataSourceTurbotNotifications() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTurbotNotificationsRead,
+		Schema: map[string]*schema.Schema{
+			"resource": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// include notifications for descendants of the resource
+			"include_descendants": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// additional filter, e.g. notificationType:control_updated
+			"filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"limit": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  50,
+			},
+			"notifications": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"notification_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"timestamp": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"control_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"control_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"control_state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"control_reason": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTurbotNotificationsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	resourceAka := d.Get("resource").(string)
+
+	level := "self"
+	if d.Get("include_descendants").(bool) {
+		level = "self,descendant"
+	}
+	filter := fmt.Sprintf("resource:%s level:%s sort:-createTimestamp limit:%d", resourceAka, level, d.Get("limit").(int))
+	if extraFilter, ok := d.GetOk("filter"); ok {
+		filter = fmt.Sprintf("%s %s", filter, extraFilter.(string))
+	}
+
+	notifications, err := client.ReadNotifications(filter)
+	if err != nil {
+		return err
+	}
+
+	var notificationList []map[string]interface{}
+	for _, notification := range notifications {
+		client.Logf(helpers.LogResources, "[DEBUG] notification %s for resource %s: %s %s %s %s", notification.Turbot.CreateTimestamp, notification.Turbot.ResourceId,
+			notification.NotificationType, notification.Control.Type.Uri, notification.Control.State, notification.Control.Reason)
+		notificationList = append(notificationList, map[string]interface{}{
+			"id":                notification.Turbot.Id,
+			"notification_type": notification.NotificationType,
+			"timestamp":         notification.Turbot.CreateTimestamp,
+			"resource_id":       notification.Turbot.ResourceId,
+			"control_id":        notification.Turbot.ControlId,
+			"control_type":      notification.Control.Type.Uri,
+			"control_state":     notification.Control.State,
+			"control_reason":    notification.Control.Reason,
+		})
+	}
+
+	d.SetId(filter)
+	d.Set("notifications", notificationList)
+	return nil
+}
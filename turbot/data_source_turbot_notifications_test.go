@@ -0,0 +1,38 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/resource"
+	"testing"
+)
+
+func TestAccNotificationsDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckFolderDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNotificationsConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.turbot_notifications.test", "notifications.#"),
+					resource.TestCheckResourceAttrPair("data.turbot_notifications.test", "notifications.0.resource_id", "turbot_folder.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNotificationsConfig() string {
+	return `
+resource "turbot_folder" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	title = "provider_test_notifications"
+	description = "provider_test_notifications"
+}
+
+data "turbot_notifications" "test" {
+	resource = turbot_folder.test.id
+	limit = 10
+}
+`
+}
@@ -0,0 +1,94 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+)
+
+// the permission types and permission levels available in the workspace, e.g. to validate grant inputs
+func dataSourceTurbotPermissionTypes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTurbotPermissionTypesRead,
+		Schema: map[string]*schema.Schema{
+			// optional filter for the permission types, e.g. "aws"
+			"filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"permission_types":  permissionTypeListSchema(),
+			"permission_levels": permissionTypeListSchema(),
+			// the uris of the permission types and levels, for use with contains()
+			"permission_type_uris": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"permission_level_uris": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func permissionTypeListSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"uri": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"title": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"description": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTurbotPermissionTypesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	filter := d.Get("filter").(string)
+
+	permissions, err := client.ReadPermissionTypes(filter)
+	if err != nil {
+		return err
+	}
+
+	permissionTypes, permissionTypeUris := flattenPermissionTypes(permissions.PermissionTypes.Items)
+	permissionLevels, permissionLevelUris := flattenPermissionTypes(permissions.PermissionLevels.Items)
+
+	d.SetId("permission_types " + filter)
+	d.Set("permission_types", permissionTypes)
+	d.Set("permission_type_uris", permissionTypeUris)
+	d.Set("permission_levels", permissionLevels)
+	d.Set("permission_level_uris", permissionLevelUris)
+	return nil
+}
+
+func flattenPermissionTypes(items []apiClient.PermissionType) ([]map[string]interface{}, []string) {
+	var result []map[string]interface{}
+	var uris []string
+	for _, item := range items {
+		result = append(result, map[string]interface{}{
+			"uri":         item.Uri,
+			"title":       item.Title,
+			"description": item.Description,
+		})
+		uris = append(uris, item.Uri)
+	}
+	return result, uris
+}
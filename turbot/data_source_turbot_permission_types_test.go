@@ -0,0 +1,33 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/resource"
+	"testing"
+)
+
+func TestAccPermissionTypesDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPermissionTypesConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.turbot_permission_types.test", "permission_types.#"),
+					resource.TestCheckResourceAttrSet("data.turbot_permission_types.test", "permission_levels.#"),
+					resource.TestCheckOutput("has_turbot_admin", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPermissionTypesConfig() string {
+	return `
+data "turbot_permission_types" "test" {}
+
+output "has_turbot_admin" {
+	value = contains(data.turbot_permission_types.test.permission_level_uris, "tmod:@turbot/turbot-iam#/permission/levels/admin")
+}
+`
+}
@@ -0,0 +1,105 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+)
+
+// look up the policy setting of a policy type made directly on a resource - unlike turbot_policy_value, which
+// returns the effective value, this returns the setting itself, so settings made outside Terraform (e.g. by a mod
+// or in the console) can be read
+func dataSourceTurbotPolicySetting() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTurbotPolicySettingRead,
+		Schema: map[string]*schema.Schema{
+			// the id or aka of the resource the setting is made on
+			"resource": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// the uri of the policy type
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// structured values are returned as YAML
+			"value": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"value_source": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"precedence": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"template": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"template_input": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"note": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"valid_from_timestamp": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"valid_to_timestamp": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"resource_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceTurbotPolicySettingRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	policyTypeUri := d.Get("type").(string)
+	resourceAka := d.Get("resource").(string)
+
+	// only settings made on the resource itself, not those inherited from its ancestors
+	filter := fmt.Sprintf("policyTypeId:'%s' resourceId:'%s' level:self", policyTypeUri, resourceAka)
+	settings, err := client.ReadPolicySettingList(filter)
+	if err != nil {
+		return err
+	}
+	if len(settings) == 0 {
+		return fmt.Errorf("no policy setting for policy type '%s' found on resource '%s'", policyTypeUri, resourceAka)
+	}
+	policySetting := settings[0]
+
+	value, err := helpers.InterfaceToScalarStringOrYaml(policySetting.Value)
+	if err != nil {
+		return err
+	}
+	templateInput, err := helpers.InterfaceToScalarStringOrYaml(policySetting.TemplateInput)
+	if err != nil {
+		return err
+	}
+
+	// assign results back into ResourceData
+	d.SetId(policySetting.Turbot.Id)
+	d.Set("value", value)
+	d.Set("value_source", policySetting.ValueSource)
+	d.Set("precedence", policySetting.Precedence)
+	d.Set("template", policySetting.Template)
+	d.Set("template_input", templateInput)
+	d.Set("note", policySetting.Note)
+	d.Set("valid_from_timestamp", policySetting.ValidFromTimestamp)
+	d.Set("valid_to_timestamp", policySetting.ValidToTimestamp)
+	d.Set("resource_id", policySetting.Turbot.ResourceId)
+	return nil
+}
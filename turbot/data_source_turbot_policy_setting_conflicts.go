@@ -0,0 +1,223 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// a setting which has no effect, as a REQUIRED setting of the same policy type on an ancestor takes precedence
+	policySettingConflictRequired = "required_override"
+	// a setting whose valid_to_timestamp has passed, which is still present
+	policySettingConflictExpired = "expired"
+)
+
+// report mistakes in the policy hierarchy of a scope: settings which are overridden by a REQUIRED setting
+// higher in the hierarchy, and expired settings which have not been removed
+func dataSourceTurbotPolicySettingConflicts() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTurbotPolicySettingConflictsRead,
+		Schema: map[string]*schema.Schema{
+			// the id or aka of the resource whose policy settings (and those of its descendants) are checked
+			"scope": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "tmod:@turbot/turbot#/",
+			},
+			// an additional filter for the policy settings, e.g. "policyTypeId:..."
+			"filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"conflicts": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						// required_override or expired
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"policy_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"setting_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						// for a required_override conflict, the REQUIRED setting which takes precedence
+						"conflicting_setting_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"conflicting_resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"message": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type policySettingConflict struct {
+	conflictType          string
+	setting               apiClient.PolicySetting
+	conflictingSetting    *apiClient.PolicySetting
+	conflictingResourceId string
+	message               string
+}
+
+func dataSourceTurbotPolicySettingConflictsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	scope, err := client.ReadResource(d.Get("scope").(string), nil)
+	if err != nil {
+		return err
+	}
+	// include the settings of the ancestors of the scope, as a REQUIRED setting above the scope overrides the settings in it
+	filter := fmt.Sprintf("resourceId:'%s' level:self,ancestor,descendant", scope.Turbot.Id)
+	if extraFilter, ok := d.GetOk("filter"); ok {
+		filter = fmt.Sprintf("%s %s", filter, extraFilter.(string))
+	}
+
+	settings, err := client.ReadPolicySettingList(filter)
+	if err != nil {
+		return err
+	}
+	resourcePaths, err := readPolicySettingResourcePaths(settings, client)
+	if err != nil {
+		return err
+	}
+
+	var conflictList []map[string]interface{}
+	for _, conflict := range findPolicySettingConflicts(settings, resourcePaths, scope.Turbot.Id, time.Now()) {
+		conflictingSettingId := ""
+		if conflict.conflictingSetting != nil {
+			conflictingSettingId = conflict.conflictingSetting.Turbot.Id
+		}
+		conflictList = append(conflictList, map[string]interface{}{
+			"type":                    conflict.conflictType,
+			"policy_type":             conflict.setting.Type.Uri,
+			"setting_id":              conflict.setting.Turbot.Id,
+			"resource_id":             conflict.setting.Turbot.ResourceId,
+			"conflicting_setting_id":  conflictingSettingId,
+			"conflicting_resource_id": conflict.conflictingResourceId,
+			"message":                 conflict.message,
+		})
+	}
+
+	d.SetId(filter)
+	d.Set("conflicts", conflictList)
+	return nil
+}
+
+// read the path (the ids of the ancestors of the resource, and the resource itself) of the resource of each setting,
+// which is needed to find the settings which are overridden. the resources are read in a single list query
+func readPolicySettingResourcePaths(settings []apiClient.PolicySetting, client *apiClient.Client) (map[string][]string, error) {
+	resourcePaths := map[string][]string{}
+	var resourceIds []string
+	for _, setting := range settings {
+		resourceId := setting.Turbot.ResourceId
+		if _, ok := resourcePaths[resourceId]; ok {
+			continue
+		}
+		resourcePaths[resourceId] = nil
+		resourceIds = append(resourceIds, resourceId)
+	}
+	if len(resourceIds) == 0 {
+		return resourcePaths, nil
+	}
+	resources, err := client.ReadResourceList(fmt.Sprintf("resourceId:%s level:self", strings.Join(resourceIds, ",")), nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, resource := range resources {
+		resourcePaths[resource.Turbot.Id] = strings.Split(resource.Turbot.Path, ".")
+	}
+	return resourcePaths, nil
+}
+
+// find the conflicting settings, given the path (the ids of the ancestors of the resource, and the resource itself)
+// of the resource of each setting. only the settings on the scope and its descendants are reported, the settings on
+// its ancestors are only used to find the settings they override. the conflicts are sorted by policy type, resource
+// and setting
+func findPolicySettingConflicts(settings []apiClient.PolicySetting, resourcePaths map[string][]string, scopeId string, now time.Time) []policySettingConflict {
+	var conflicts []policySettingConflict
+
+	// the unexpired REQUIRED settings: policy type -> resource id -> setting
+	required := map[string]map[string]*apiClient.PolicySetting{}
+	for i, setting := range settings {
+		if setting.Precedence != "REQUIRED" || policySettingExpired(setting, now) {
+			continue
+		}
+		if required[setting.Type.Uri] == nil {
+			required[setting.Type.Uri] = map[string]*apiClient.PolicySetting{}
+		}
+		required[setting.Type.Uri][setting.Turbot.ResourceId] = &settings[i]
+	}
+
+	for _, setting := range settings {
+		path := resourcePaths[setting.Turbot.ResourceId]
+		if !helpers.SliceContains(path, scopeId) {
+			continue
+		}
+		if policySettingExpired(setting, now) {
+			conflicts = append(conflicts, policySettingConflict{
+				conflictType: policySettingConflictExpired,
+				setting:      setting,
+				message:      fmt.Sprintf("setting expired at %s but has not been deleted", setting.ValidToTimestamp),
+			})
+			continue
+		}
+		// find the nearest REQUIRED setting of the same type on an ancestor of the resource
+		for i := len(path) - 2; i >= 0; i-- {
+			if ancestorSetting, ok := required[setting.Type.Uri][path[i]]; ok {
+				conflicts = append(conflicts, policySettingConflict{
+					conflictType:          policySettingConflictRequired,
+					setting:               setting,
+					conflictingSetting:    ancestorSetting,
+					conflictingResourceId: path[i],
+					message:               fmt.Sprintf("setting has no effect - REQUIRED setting %s on ancestor %s takes precedence", ancestorSetting.Turbot.Id, path[i]),
+				})
+				break
+			}
+		}
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		a, b := conflicts[i].setting, conflicts[j].setting
+		if a.Type.Uri != b.Type.Uri {
+			return a.Type.Uri < b.Type.Uri
+		}
+		if a.Turbot.ResourceId != b.Turbot.ResourceId {
+			return turbotIdLess(a.Turbot.ResourceId, b.Turbot.ResourceId)
+		}
+		return turbotIdLess(a.Turbot.Id, b.Turbot.Id)
+	})
+	return conflicts
+}
+
+// has the valid_to_timestamp of the setting passed
+func policySettingExpired(setting apiClient.PolicySetting, now time.Time) bool {
+	if setting.ValidToTimestamp == "" {
+		return false
+	}
+	validTo, err := time.Parse(time.RFC3339, setting.ValidToTimestamp)
+	return err == nil && validTo.Before(now)
+}
@@ -0,0 +1,113 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"testing"
+	"time"
+)
+
+func TestFindPolicySettingConflicts(t *testing.T) {
+	setting := func(id, resourceId, policyType, precedence, validTo string) apiClient.PolicySetting {
+		s := apiClient.PolicySetting{Precedence: precedence, ValidToTimestamp: validTo}
+		s.Type.Uri = policyType
+		s.Turbot.Id = id
+		s.Turbot.ResourceId = resourceId
+		return s
+	}
+	const regions = "tmod:@turbot/aws#/policy/types/approvedRegionsDefault"
+	const tags = "tmod:@turbot/aws#/policy/types/accountTags"
+	// root 1 > folder 10 (the scope) > folder 100 > account 1000, and folder 11 outside the scope
+	resourcePaths := map[string][]string{
+		"1":    {"1"},
+		"10":   {"1", "10"},
+		"11":   {"1", "11"},
+		"100":  {"1", "10", "100"},
+		"1000": {"1", "10", "100", "1000"},
+	}
+	now := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	settings := []apiClient.PolicySetting{
+		// REQUIRED on an ancestor of the scope, overriding the settings in the scope
+		setting("5001", "1", tags, "REQUIRED", ""),
+		// expired, but above the scope, so not reported
+		setting("5002", "1", regions, "RECOMMENDED", "2020-01-01T00:00:00Z"),
+		setting("5003", "10", regions, "REQUIRED", ""),
+		setting("5004", "100", regions, "RECOMMENDED", ""),
+		setting("5005", "1000", regions, "REQUIRED", ""),
+		setting("5006", "1000", tags, "RECOMMENDED", ""),
+		setting("5007", "100", tags, "RECOMMENDED", "2020-05-01T00:00:00Z"),
+		// not yet expired
+		setting("5008", "10", "tmod:@turbot/aws#/policy/types/accountStack", "RECOMMENDED", "2020-07-01T00:00:00Z"),
+		// outside the scope
+		setting("5009", "11", regions, "RECOMMENDED", ""),
+	}
+
+	var result []string
+	for _, conflict := range findPolicySettingConflicts(settings, resourcePaths, "10", now) {
+		result = append(result, fmt.Sprintf("%s %s %s", conflict.conflictType, conflict.setting.Turbot.Id, conflict.conflictingResourceId))
+	}
+	assert.Equal(t, []string{
+		"expired 5007 ",
+		"required_override 5006 1",
+		// the nearest REQUIRED setting is reported
+		"required_override 5004 10",
+		"required_override 5005 10",
+	}, result)
+}
+
+func TestAccPolicySettingConflictsDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicySettingConflictsConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.turbot_policy_setting_conflicts.test", "conflicts.#", "1"),
+					resource.TestCheckResourceAttr("data.turbot_policy_setting_conflicts.test", "conflicts.0.type", "required_override"),
+					resource.TestCheckResourceAttrPair("data.turbot_policy_setting_conflicts.test", "conflicts.0.setting_id", "turbot_policy_setting.child", "id"),
+					resource.TestCheckResourceAttrPair("data.turbot_policy_setting_conflicts.test", "conflicts.0.conflicting_setting_id", "turbot_policy_setting.parent", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPolicySettingConflictsConfig() string {
+	return fmt.Sprintf(`
+resource "turbot_folder" "parent" {
+	parent      = "tmod:@turbot/turbot#/"
+	title       = "provider_test_conflicts"
+	description = "policy setting conflicts test"
+}
+
+resource "turbot_folder" "child" {
+	parent      = turbot_folder.parent.id
+	title       = "provider_test_conflicts_child"
+	description = "policy setting conflicts test"
+}
+
+resource "turbot_policy_setting" "parent" {
+	resource   = turbot_folder.parent.id
+	type       = "%[1]s"
+	value      = "parent"
+	precedence = "REQUIRED"
+}
+
+resource "turbot_policy_setting" "child" {
+	resource   = turbot_folder.child.id
+	type       = "%[1]s"
+	value      = "child"
+	precedence = "REQUIRED"
+}
+
+data "turbot_policy_setting_conflicts" "test" {
+	scope  = turbot_folder.parent.id
+	filter = "policyTypeId:'%[1]s'"
+
+	depends_on = [turbot_policy_setting.parent, turbot_policy_setting.child]
+}
+`, stringPolicyType)
+}
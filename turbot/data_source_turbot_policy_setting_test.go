@@ -0,0 +1,68 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/resource"
+	"testing"
+)
+
+func TestAccPolicySettingDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPolicySettingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicySettingDataSourceConfig(stringPolicyType, `"test"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.turbot_policy_setting.test", "id", "turbot_policy_setting.test_policy", "id"),
+					resource.TestCheckResourceAttrPair("data.turbot_policy_setting.test", "resource_id", "turbot_folder.test", "id"),
+					resource.TestCheckResourceAttr("data.turbot_policy_setting.test", "value", "test"),
+					resource.TestCheckResourceAttr("data.turbot_policy_setting.test", "precedence", "REQUIRED"),
+					resource.TestCheckResourceAttr("data.turbot_policy_setting.test", "note", "set by the provider tests"),
+				),
+			},
+		},
+	})
+}
+
+// a number is returned as a plain string, not YAML
+func TestAccPolicySettingDataSource_Integer(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPolicySettingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicySettingDataSourceConfig(intPolicyType, "30"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.turbot_policy_setting.test", "id", "turbot_policy_setting.test_policy", "id"),
+					resource.TestCheckResourceAttr("data.turbot_policy_setting.test", "value", "30"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPolicySettingDataSourceConfig(policyType, value string) string {
+	return fmt.Sprintf(`
+resource "turbot_folder" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	title = "provider_test_policy_setting_data_source"
+	description = "test folder for turbot terraform provider"
+}
+
+resource "turbot_policy_setting" "test_policy" {
+	resource = turbot_folder.test.id
+	type = "%s"
+	value = %s
+	precedence = "REQUIRED"
+	note = "set by the provider tests"
+}
+
+data "turbot_policy_setting" "test" {
+	resource = turbot_policy_setting.test_policy.resource
+	type = turbot_policy_setting.test_policy.type
+}
+`, policyType, value)
+}
@@ -0,0 +1,112 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"reflect"
+	"sort"
+)
+
+func dataSourceTurbotPolicyTypesDiff() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTurbotPolicyTypesDiffRead,
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"mod": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"from_version": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"to_version": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"added": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"removed": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"changed": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTurbotPolicyTypesDiffRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	org := d.Get("org").(string)
+	modName := d.Get("mod").(string)
+	fromVersion := d.Get("from_version").(string)
+	toVersion := d.Get("to_version").(string)
+
+	fromPolicyTypes, err := client.GetModVersionPolicyTypes(org, modName, fromVersion)
+	if err != nil {
+		return err
+	}
+	toPolicyTypes, err := client.GetModVersionPolicyTypes(org, modName, toVersion)
+	if err != nil {
+		return err
+	}
+
+	added, removed, changed := diffPolicyTypes(fromPolicyTypes, toPolicyTypes)
+
+	d.SetId(fmt.Sprintf("%s_%s_%s", buildModAka(org, modName), fromVersion, toVersion))
+	d.Set("added", added)
+	d.Set("removed", removed)
+	d.Set("changed", changed)
+	return nil
+}
+
+// compare 2 sets of policy types by uri, returning the sorted uris of the added, removed and changed types
+// a policy type is changed if its schema or default template differ
+func diffPolicyTypes(from, to []apiClient.PolicyType) (added, removed, changed []string) {
+	fromMap := map[string]apiClient.PolicyType{}
+	for _, policyType := range from {
+		fromMap[policyType.Uri] = policyType
+	}
+	toMap := map[string]apiClient.PolicyType{}
+	for _, policyType := range to {
+		toMap[policyType.Uri] = policyType
+	}
+
+	for uri, toPolicyType := range toMap {
+		fromPolicyType, ok := fromMap[uri]
+		if !ok {
+			added = append(added, uri)
+			continue
+		}
+		if fromPolicyType.DefaultTemplate != toPolicyType.DefaultTemplate || !reflect.DeepEqual(fromPolicyType.Schema, toPolicyType.Schema) {
+			changed = append(changed, uri)
+		}
+	}
+	for uri := range fromMap {
+		if _, ok := toMap[uri]; !ok {
+			removed = append(removed, uri)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(changed)
+	return
+}
@@ -0,0 +1,83 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"testing"
+)
+
+func TestAccPolicyTypesDiffDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyTypesDiffConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.turbot_policy_types_diff.test", "added.#"),
+					resource.TestCheckResourceAttr("data.turbot_policy_types_diff.test", "removed.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPolicyTypesDiffConfig() string {
+	return `
+data "turbot_policy_types_diff" "test" {
+  org          = "turbot"
+  mod          = "aws-s3"
+  from_version = "5.0.0"
+  to_version   = "5.1.0"
+}
+`
+}
+
+func TestDiffPolicyTypes(t *testing.T) {
+	bucketVersioning := apiClient.PolicyType{
+		Uri:             "tmod:@turbot/aws-s3#/policy/types/bucketVersioning",
+		Schema:          map[string]interface{}{"type": "string", "enum": []interface{}{"Skip", "Enforce"}},
+		DefaultTemplate: "Skip",
+	}
+	bucketTags := apiClient.PolicyType{
+		Uri:    "tmod:@turbot/aws-s3#/policy/types/bucketTags",
+		Schema: map[string]interface{}{"type": "string"},
+	}
+	bucketTagsTemplate := apiClient.PolicyType{
+		Uri:             "tmod:@turbot/aws-s3#/policy/types/bucketTagsTemplate",
+		Schema:          map[string]interface{}{"type": "string"},
+		DefaultTemplate: "[]",
+	}
+	// the same uri as bucketVersioning, with a different schema or default template
+	bucketVersioningSchemaChanged := bucketVersioning
+	bucketVersioningSchemaChanged.Schema = map[string]interface{}{"type": "string", "enum": []interface{}{"Skip", "Check", "Enforce"}}
+	bucketVersioningTemplateChanged := bucketVersioning
+	bucketVersioningTemplateChanged.DefaultTemplate = "Enforce"
+
+	type test struct {
+		name            string
+		from            []apiClient.PolicyType
+		to              []apiClient.PolicyType
+		expectedAdded   []string
+		expectedRemoved []string
+		expectedChanged []string
+	}
+	tests := []test{
+		{"both empty", nil, nil, nil, nil, nil},
+		{"from empty", nil, []apiClient.PolicyType{bucketVersioning, bucketTags}, []string{bucketTags.Uri, bucketVersioning.Uri}, nil, nil},
+		{"to empty", []apiClient.PolicyType{bucketVersioning, bucketTags}, nil, nil, []string{bucketTags.Uri, bucketVersioning.Uri}, nil},
+		{"unchanged", []apiClient.PolicyType{bucketVersioning, bucketTags}, []apiClient.PolicyType{bucketTags, bucketVersioning}, nil, nil, nil},
+		{"added", []apiClient.PolicyType{bucketTags}, []apiClient.PolicyType{bucketTags, bucketTagsTemplate}, []string{bucketTagsTemplate.Uri}, nil, nil},
+		{"removed", []apiClient.PolicyType{bucketTags, bucketTagsTemplate}, []apiClient.PolicyType{bucketTags}, nil, []string{bucketTagsTemplate.Uri}, nil},
+		{"schema changed", []apiClient.PolicyType{bucketVersioning}, []apiClient.PolicyType{bucketVersioningSchemaChanged}, nil, nil, []string{bucketVersioning.Uri}},
+		{"default template changed", []apiClient.PolicyType{bucketVersioning}, []apiClient.PolicyType{bucketVersioningTemplateChanged}, nil, nil, []string{bucketVersioning.Uri}},
+		{"added, removed and changed", []apiClient.PolicyType{bucketVersioning, bucketTags}, []apiClient.PolicyType{bucketVersioningSchemaChanged, bucketTagsTemplate}, []string{bucketTagsTemplate.Uri}, []string{bucketTags.Uri}, []string{bucketVersioning.Uri}},
+	}
+	for _, test := range tests {
+		added, removed, changed := diffPolicyTypes(test.from, test.to)
+		assert.Equal(t, test.expectedAdded, added, test.name)
+		assert.Equal(t, test.expectedRemoved, removed, test.name)
+		assert.Equal(t, test.expectedChanged, changed, test.name)
+	}
+}
@@ -1,9 +1,9 @@
 package turbot
 
 import (
-	"fmt"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
 )
 
 func dataSourceTurbotPolicyValue() *schema.Resource {
@@ -64,10 +64,16 @@ func dataSourceTurbotPolicyValueRead(d *schema.ResourceData, meta interface{}) e
 		return err
 	}
 
+	// structured values (e.g. a list of regions) are returned as YAML, so they can be decoded using yamldecode
+	value, err := helpers.InterfaceToScalarStringOrYaml(policyValue.Value)
+	if err != nil {
+		return err
+	}
+
 	// assign results back into ResourceData
 	d.SetId(policyValue.Turbot.Id)
 
-	d.Set("value", fmt.Sprintf("%v", policyValue.Value))
+	d.Set("value", value)
 	d.Set("value_source", policyValue.Setting.ValueSource)
 	d.Set("precedence", policyValue.Precedence)
 	d.Set("state", policyValue.State)
@@ -13,6 +13,14 @@ func dataSourceTurbotResource() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			// if set, only these properties are included in data
+			"properties": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"data": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -49,7 +57,11 @@ func dataSourceTurbotResource() *schema.Resource {
 func dataSourceTurbotResourceRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
 	resourceAka := d.Get("id").(string)
-	resource, err := client.ReadSerializableResource(resourceAka)
+	var properties []string
+	for _, property := range d.Get("properties").([]interface{}) {
+		properties = append(properties, property.(string))
+	}
+	resource, err := client.ReadSerializableResource(resourceAka, properties)
 	if err != nil {
 		if apiClient.NotFoundError(err) {
 			// setting was not found - clear id
@@ -57,7 +69,7 @@ func dataSourceTurbotResourceRead(d *schema.ResourceData, meta interface{}) erro
 		}
 		return err
 	}
-	d.SetId(resource.Turbot["id"])
+	d.SetId(resource.TurbotMetadata.Id)
 	d.Set("data", resource.Data)
 	d.Set("metadata", resource.Metadata)
 	d.Set("tags", resource.Tags)
@@ -0,0 +1,111 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"time"
+)
+
+// the recent changes made to a resource (created, updated, deleted) and the identity which made each change,
+// most recent first
+func dataSourceTurbotResourceActivity() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTurbotResourceActivityRead,
+		Schema: map[string]*schema.Schema{
+			"resource": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"limit": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  20,
+			},
+			// only return activity after this time (RFC3339)
+			"since": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"activity": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"notification_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"timestamp": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"actor_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"actor_title": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"new_version_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"old_version_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTurbotResourceActivityRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	resourceAka := d.Get("resource").(string)
+
+	var since time.Time
+	if value, ok := d.GetOk("since"); ok {
+		var err error
+		if since, err = time.Parse(time.RFC3339, value.(string)); err != nil {
+			return fmt.Errorf("since must be an RFC3339 timestamp, e.g. 2020-07-20T00:00:00Z: %s", err.Error())
+		}
+	}
+
+	filter := fmt.Sprintf("resource:%s level:self notificationClass:resource sort:-createTimestamp limit:%d", resourceAka, d.Get("limit").(int))
+	activity, err := client.ReadResourceActivity(filter)
+	if err != nil {
+		return err
+	}
+
+	var activityList []map[string]interface{}
+	for _, item := range activity {
+		// the activity is sorted most recent first, so stop at the first item before 'since'
+		if !since.IsZero() {
+			timestamp, err := time.Parse(time.RFC3339, item.Turbot.CreateTimestamp)
+			if err == nil && timestamp.Before(since) {
+				break
+			}
+		}
+		activityList = append(activityList, map[string]interface{}{
+			"id":                item.Turbot.Id,
+			"notification_type": item.NotificationType,
+			"timestamp":         item.Turbot.CreateTimestamp,
+			"actor_id":          item.Actor.Identity.Turbot.Id,
+			"actor_title":       item.Actor.Identity.Turbot.Title,
+			"new_version_id":    item.Turbot.ResourceNewVersionId,
+			"old_version_id":    item.Turbot.ResourceOldVersionId,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%s %s", filter, d.Get("since").(string)))
+	d.Set("activity", activityList)
+	return nil
+}
@@ -0,0 +1,38 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/resource"
+	"testing"
+)
+
+func TestAccResourceActivityDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckFolderDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceActivityConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.turbot_resource_activity.test", "activity.0.notification_type", "resource_created"),
+					resource.TestCheckResourceAttrSet("data.turbot_resource_activity.test", "activity.0.actor_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceActivityConfig() string {
+	return `
+resource "turbot_folder" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	title = "provider_test_resource_activity"
+	description = "provider_test_resource_activity"
+}
+
+data "turbot_resource_activity" "test" {
+	resource = turbot_folder.test.id
+	limit = 5
+}
+`
+}
@@ -0,0 +1,47 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+)
+
+// resolve any aka of a resource to its id and the full list of its akas
+func dataSourceTurbotResourceAkas() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTurbotResourceAkasRead,
+		Schema: map[string]*schema.Schema{
+			"aka": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"akas": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTurbotResourceAkasRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	aka := d.Get("aka").(string)
+	resource, err := client.ReadResource(aka, nil)
+	if err != nil {
+		if apiClient.NotFoundError(err) {
+			// resource was not found - clear id
+			d.SetId("")
+		}
+		return err
+	}
+	akas := resource.Turbot.Akas
+	// if this resource has no akas, just use the one passed in
+	if akas == nil {
+		akas = []string{aka}
+	}
+	d.SetId(resource.Turbot.Id)
+	d.Set("akas", akas)
+	return nil
+}
@@ -0,0 +1,38 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/resource"
+	"testing"
+)
+
+func TestAccResourceAkasDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceAkasDataSourceConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					// the aka of the root resolves to the id stored as the parent of the folder
+					resource.TestCheckResourceAttrPair(
+						"data.turbot_resource_akas.test", "id", "turbot_folder.test", "parent"),
+					resource.TestCheckResourceAttr("data.turbot_resource_akas.test", "akas.0", "tmod:@turbot/turbot#/"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceAkasDataSourceConfig() string {
+	return `
+resource "turbot_folder" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	title = "provider_test"
+	description = "test folder for turbot terraform provider"
+}
+
+data "turbot_resource_akas" "test" {
+  aka = "tmod:@turbot/turbot#/"
+}
+`
+}
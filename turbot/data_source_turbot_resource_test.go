@@ -23,6 +23,22 @@ func TestAccResourceDataSource_Basic(t *testing.T) {
 	})
 }
 
+func TestAccResourceDataSource_Properties(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDataSourcePropertiesConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.turbot_resource.test_resource", "data", "{\n \"title\": \"provider_test\"\n}"),
+				),
+			},
+		},
+	})
+}
+
 func testAccResourceDataSourceConfig() string {
 	return `
 resource "turbot_folder" "test" {
@@ -39,3 +55,18 @@ data "turbot_resource" "test_resource" {
 }
 `
 }
+
+func testAccResourceDataSourcePropertiesConfig() string {
+	return `
+resource "turbot_folder" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	title = "provider_test"
+	description = "test folder for turbot terraform provider"
+}
+
+data "turbot_resource" "test_resource" {
+  id = turbot_folder.test.id
+  properties = ["title"]
+}
+`
+}
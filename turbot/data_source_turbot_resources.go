@@ -0,0 +1,123 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+)
+
+// all resources matching a Turbot filter, e.g. "resourceType:tmod:@turbot/aws#/resource/types/account"
+func dataSourceTurbotResources() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTurbotResourcesRead,
+		Schema: withOutputFileSchema(map[string]*schema.Schema{
+			"filter": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// the paths of the properties to return in the data of each resource
+			"properties": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"resources": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"title": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"parent": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"akas": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						// JSON representation of the selected properties
+						"data": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			// the turbot ids of the resources, for use with for_each
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		}),
+	}
+}
+
+func dataSourceTurbotResourcesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	filter := d.Get("filter").(string)
+
+	// property paths may not be valid graphql aliases, so alias each property by its index
+	properties := map[string]string{}
+	for i, property := range d.Get("properties").([]interface{}) {
+		properties[fmt.Sprintf("property%d", i)] = property.(string)
+	}
+
+	resources, err := client.ReadResourceList(filter, properties)
+	if err != nil {
+		return err
+	}
+
+	var resourceList []map[string]interface{}
+	var ids []string
+	for _, resource := range resources {
+		data := map[string]interface{}{}
+		for alias, property := range properties {
+			data[property] = resource.Data[alias]
+		}
+		dataJson, err := helpers.MapToJsonString(data)
+		if err != nil {
+			return err
+		}
+		resourceList = append(resourceList, map[string]interface{}{
+			"id":     resource.Turbot.Id,
+			"title":  resource.Turbot.Title,
+			"type":   resource.Type.Uri,
+			"parent": resource.Turbot.ParentId,
+			"akas":   resource.Turbot.Akas,
+			"data":   dataJson,
+		})
+		ids = append(ids, resource.Turbot.Id)
+	}
+
+	d.SetId(filter)
+	written, err := writeOutputFile(d, resourceList, []string{"id", "title", "type", "parent", "akas", "data"})
+	if err != nil {
+		return err
+	}
+	if written {
+		resourceList, ids = nil, nil
+	}
+	d.Set("resources", resourceList)
+	d.Set("ids", ids)
+	return nil
+}
@@ -0,0 +1,100 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAccResourcesDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckFolderDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourcesConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.turbot_resources.test", "resources.#", "2"),
+					resource.TestCheckResourceAttr("data.turbot_resources.test", "ids.#", "2"),
+					resource.TestCheckResourceAttrPair("data.turbot_resources.test", "resources.0.parent", "turbot_folder.parent", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourcesDataSource_OutputFile(t *testing.T) {
+	outputFile := filepath.Join(os.TempDir(), "provider_test_resources.csv")
+	defer os.Remove(outputFile)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckFolderDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourcesOutputFileConfig(outputFile),
+				Check: resource.ComposeTestCheckFunc(
+					// the results are written to the file rather than the state
+					resource.TestCheckResourceAttr("data.turbot_resources.test", "resources.#", "0"),
+					resource.TestCheckResourceAttr("data.turbot_resources.test", "ids.#", "0"),
+					resource.TestCheckResourceAttrSet("data.turbot_resources.test", "output_checksum"),
+					testAccCheckOutputFileLines(outputFile, 3),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourcesConfig() string {
+	return `
+resource "turbot_folder" "parent" {
+	parent = "tmod:@turbot/turbot#/"
+	title = "provider_test_resources"
+	description = "provider_test_resources"
+}
+
+resource "turbot_folder" "child_1" {
+	parent = turbot_folder.parent.id
+	title = "provider_test_resources_1"
+	description = "provider_test_resources_1"
+}
+
+resource "turbot_folder" "child_2" {
+	parent = turbot_folder.parent.id
+	title = "provider_test_resources_2"
+	description = "provider_test_resources_2"
+}
+
+data "turbot_resources" "test" {
+	filter = "resourceType:tmod:@turbot/turbot#/resource/types/folder resourceId:${turbot_folder.parent.id} level:descendant"
+	properties = ["description"]
+	depends_on = [turbot_folder.child_1, turbot_folder.child_2]
+}
+`
+}
+
+func testAccResourcesOutputFileConfig(outputFile string) string {
+	return strings.Replace(testAccResourcesConfig(), `properties = ["description"]`, fmt.Sprintf(`properties = ["description"]
+	output_file = "%s"
+	output_format = "csv"`, outputFile), 1)
+}
+
+// check the output file has the given number of lines - a header row and a row per result
+func testAccCheckOutputFileLines(outputFile string, expected int) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		content, err := ioutil.ReadFile(outputFile)
+		if err != nil {
+			return err
+		}
+		if lines := strings.Count(string(content), "\n"); lines != expected {
+			return fmt.Errorf("expected %d lines in %s, got %d", expected, outputFile, lines)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,35 @@
+package turbot
+
+import (
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/zclconf/go-cty/cty"
+	"strconv"
+	"strings"
+)
+
+// return an error associated with an attribute of the resource
+// terraform uses the attribute path to point to the attribute in the configuration when displaying the error
+// nested attributes are specified using the flatmap syntax, e.g. depends_on_control.0.id
+func attributeError(attribute string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var path cty.Path
+	for _, step := range strings.Split(attribute, ".") {
+		if index, parseErr := strconv.Atoi(step); parseErr == nil {
+			path = path.Index(cty.NumberIntVal(int64(index)))
+		} else {
+			path = path.GetAttr(step)
+		}
+	}
+	return path.NewError(err)
+}
+
+// if the API rejected the input because it failed validation, associate the error with the given attribute
+// all other errors are returned unchanged
+func apiValidationError(attribute string, err error) error {
+	if err != nil && apiClient.FailedValidationError(err) {
+		return attributeError(attribute, err)
+	}
+	return err
+}
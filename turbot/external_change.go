@@ -0,0 +1,80 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// how changes made outside of Terraform (e.g. renaming a folder in the Turbot console) are handled when the resource is read
+const (
+	// store the changed values, so the next apply reverts the change (the default)
+	externalChangeRevert = "revert"
+	// keep the last applied values in the state, so the change does not cause a diff
+	externalChangeIgnore = "ignore"
+	// fail the refresh, reporting the changed attributes
+	externalChangeFail = "fail"
+)
+
+func validateExternalChangeMode(d *schema.ResourceDiff) error {
+	switch mode := d.Get("on_external_change").(string); mode {
+	case "", externalChangeRevert, externalChangeIgnore, externalChangeFail:
+		return nil
+	default:
+		return attributeError("on_external_change", fmt.Errorf("invalid value '%s' - must be one of '%s', '%s' or '%s'", mode, externalChangeRevert, externalChangeIgnore, externalChangeFail))
+	}
+}
+
+// set the attribute values read from Turbot, handling any which have changed since they were applied according to on_external_change
+func setExternallyChangedAttributes(d *schema.ResourceData, values map[string]interface{}, meta interface{}) error {
+	// NOTE: when importing there is no state, so the mode is empty and all values are set
+	mode := d.Get("on_external_change").(string)
+	var changed []string
+	for attribute, value := range values {
+		if mode == "" || mode == externalChangeRevert || !externalValueChanged(d.Get(attribute), value) {
+			d.Set(attribute, value)
+			continue
+		}
+		changed = append(changed, attribute)
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	sort.Strings(changed)
+	if mode == externalChangeFail {
+		return fmt.Errorf("%s of %s changed outside of Terraform. Update the configuration to match, or set on_external_change = \"%s\" to revert the change", strings.Join(changed, ", "), d.Id(), externalChangeRevert)
+	}
+	meta.(*apiClient.Client).Logf(helpers.LogResources, "[WARN] ignoring changes made outside of Terraform to %s of %s", strings.Join(changed, ", "), d.Id())
+	return nil
+}
+
+// compare a value in the state with the value read from Turbot, ignoring formatting differences
+func externalValueChanged(stateValue, value interface{}) bool {
+	return !reflect.DeepEqual(normalizeExternalValue(stateValue), normalizeExternalValue(value))
+}
+
+func normalizeExternalValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		// json (e.g. resource data) and markdown (e.g. folder description) may be formatted differently
+		return helpers.NormalizeWhitespace(helpers.FormatJson(v))
+	case map[string]string:
+		// copy so nil and empty maps compare as equal
+		result := map[string]string{}
+		for key, element := range v {
+			result[key] = element
+		}
+		return result
+	case map[string]interface{}:
+		result := map[string]string{}
+		for key, element := range v {
+			result[key] = helpers.InterfaceToString(element)
+		}
+		return result
+	}
+	return value
+}
@@ -0,0 +1,285 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/Masterminds/semver"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// the peer dependency version range required by a mod in the configuration
+type modDependencyConstraint struct {
+	// the mod requiring the dependency, e.g. @turbot/aws-ec2@5.1.0
+	requiredBy string
+	constraint string
+}
+
+// modDependencyTracker collects the versions and peer dependencies of all turbot_mod resources planned by the
+// provider, so the constraints of different mods on a shared dependency can be evaluated together
+// NOTE: the provider process only lives for a single terraform operation, so this covers a single plan
+type modDependencyTracker struct {
+	lock sync.Mutex
+	// map of mod name ("@<org>/<mod>") to the version being installed
+	versions map[string]string
+	// map of dependency mod name to the constraints placed on it by each mod
+	constraints map[string]map[string]modDependencyConstraint
+}
+
+var plannedModDependencies = &modDependencyTracker{
+	versions:    map[string]string{},
+	constraints: map[string]map[string]modDependencyConstraint{},
+}
+
+// check the peer dependencies of the mod version against all other mods in the configuration, and against the
+// versions available in the registry. Return an error naming the conflicting mods if the constraints cannot be satisfied
+func checkModDependencyConflicts(org, modName, version string, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	dependencies, err := client.GetModVersionDependencies(org, modName, version)
+	if err != nil {
+		return err
+	}
+	name := buildModName(org, modName)
+	conflicts := plannedModDependencies.add(name, version, dependencies)
+	if len(conflicts) > 0 {
+		return fmt.Errorf("mod dependency conflict: %s", strings.Join(conflicts, "; "))
+	}
+
+	// now check there is a version of each dependency in the registry which satisfies all mods requiring it
+	for dependency := range dependencies {
+		constraints := plannedModDependencies.getConstraints(dependency)
+		if len(constraints) < 2 {
+			continue
+		}
+		if err := checkDependencyResolvable(dependency, constraints, client); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// register the mod version and its dependencies, returning a description of any conflicts with the versions of
+// other mods in the configuration
+func (t *modDependencyTracker) add(name, version string, dependencies map[string]string) []string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	requiredBy := fmt.Sprintf("%s@%s", name, version)
+	t.versions[name] = version
+	// remove any constraints previously registered by this mod (for a different version)
+	for _, constraints := range t.constraints {
+		delete(constraints, name)
+	}
+
+	var conflicts []string
+	for dependency, constraint := range dependencies {
+		if t.constraints[dependency] == nil {
+			t.constraints[dependency] = map[string]modDependencyConstraint{}
+		}
+		t.constraints[dependency][name] = modDependencyConstraint{requiredBy: requiredBy, constraint: constraint}
+
+		// if the dependency is also installed by this configuration, check the version satisfies the constraint
+		// (the version may be a pre-release, installed from the beta channel)
+		if dependencyVersion, ok := t.versions[dependency]; ok && !versionSatisfies(dependencyVersion, constraint, modChannelBeta) {
+			conflicts = append(conflicts, fmt.Sprintf("%s requires %s %s, but the configuration installs %s@%s", requiredBy, dependency, constraint, dependency, dependencyVersion))
+		}
+	}
+
+	// check this mod version satisfies the constraints of all mods which depend on it
+	for dependent, c := range t.constraints[name] {
+		if dependent != name && !versionSatisfies(version, c.constraint, modChannelBeta) {
+			conflicts = append(conflicts, fmt.Sprintf("%s requires %s %s, but the configuration installs %s", c.requiredBy, name, c.constraint, requiredBy))
+		}
+	}
+	sort.Strings(conflicts)
+	return conflicts
+}
+
+func (t *modDependencyTracker) getConstraints(dependency string) []modDependencyConstraint {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var constraints []modDependencyConstraint
+	for _, c := range t.constraints[dependency] {
+		constraints = append(constraints, c)
+	}
+	sort.Slice(constraints, func(i, j int) bool { return constraints[i].requiredBy < constraints[j].requiredBy })
+	return constraints
+}
+
+// check the registry contains a version of the dependency which satisfies all constraints
+func checkDependencyResolvable(dependency string, constraints []modDependencyConstraint, client *apiClient.Client) error {
+	org, modName := parseModName(dependency)
+	modVersions, err := client.GetModVersions(org, modName)
+	if err != nil {
+		return err
+	}
+	for _, modVersion := range modVersions {
+		status := strings.ToLower(modVersion.Status)
+		if status != "available" && status != "recommended" {
+			continue
+		}
+		satisfied := true
+		for _, c := range constraints {
+			if !versionSatisfies(modVersion.Version, c.constraint, modChannelStable) {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied {
+			return nil
+		}
+	}
+
+	var requirements []string
+	for _, c := range constraints {
+		requirements = append(requirements, fmt.Sprintf("%s requires %s", c.requiredBy, c.constraint))
+	}
+	return fmt.Errorf("mod dependency conflict: no version of %s satisfies all mods which depend on it (%s)", dependency, strings.Join(requirements, ", "))
+}
+
+// return whether the version satisfies the constraint in the channel - an invalid version or constraint is treated
+// as satisfied, as the Turbot API will report the error when the mod is installed
+func versionSatisfies(version, constraint, channel string) bool {
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return true
+	}
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return true
+	}
+	return modVersionMatches(c, v, channel)
+}
+
+// semver constraints only match a pre-release version if the constraint has a pre-release, so (for example) ^5.0.0
+// never matches 5.1.0-beta.1. in the beta channel a pre-release also matches if its release version does
+func modVersionMatches(c *semver.Constraints, v *semver.Version, channel string) bool {
+	if c.Check(v) {
+		return true
+	}
+	if channel != modChannelBeta || v.Prerelease() == "" {
+		return false
+	}
+	release, err := v.SetPrerelease("")
+	if err != nil {
+		return false
+	}
+	return c.Check(&release)
+}
+
+// return the installed mods which have a peer dependency on the named mod, e.g. "@turbot/aws-s3@5.0.2 (requires >=5.0.0)"
+// the dependencies of all installed mods are read together, rather than with a query per mod
+func getInstalledModDependents(name string, client *apiClient.Client) ([]string, error) {
+	mods, err := client.ReadModList()
+	if err != nil {
+		return nil, err
+	}
+	var others []apiClient.Mod
+	for _, mod := range mods {
+		// a mod which is still installing has no version
+		if buildModName(mod.Org, mod.Mod) == name || mod.Version == "" {
+			continue
+		}
+		others = append(others, mod)
+	}
+	dependencies, err := client.GetModListDependencies(others)
+	if err != nil {
+		return nil, err
+	}
+	return findModDependents(name, others, dependencies), nil
+}
+
+// return the mods whose dependencies include the named mod, sorted - dependencies[i] are the dependencies of mods[i]
+func findModDependents(name string, mods []apiClient.Mod, dependencies []map[string]string) []string {
+	var dependents []string
+	for i, mod := range mods {
+		if constraint, ok := dependencies[i][name]; ok {
+			dependents = append(dependents, fmt.Sprintf("%s@%s (requires %s)", buildModName(mod.Org, mod.Mod), mod.Version, constraint))
+		}
+	}
+	sort.Strings(dependents)
+	return dependents
+}
+
+func buildModName(org, mod string) string {
+	return fmt.Sprintf("@%s/%s", org, mod)
+}
+
+// mod name will be of form "@<org>/<mod>"
+func parseModName(name string) (org, mod string) {
+	segments := strings.SplitN(strings.TrimPrefix(name, "@"), "/", 2)
+	org = segments[0]
+	if len(segments) > 1 {
+		mod = segments[1]
+	}
+	return
+}
+
+// wait for the mod installed control of each peer dependency of the mod version to be 'ok'. only dependencies which
+// are installed when the mod is installed are waited for - a dependency installed by the same configuration must be
+// installed first, by referencing it or adding it to depends_on. installing the mod without a dependency fails with
+// an error naming it, rather than waiting for the timeout
+func waitForModDependencies(org, modName, version string, dependencies map[string]string, timeout time.Duration, client *apiClient.Client) error {
+	var names []string
+	for dependency := range dependencies {
+		names = append(names, dependency)
+	}
+	sort.Strings(names)
+
+	deadline := time.Now().Add(timeout)
+	for _, dependency := range names {
+		dependencyOrg, dependencyMod := parseModName(dependency)
+		aka := buildModAka(dependencyOrg, dependencyMod)
+		exists, err := client.ResourceExists(aka)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		client.Logf(helpers.LogWaiters, "[DEBUG] %s@%s requires %s, waiting for it to be installed", buildModName(org, modName), version, dependency)
+		c := controlReference{Type: modInstalledControlType, Resource: aka}
+		control, ok, err := waitForControlState(c, []string{"ok"}, time.Until(deadline), time.Second, 10*time.Second, client)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			if control == nil {
+				return fmt.Errorf("timed out after %s waiting for dependency %s of %s to be installed", timeout, dependency, buildModName(org, modName))
+			}
+			return fmt.Errorf("timed out after %s waiting for dependency %s of %s to be installed - its installed control is in state '%s': %s", timeout, dependency, buildModName(org, modName), control.State, control.Reason)
+		}
+	}
+	return nil
+}
+
+// read the peer dependencies of the installed version of the mod from the registry and store them
+func storeModDependencies(d *schema.ResourceData, mod *apiClient.Mod, client *apiClient.Client) error {
+	var dependencies map[string]string
+	// a mod which is still installing has no version
+	if mod.Version != "" {
+		var err error
+		if dependencies, err = client.GetModVersionDependencies(mod.Org, mod.Mod, mod.Version); err != nil {
+			return err
+		}
+	}
+	setModDependencies(d, dependencies)
+	return nil
+}
+
+// store the peer dependencies, sorted by name
+func setModDependencies(d *schema.ResourceData, dependencies map[string]string) {
+	var dependencyList []map[string]interface{}
+	for name, version := range dependencies {
+		dependencyList = append(dependencyList, map[string]interface{}{"mod": name, "version": version})
+	}
+	sort.Slice(dependencyList, func(i, j int) bool {
+		return dependencyList[i]["mod"].(string) < dependencyList[j]["mod"].(string)
+	})
+	d.Set("dependencies", dependencyList)
+}
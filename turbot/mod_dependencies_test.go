@@ -0,0 +1,57 @@
+package turbot
+
+import (
+	"github.com/Masterminds/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"testing"
+)
+
+func TestFindModDependents(t *testing.T) {
+	mods := []apiClient.Mod{
+		{Org: "turbot", Mod: "aws-s3", Version: "5.0.2"},
+		{Org: "turbot", Mod: "aws-iam", Version: "5.0.1"},
+		{Org: "turbot", Mod: "aws-ec2", Version: "5.3.0"},
+	}
+	dependencies := []map[string]string{
+		{"@turbot/aws": ">=5.0.0", "@turbot/turbot": ">=5.0.0"},
+		{"@turbot/turbot": ">=5.0.0"},
+		{"@turbot/aws": "^5.1.0"},
+	}
+
+	assert.Equal(t, []string{
+		"@turbot/aws-ec2@5.3.0 (requires ^5.1.0)",
+		"@turbot/aws-s3@5.0.2 (requires >=5.0.0)",
+	}, findModDependents("@turbot/aws", mods, dependencies))
+	assert.Len(t, findModDependents("@turbot/turbot", mods, dependencies), 2)
+	assert.Empty(t, findModDependents("@turbot/aws-s3", mods, dependencies))
+	// a mod with no peer dependencies
+	assert.Empty(t, findModDependents("@turbot/aws", mods[:1], []map[string]string{nil}))
+}
+
+func TestModVersionMatches(t *testing.T) {
+	type test struct {
+		name       string
+		constraint string
+		version    string
+		channel    string
+		expected   bool
+	}
+	tests := []test{
+		{"release in range", "^5.0.0", "5.1.0", modChannelStable, true},
+		{"release out of range", "^5.0.0", "6.0.0", modChannelStable, false},
+		{"release in range in beta channel", "^5.0.0", "5.1.0", modChannelBeta, true},
+		{"pre-release in stable channel", "^5.0.0", "5.1.0-beta.1", modChannelStable, false},
+		{"pre-release in beta channel", "^5.0.0", "5.1.0-beta.1", modChannelBeta, true},
+		{"pre-release out of range in beta channel", "^5.0.0", "6.0.0-beta.1", modChannelBeta, false},
+		{"pre-release of minimum version in beta channel", ">=5.1.0", "5.1.0-beta.1", modChannelBeta, true},
+		{"pre-release constraint", ">=5.1.0-beta.1", "5.1.0-beta.2", modChannelStable, true},
+	}
+	for _, test := range tests {
+		c, err := semver.NewConstraint(test.constraint)
+		assert.NoError(t, err, test.name)
+		v, err := semver.NewVersion(test.version)
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, modVersionMatches(c, v, test.channel), test.name)
+	}
+}
@@ -0,0 +1,113 @@
+package turbot
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/mitchellh/go-homedir"
+	"io/ioutil"
+)
+
+const (
+	outputFormatJson = "json"
+	outputFormatCsv  = "csv"
+)
+
+// add the arguments for writing the results of a plural data source to a local file, rather than storing them
+// in the state - for very large inventories this keeps the state small
+func withOutputFileSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["output_file"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	// json or csv
+	s["output_format"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  outputFormatJson,
+	}
+	// the sha256 checksum of the output file, so a change to the results can be detected without storing them
+	s["output_checksum"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	return s
+}
+
+// if output_file is set, write the results to it and store its checksum, returning true - the results should then
+// not be stored in the state. columns are the keys of each result, in the order of the csv columns
+func writeOutputFile(d *schema.ResourceData, results []map[string]interface{}, columns []string) (bool, error) {
+	outputFile := d.Get("output_file").(string)
+	if outputFile == "" {
+		d.Set("output_checksum", "")
+		return false, nil
+	}
+	var content []byte
+	var err error
+	switch format := d.Get("output_format").(string); format {
+	case outputFormatJson:
+		content, err = outputJson(results)
+	case outputFormatCsv:
+		content, err = outputCsv(results, columns)
+	default:
+		return false, attributeError("output_format", fmt.Errorf("invalid value '%s' - must be one of %s, %s", format, outputFormatJson, outputFormatCsv))
+	}
+	if err != nil {
+		return false, err
+	}
+
+	path, err := homedir.Expand(outputFile)
+	if err != nil {
+		return false, attributeError("output_file", err)
+	}
+	if err := ioutil.WriteFile(path, content, 0644); err != nil {
+		return false, attributeError("output_file", err)
+	}
+	checksum := sha256.Sum256(content)
+	d.Set("output_checksum", hex.EncodeToString(checksum[:]))
+	return true, nil
+}
+
+func outputJson(results []map[string]interface{}) ([]byte, error) {
+	// no results are written as an empty array rather than null
+	if results == nil {
+		results = []map[string]interface{}{}
+	}
+	return json.MarshalIndent(results, "", "  ")
+}
+
+// write a header row of the columns, then a row per result. list values are json encoded
+func outputCsv(results []map[string]interface{}, columns []string) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	if err := writer.Write(columns); err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			switch value := result[column].(type) {
+			case nil:
+			case string:
+				row[i] = value
+			case []string, []interface{}, map[string]interface{}:
+				encoded, err := json.Marshal(value)
+				if err != nil {
+					return nil, err
+				}
+				row[i] = string(encoded)
+			default:
+				row[i] = fmt.Sprintf("%v", value)
+			}
+		}
+		if err := writer.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return buffer.Bytes(), writer.Error()
+}
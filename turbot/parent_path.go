@@ -0,0 +1,179 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+	"regexp"
+	"strings"
+)
+
+// a parent may be given as a path of folder titles from the Turbot root, e.g. "turbot:/Prod/AWS"
+const parentPathPrefix = "turbot:/"
+
+// the Turbot root resource may be given as the parent of any resource using the shortcut "turbot", so a top level
+// resource does not need a data source to look up the root
+const (
+	rootParent    = "turbot"
+	turbotRootAka = "tmod:@turbot/turbot#/"
+)
+
+// replace the root shortcut with the aka of the Turbot root
+func resolveRootParent(parent string) string {
+	if parent == rootParent {
+		return turbotRootAka
+	}
+	return parent
+}
+
+var resourceIdRegex = regexp.MustCompile(`^[0-9]+$`)
+
+func isParentPath(parent string) bool {
+	return strings.HasPrefix(parent, parentPathPrefix)
+}
+
+// resolve a parent path expression to the id of the folder it refers to
+// each title in the path must match exactly one folder under the previous folder (or the Turbot root) - only the
+// children of the previous folder are read for each title
+func resolveParentPath(parentPath string, meta interface{}) (string, error) {
+	client := meta.(*apiClient.Client)
+	root, err := client.ReadResource(turbotRootAka, nil)
+	if err != nil {
+		return "", err
+	}
+	parentId := root.Turbot.Id
+	for _, title := range helpers.SplitTitlePath(strings.TrimPrefix(parentPath, parentPathPrefix)) {
+		folders, err := readChildrenByTitle(folderResourceType, parentId, title, client)
+		if err != nil {
+			return "", err
+		}
+		var matches []string
+		for _, folder := range folders {
+			matches = append(matches, folder.Turbot.Id)
+		}
+		switch len(matches) {
+		case 0:
+			return "", fmt.Errorf("failed to resolve parent %s: folder '%s' not found", parentPath, title)
+		case 1:
+			parentId = matches[0]
+		default:
+			return "", fmt.Errorf("failed to resolve parent %s: there are %d folders with title '%s' (ids: %s) - use the id of the parent instead", parentPath, len(matches), title, strings.Join(matches, ", "))
+		}
+	}
+	return parentId, nil
+}
+
+// if the parent is a path expression, resolve it to an id, otherwise return it unchanged (or the root aka for the
+// root shortcut)
+func resolveParent(parent string, meta interface{}) (string, error) {
+	if !isParentPath(parent) {
+		return resolveRootParent(parent), nil
+	}
+	return resolveParentPath(parent, meta)
+}
+
+// resolve a parent given as an id, an aka, a path or the root shortcut to the id of the parent
+func resolveParentId(parent string, meta interface{}) (string, error) {
+	parent, err := resolveParent(parent, meta)
+	if err != nil || resourceIdRegex.MatchString(parent) {
+		return parent, err
+	}
+	resource, err := meta.(*apiClient.Client).ReadResource(parent, nil)
+	if err != nil {
+		return "", err
+	}
+	return resource.Turbot.Id, nil
+}
+
+// the parent of a resource - if the parent is not set, the provider default_parent is used
+func parentOrDefault(parent string, meta interface{}) string {
+	if parent == "" {
+		parent = meta.(*apiClient.Client).DefaultParent
+	}
+	return resolveRootParent(parent)
+}
+
+// set the parent to the provider default_parent if it is not set, and replace the root shortcut with the root aka,
+// so the parent is included in the create or update mutation in a form Turbot accepts
+func setParent(d *schema.ResourceData, meta interface{}) error {
+	if !setParentOrDefault(d, meta.(*apiClient.Client).DefaultParent) {
+		return attributeError("parent", fmt.Errorf("parent must be set, either on the resource or using the provider default_parent"))
+	}
+	return nil
+}
+
+// set the parent to defaultParent if it is not set, replacing the root shortcut - returns false if there is no parent
+// the parent is computed, so when planning a parent missing from the config cannot be told apart from one which is
+// not known yet - it is only reported when the resource is created
+func setParentOrDefault(d *schema.ResourceData, defaultParent string) bool {
+	parent := d.Get("parent").(string)
+	if parent == "" {
+		parent = defaultParent
+	}
+	d.Set("parent", resolveRootParent(parent))
+	return parent != ""
+}
+
+// set the parent of a resource which has no default parent, e.g. a profile, whose parent is its directory
+func setRequiredParent(d *schema.ResourceData) error {
+	if !setParentOrDefault(d, "") {
+		return attributeError("parent", fmt.Errorf("parent is required"))
+	}
+	return nil
+}
+
+// a resource with no default parent (e.g. a profile, whose parent is its directory) must have a parent. the parent is
+// computed, so a parent omitted from the config is planned as unknown and is reported by setRequiredParent when the
+// resource is created - a parent which is known to be empty is reported here, at plan time
+func validateRequiredParent(d *schema.ResourceDiff, meta interface{}) error {
+	if d.NewValueKnown("parent") && d.Get("parent").(string) == "" {
+		return attributeError("parent", fmt.Errorf("parent is required"))
+	}
+	return nil
+}
+
+// a resource must have either a parent or a provider default_parent
+// (if the parent is not known yet it is an interpolation, so is set)
+func validateParent(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("parent") {
+		return nil
+	}
+	parent := parentOrDefault(d.Get("parent").(string), meta)
+	if parent == "" {
+		return attributeError("parent", fmt.Errorf("parent must be set, either on the resource or using the provider default_parent"))
+	}
+	// catch a mistyped root shortcut at plan time, rather than when Turbot fails to find the parent
+	if strings.EqualFold(parent, rootParent) {
+		return attributeError("parent", fmt.Errorf("invalid parent '%s' - use '%s' for the Turbot root", parent, rootParent))
+	}
+	return nil
+}
+
+// the state holds the id of the parent, while the config may give the parent as an aka, a path or the root shortcut.
+// resolve the configured parent and compare the ids, so a change of parent is only planned if the parent is a
+// different resource. the parent is not updated if it is removed from the config - the resource stays under the
+// parent it was created in, which is either the previously configured parent or the provider default_parent at the
+// time of creation
+func parentCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("parent") || !d.NewValueKnown("parent") {
+		return nil
+	}
+	old, new := d.GetChange("parent")
+	if new.(string) == "" {
+		return d.Clear("parent")
+	}
+	parentId, err := resolveParentId(new.(string), meta)
+	if err != nil {
+		// the parent may be created in this apply - plan the change and let the update resolve it
+		if apiClient.NotFoundError(err) {
+			meta.(*apiClient.Client).Logf(helpers.LogResources, "[WARN] %s, planning a change of parent", err.Error())
+			return nil
+		}
+		return attributeError("parent", err)
+	}
+	if parentId == old.(string) {
+		return d.Clear("parent")
+	}
+	return nil
+}
@@ -5,11 +5,15 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
 	"log"
+	"os"
+	"strings"
+	"time"
 )
 
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"access_key": {
 				Type:     schema.TypeString,
@@ -23,6 +27,18 @@ func Provider() terraform.ResourceProvider {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			// pin the client to an api version (e.g. "v5"), so an upgrade of the workspace does not change the behaviour of queries
+			"api_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TURBOT_API_VERSION", ""),
+			},
+			// the path of the graphql endpoint, replacing the path of the workspace url
+			"endpoint_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TURBOT_ENDPOINT_PATH", ""),
+			},
 			"profile": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -31,54 +47,253 @@ func Provider() terraform.ResourceProvider {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			// a command which prints credentials as JSON, used for any credentials not set by the other sources
+			"credential_process": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"registry_access_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"registry_secret_key": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			// retry policy for throttled and transient API failures
+			"max_retries": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  5,
+			},
+			// minimum and maximum wait between retries, in seconds
+			"retry_wait_min": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1,
+			},
+			"retry_wait_max": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  60,
+			},
+			// client-side limit on the rate of API requests, shared by all parallel operations - 0 means no limit
+			"max_requests_per_second": {
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TURBOT_MAX_REQUESTS_PER_SECOND", 0.0),
+			},
+			// block all create, update and delete operations, e.g. for drift detection runs
+			"read_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TURBOT_READ_ONLY", false),
+			},
+			// a reference to the change being applied (e.g. a ticket id or CI pipeline url), sent with every API request
+			"change_reference": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TURBOT_CHANGE_REFERENCE", ""),
+			},
+			// an id sent with every API request, so the requests of a run can be grouped - if not set, a random id is used
+			"correlation_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TURBOT_CORRELATION_ID", ""),
+			},
+			// headers added to every API request, e.g. for a proxy which requires them
+			"extra_headers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// log the query, variables and response of every API request - sensitive values are redacted
+			"graphql_logging": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TURBOT_GRAPHQL_LOGGING", false),
+			},
+			// the minimum level (TRACE, DEBUG, INFO, WARN or ERROR) of the messages logged by each subsystem, so the
+			// logging of one subsystem can be reduced while debugging another - these cannot log more than TF_LOG allows
+			"transport_log_level": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TURBOT_TRANSPORT_LOG_LEVEL", ""),
+			},
+			"resource_log_level": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TURBOT_RESOURCE_LOG_LEVEL", ""),
+			},
+			"waiter_log_level": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TURBOT_WAITER_LOG_LEVEL", ""),
+			},
+			// the parent of resources which do not set a parent
+			"default_parent": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TURBOT_DEFAULT_PARENT", ""),
+			},
+			// tags applied to every resource managed by the provider - tags set on a resource take precedence
+			"default_tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
 			"turbot_policy_setting":          resourceTurbotPolicySetting(),
+			"turbot_policy_setting_fan_out":  resourceTurbotPolicySettingFanOut(),
 			"turbot_mod":                     resourceTurbotMod(),
 			"turbot_folder":                  resourceTurbotFolder(),
 			"turbot_resource":                resourceTurbotResource(),
 			"turbot_local_directory":         resourceTurbotLocalDirectory(),
 			"turbot_profile":                 resourceTurbotProfile(),
+			"turbot_group_profile":           resourceTurbotGroupProfile(),
 			"turbot_local_directory_user":    resourceTurbotLocalDirectoryUser(),
+			"turbot_local_directory_users":   resourceTurbotLocalDirectoryUsers(),
 			"turbot_google_directory":        resourceGoogleDirectory(),
+			"turbot_ldap_directory":          resourceTurbotLdapDirectory(),
 			"turbot_saml_directory":          resourceTurbotSamlDirectory(),
 			"turbot_shadow_resource":         resourceTurbotShadowResource(),
 			"turbot_smart_folder":            resourceTurbotSmartFolder(),
 			"turbot_smart_folder_attachment": resourceTurbotSmartFolderAttachemnt(),
+			"turbot_smart_folder_policy":     resourceTurbotSmartFolderPolicy(),
+			"turbot_policy_pack":             resourceTurbotPolicyPack(),
+			"turbot_policy_pack_attachment":  resourceTurbotPolicyPackAttachment(),
 			"turbot_grant":                   resourceTurbotGrant(),
 			"turbot_grant_activation":        resourceTurbotGrantActivation(),
+			"turbot_grant_request":           resourceTurbotGrantRequest(),
+			"turbot_resource_grants":         resourceTurbotResourceGrants(),
 			"turbot_turbot_directory":        resourceTurbotTurbotDirectory(),
 			"turbot_file":                    resourceTurbotFile(),
+			"turbot_mod_registry_credential": resourceTurbotModRegistryCredential(),
+			"turbot_watch":                   resourceTurbotWatch(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"turbot_policy_value": dataSourceTurbotPolicyValue(),
-			"turbot_resource":     dataSourceTurbotResource(),
-			"turbot_control":      dataSourceTurbotControl(),
+			"turbot_policy_value":             dataSourceTurbotPolicyValue(),
+			"turbot_resource":                 dataSourceTurbotResource(),
+			"turbot_control":                  dataSourceTurbotControl(),
+			"turbot_controls":                 dataSourceTurbotControls(),
+			"turbot_control_reasons":          dataSourceTurbotControlReasons(),
+			"turbot_control_wait":             dataSourceTurbotControlWait(),
+			"turbot_policy_types_diff":        dataSourceTurbotPolicyTypesDiff(),
+			"turbot_policy_setting_conflicts": dataSourceTurbotPolicySettingConflicts(),
+			"turbot_policy_setting":           dataSourceTurbotPolicySetting(),
+			"turbot_effective_tags_diff":      dataSourceTurbotEffectiveTagsDiff(),
+			"turbot_mod_policy_defaults":      dataSourceTurbotModPolicyDefaults(),
+			"turbot_mod_version_latest":       dataSourceTurbotModVersionLatest(),
+			"turbot_aws_accounts":             dataSourceTurbotAwsAccounts(),
+			"turbot_directories":              dataSourceTurbotDirectories(),
+			"turbot_azure_subscriptions":      dataSourceTurbotAzureSubscriptions(),
+			"turbot_gcp_projects":             dataSourceTurbotGcpProjects(),
+			"turbot_notifications":            dataSourceTurbotNotifications(),
+			"turbot_resource_activity":        dataSourceTurbotResourceActivity(),
+			"turbot_resources":                dataSourceTurbotResources(),
+			"turbot_resource_akas":            dataSourceTurbotResourceAkas(),
+			"turbot_permission_types":         dataSourceTurbotPermissionTypes(),
 		},
-
-		ConfigureFunc: providerConfigure,
 	}
+	// the Terraform version is only set on the provider when it is configured
+	provider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		return providerConfigure(d, provider.TerraformVersion)
+	}
+	addStateUpgraders(provider.ResourcesMap)
+	addRetryLogging(provider.ResourcesMap, provider.DataSourcesMap)
+	return provider
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+func providerConfigure(d *schema.ResourceData, terraformVersion string) (interface{}, error) {
 	config := apiClient.ClientConfig{
 		Credentials: apiClient.ClientCredentials{
 			AccessKey: d.Get("access_key").(string),
 			SecretKey: d.Get("secret_key").(string),
 			Workspace: d.Get("workspace").(string),
 		},
-		Profile:         d.Get("profile").(string),
-		CredentialsPath: d.Get("credentials_file").(string),
+		Profile:           d.Get("profile").(string),
+		CredentialsPath:   d.Get("credentials_file").(string),
+		CredentialProcess: d.Get("credential_process").(string),
+		RegistryCredentials: apiClient.RegistryCredentials{
+			AccessKey: d.Get("registry_access_key").(string),
+			SecretKey: d.Get("registry_secret_key").(string),
+		},
+		ReadOnly:             d.Get("read_only").(bool),
+		DefaultTags:          d.Get("default_tags").(map[string]interface{}),
+		DefaultParent:        d.Get("default_parent").(string),
+		GraphqlLogging:       d.Get("graphql_logging").(bool),
+		ApiVersion:           d.Get("api_version").(string),
+		EndpointPath:         d.Get("endpoint_path").(string),
+		MaxRequestsPerSecond: d.Get("max_requests_per_second").(float64),
+		UserAgent:            userAgent(terraformVersion),
+		ExtraHeaders:         map[string]string{},
+		RetryPolicy: &apiClient.RetryPolicy{
+			MaxRetries:   d.Get("max_retries").(int),
+			RetryWaitMin: time.Duration(d.Get("retry_wait_min").(int)) * time.Second,
+			RetryWaitMax: time.Duration(d.Get("retry_wait_max").(int)) * time.Second,
+		},
+	}
+	// the change reference is sent as a header, so must be a single line
+	config.ChangeReference = strings.TrimSpace(d.Get("change_reference").(string))
+	if strings.ContainsAny(config.ChangeReference, "\r\n") {
+		return nil, fmt.Errorf("change_reference must not contain line breaks")
+	}
+	config.CorrelationId = strings.TrimSpace(d.Get("correlation_id").(string))
+	if strings.ContainsAny(config.CorrelationId, "\r\n") {
+		return nil, fmt.Errorf("correlation_id must not contain line breaks")
+	}
+	if config.CorrelationId == "" {
+		config.CorrelationId = apiClient.NewCorrelationId()
+	}
+	for name, value := range d.Get("extra_headers").(map[string]interface{}) {
+		config.ExtraHeaders[name] = value.(string)
+	}
+	if config.MaxRequestsPerSecond < 0 {
+		return nil, fmt.Errorf("max_requests_per_second must not be negative, got %g", config.MaxRequestsPerSecond)
+	}
+	if config.RetryPolicy.RetryWaitMin > config.RetryPolicy.RetryWaitMax {
+		return nil, fmt.Errorf("retry_wait_min (%d) must not be greater than retry_wait_max (%d)", d.Get("retry_wait_min").(int), d.Get("retry_wait_max").(int))
+	}
+	config.LogLevels = helpers.LogLevels{}
+	for subsystem, key := range map[string]string{
+		helpers.LogTransport: "transport_log_level",
+		helpers.LogResources: "resource_log_level",
+		helpers.LogWaiters:   "waiter_log_level",
+	} {
+		if err := config.LogLevels.Set(subsystem, d.Get(key).(string)); err != nil {
+			return nil, fmt.Errorf("%s: %s", key, err.Error())
+		}
 	}
 
 	client, err := apiClient.CreateClient(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create client: %s", err.Error())
 	}
-	log.Println("[INFO] Turbot API client initialized, now validating...", client)
+	log.Printf("[INFO] Turbot API client initialized, user agent: %s, correlation id: %s, now validating...", config.UserAgent, config.CorrelationId)
 	if err = client.Validate(); err != nil {
 		return nil, err
 	}
 	return client, nil
 }
+
+// the user agent of API requests, following the form used by other Terraform providers, e.g.
+// "Terraform/0.12.24 (+https://www.terraform.io) terraform-provider-turbot/1.7.0"
+// anything in TF_APPEND_USER_AGENT is appended, as it is for the requests Terraform makes itself
+func userAgent(terraformVersion string) string {
+	// Terraform 0.11 does not send its version to the provider
+	if terraformVersion == "" {
+		terraformVersion = "0.11+compatible"
+	}
+	userAgent := fmt.Sprintf("Terraform/%s (+https://www.terraform.io) terraform-provider-turbot/%s", terraformVersion, ProviderVersion)
+	if extra := strings.TrimSpace(os.Getenv("TF_APPEND_USER_AGENT")); extra != "" {
+		userAgent = fmt.Sprintf("%s %s", userAgent, extra)
+	}
+	return userAgent
+}
@@ -12,6 +12,20 @@ var testAccProvider *schema.Provider
 
 func init() {
 	testAccProvider = Provider().(*schema.Provider)
+	// tag every resource the acceptance tests create, so the sweepers can find resources left behind by failed runs
+	configure := testAccProvider.ConfigureFunc
+	testAccProvider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		meta, err := configure(d)
+		if err != nil {
+			return nil, err
+		}
+		client := meta.(*apiClient.Client)
+		if client.DefaultTags == nil {
+			client.DefaultTags = map[string]interface{}{}
+		}
+		client.DefaultTags[testAccSweepTag] = "true"
+		return meta, nil
+	}
 	testAccProviders = map[string]terraform.ResourceProvider{
 		"turbot": testAccProvider,
 	}
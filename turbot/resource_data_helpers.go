@@ -1,6 +1,7 @@
 package turbot
 
 import (
+	"fmt"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/iancoleman/strcase"
 	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
@@ -58,3 +59,43 @@ func storeAkas(aka, propertyName string, d *schema.ResourceData, meta interface{
 	d.Set(propertyName, akas)
 	return nil
 }
+
+// check there is no other resource of the given type with the same title under the parent
+// this prevents re-runs (e.g. after the state file has been lost) from creating duplicate resources
+func checkTitleUnique(resourceType, parentAka, title, id string, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	parent, err := client.ReadResource(parentAka, nil)
+	if err != nil {
+		// if the parent does not exist yet there can be no siblings
+		if apiClient.NotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	siblings, err := readChildrenByTitle(resourceType, parent.Turbot.Id, title, client)
+	if err != nil {
+		return err
+	}
+	for _, sibling := range siblings {
+		if sibling.Turbot.Id != id {
+			return fmt.Errorf("a resource with title '%s' already exists under parent %s (id: %s). To manage the existing resource, import it using 'terraform import <resource_address> %s', or set allow_duplicate_titles = true", title, parentAka, sibling.Turbot.Id, sibling.Turbot.Id)
+		}
+	}
+	return nil
+}
+
+// read the resources of the given type with the given title which are direct children of the parent - only the
+// children are listed, so a large subtree under the parent is not paged through
+func readChildrenByTitle(resourceType, parentId, title string, client *apiClient.Client) ([]apiClient.Resource, error) {
+	children, err := client.ReadResourceList(fmt.Sprintf("resourceType:%s parent:%s level:self", resourceType, parentId), nil)
+	if err != nil {
+		return nil, err
+	}
+	var matches []apiClient.Resource
+	for _, child := range children {
+		if child.Turbot.ParentId == parentId && child.Turbot.Title == title {
+			matches = append(matches, child)
+		}
+	}
+	return matches, nil
+}
@@ -2,6 +2,7 @@ package turbot
 
 import (
 	"fmt"
+	"github.com/hashicorp/terraform/helper/customdiff"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
 	"github.com/terraform-providers/terraform-provider-turbot/helpers"
@@ -20,21 +21,12 @@ func resourceTurbotFile() *schema.Resource {
 			State: resourceTurbotFileImport,
 		},
 		Schema: map[string]*schema.Schema{
-			// aka of the parent resource
+			// aka of the parent resource - if not set, the provider default_parent is used
 			"parent": {
 				Type:     schema.TypeString,
-				Required: true,
-				// when doing a diff, the state file will contain the id of the parent but the config contains the aka,
-				// so we need custom diff code
-				DiffSuppressFunc: suppressIfAkaMatches("parent_akas"),
-			},
-			// when doing a read, fetch the parent akas to use in suppressIfAkaMatches
-			"parent_akas": {
-				Type:     schema.TypeList,
+				Optional: true,
+				// the state holds the id of the parent, which parentCustomizeDiff compares to the configured parent
 				Computed: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
 			},
 			"title": {
 				Type:     schema.TypeString,
@@ -47,7 +39,7 @@ func resourceTurbotFile() *schema.Resource {
 			"content": {
 				Type:             schema.TypeString,
 				Optional:         true,
-				DiffSuppressFunc: suppressIfDataMatches,
+				DiffSuppressFunc: suppressIfFileContentMatches,
 			},
 			"tags": {
 				Type:     schema.TypeMap,
@@ -56,6 +48,11 @@ func resourceTurbotFile() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			// the tags applied in Turbot, including the provider default_tags
+			"tags_all": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
 			"akas": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -63,8 +60,73 @@ func resourceTurbotFile() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			// expose the previous versions of the file in 'versions', and allow them to be restored using revert_to_version
+			"keep_history": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// the id of the current version of the file
+			"version_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			// the most recent versions of the file, most recent first
+			"versions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"timestamp": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			// restore the content of a previous version - while this is set, the content attribute is ignored
+			"revert_to_version": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"turbot": turbotMetadataSchema(),
 		},
+		// the tags are planned first, so a change to the provider default_tags is seen as a change to the file
+		CustomizeDiff: customdiff.All(validateParent, parentCustomizeDiff, tagsCustomizeDiff, resourceTurbotFileCustomizeDiff, turbotMetadataCustomizeDiff),
+	}
+}
+
+// the number of versions listed in 'versions'
+const fileVersionsLimit = 10
+
+// the number of versions searched for the version to revert to
+const fileRevertVersionsLimit = 100
+
+func resourceTurbotFileCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if revertToVersion := d.Get("revert_to_version").(string); revertToVersion != "" {
+		if !d.Get("keep_history").(bool) {
+			return attributeError("revert_to_version", fmt.Errorf("keep_history must be true to revert to a previous version"))
+		}
+		if d.Id() == "" {
+			return attributeError("revert_to_version", fmt.Errorf("revert_to_version can only be set for an existing file"))
+		}
+	}
+	// any change to the file creates a new version
+	if d.Id() != "" {
+		for _, attribute := range []string{"parent", "title", "description", "content", "tags", "tags_all", "akas", "revert_to_version"} {
+			if d.HasChange(attribute) {
+				if err := d.SetNewComputed("version_id"); err != nil {
+					return err
+				}
+				return d.SetNewComputed("versions")
+			}
+		}
 	}
+	return nil
 }
 
 func resourceTurbotFileExists(d *schema.ResourceData, meta interface{}) (b bool, e error) {
@@ -75,6 +137,9 @@ func resourceTurbotFileExists(d *schema.ResourceData, meta interface{}) (b bool,
 
 func resourceTurbotFileCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
+	if err := setParent(d, meta); err != nil {
+		return err
+	}
 	title := d.Get("title")
 	description := d.Get("description")
 	var err error
@@ -85,23 +150,22 @@ func resourceTurbotFileCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 	// set type property
 	input["type"] = "tmod:@turbot/turbot#/resource/types/file"
+	input["tags"] = buildTagsInput(d, meta)
 
 	turbotMetadata, err := client.CreateResource(input)
 	if err != nil {
-		return err
+		return apiValidationError("content", err)
 	}
 
-	// set parent_akas property by loading resource and fetching the akas
-	if err := storeAkas(turbotMetadata.ParentId, "parent_akas", d, meta); err != nil {
-		return err
-	}
 	// assign the id
 	d.SetId(turbotMetadata.Id)
+	storeTurbotMetadata(d, *turbotMetadata)
 	// save the formatted data: this is to ensure the acceptance tests behave in a consistent way regardless of the ordering of the json data
 	d.Set("content", helpers.FormatJson(d.Get("content").(string)))
 	d.Set("title", title)
 	d.Set("description", description)
-	return nil
+	d.Set("version_id", turbotMetadata.VersionId)
+	return storeFileVersions(d, meta)
 }
 
 func resourceTurbotFileRead(d *schema.ResourceData, meta interface{}) error {
@@ -110,8 +174,9 @@ func resourceTurbotFileRead(d *schema.ResourceData, meta interface{}) error {
 	resource, err := client.ReadFullResource(id)
 	if err != nil {
 		if apiClient.NotFoundError(err) {
-			// resource was not found - clear id
+			// resource was not found - remove it from the state
 			d.SetId("")
+			return nil
 		}
 		return err
 	}
@@ -125,11 +190,6 @@ func resourceTurbotFileRead(d *schema.ResourceData, meta interface{}) error {
 
 	customMetadata := resource.Turbot.Custom
 
-	// set parent_akas property by loading resource and fetching the akas
-	if err := storeAkas(resource.Turbot.ParentId, "parent_akas", d, meta); err != nil {
-		return err
-	}
-
 	if v, ok := customMetadata["title"]; ok {
 		d.Set("title", v)
 	}
@@ -138,12 +198,21 @@ func resourceTurbotFileRead(d *schema.ResourceData, meta interface{}) error {
 	}
 	// assign results back into ResourceData
 	d.Set("parent", resource.Turbot.ParentId)
-	d.Set("content", content)
-	return nil
+	// keep the content in the state if it is unchanged, so content written as YAML is not replaced by its JSON equivalent
+	if !helpers.JsonOrYamlStringsAreEqual(d.Get("content").(string), content) {
+		d.Set("content", content)
+	}
+	storeTags(d, resource.Turbot.Tags, meta)
+	d.Set("version_id", resource.Turbot.VersionId)
+	storeTurbotMetadata(d, resource.Turbot)
+	return storeFileVersions(d, meta)
 }
 
 func resourceTurbotFileUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
+	if err := setParent(d, meta); err != nil {
+		return err
+	}
 	// build input map to pass to mutation
 	id := d.Id()
 
@@ -152,32 +221,48 @@ func resourceTurbotFileUpdate(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	input["data"], err = buildInputDataMap(d)
+	revertToVersion := d.Get("revert_to_version").(string)
+	if revertToVersion != "" && d.HasChange("revert_to_version") {
+		input["data"], err = buildRevertedDataMap(d, revertToVersion, meta)
+	} else {
+		input["data"], err = buildInputDataMap(d)
+	}
 	if err != nil {
 		return err
 	}
+	if d.HasChange("tags") || d.HasChange("tags_all") {
+		input["tags"] = buildTagsUpdateInput(d, meta)
+	} else {
+		delete(input, "tags")
+	}
 	input["id"] = id
 	turbotMetadata, err := client.UpdateResource(input)
 	if err != nil {
+		return apiValidationError("content", err)
+	}
+	if revertToVersion == "" {
+		// save the formatted data: this is to ensure the acceptance tests behave in a consistent way regardless of the ordering of the json data
+		d.Set("content", helpers.FormatJson(d.Get("content").(string)))
+	}
+	d.Set("version_id", turbotMetadata.VersionId)
+	if err := storeFileVersions(d, meta); err != nil {
 		return err
 	}
-	// save the formatted data: this is to ensure the acceptance tests behave in a consistent way regardless of the ordering of the json data
-	d.Set("content", helpers.FormatJson(d.Get("content").(string)))
 
 	metadataMap := turbotMetadata.Custom
 	if v, ok := metadataMap["description"]; ok {
 		d.Set("description", v)
 	}
 	d.Set("title", metadataMap["title"])
-	// set parent_akas property by loading resource and fetching the akas
-	return storeAkas(turbotMetadata.ParentId, "parent_akas", d, meta)
+	storeTurbotMetadata(d, *turbotMetadata)
+	return nil
 }
 
 func resourceTurbotFileDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
 	id := d.Id()
 	err := client.DeleteResource(id)
-	if err != nil {
+	if err != nil && !apiClient.NotFoundError(err) {
 		return err
 	}
 
@@ -201,8 +286,8 @@ func buildFileInput(d *schema.ResourceData, properties []interface{}) (map[strin
 	input = mapFromResourceData(d, properties)
 	// convert data from json string to map
 	contentString := d.Get("content").(string)
-	if input["data"], err = helpers.JsonStringToMap(contentString); err != nil {
-		return nil, fmt.Errorf("error build resource mutation input, failed to unmarshal content: \n%s\nerror: %s", contentString, err.Error())
+	if input["data"], err = helpers.JsonOrYamlStringToMap(contentString); err != nil {
+		return nil, attributeError("content", fmt.Errorf("error build resource mutation input, failed to unmarshal content: \n%s\nerror: %s", contentString, err.Error()))
 	}
 	input["metadata"] = buildInputMetadataMap(d)
 
@@ -232,11 +317,11 @@ func buildInputDataMap(d *schema.ResourceData) (map[string]interface{}, error) {
 	var err error
 	// fetch old(state-file) and new(config) content
 	if old, new := d.GetChange("content"); old != nil {
-		if oldContent, err = helpers.JsonStringToMap(old.(string)); err != nil {
-			return nil, fmt.Errorf("error build resource mutation input, failed to unmarshal content: \n%s\nerror: %s", old.(string), err.Error())
+		if oldContent, err = helpers.JsonOrYamlStringToMap(old.(string)); err != nil {
+			return nil, attributeError("content", fmt.Errorf("error build resource mutation input, failed to unmarshal content: \n%s\nerror: %s", old.(string), err.Error()))
 		}
-		if newContent, err = helpers.JsonStringToMap(new.(string)); err != nil {
-			return nil, fmt.Errorf("error build resource mutation input, failed to unmarshal content: \n%s\nerror: %s", new.(string), err.Error())
+		if newContent, err = helpers.JsonOrYamlStringToMap(new.(string)); err != nil {
+			return nil, attributeError("content", fmt.Errorf("error build resource mutation input, failed to unmarshal content: \n%s\nerror: %s", new.(string), err.Error()))
 		}
 		// extract keys from old content not in new
 		excludeContentProperties := helpers.GetOldMapProperties(oldContent, newContent)
@@ -249,3 +334,70 @@ func buildInputDataMap(d *schema.ResourceData) (map[string]interface{}, error) {
 	}
 	return newContent, nil
 }
+
+// build the data to restore a previous version of the file - any property which is not in the version must be
+// explicitly set to null in the mutation input
+func buildRevertedDataMap(d *schema.ResourceData, versionId string, meta interface{}) (map[string]interface{}, error) {
+	client := meta.(*apiClient.Client)
+	versions, err := client.ReadResourceVersions(d.Id(), fileRevertVersionsLimit)
+	if err != nil {
+		return nil, err
+	}
+	for _, version := range versions {
+		if version.Turbot.ResourceNewVersionId != versionId {
+			continue
+		}
+		data := map[string]interface{}{}
+		for key, value := range version.Resource.Data {
+			data[key] = value
+		}
+		// the state contains the current content of the file
+		old, _ := d.GetChange("content")
+		if currentContent, err := helpers.JsonOrYamlStringToMap(old.(string)); err == nil {
+			for _, key := range helpers.GetOldMapProperties(currentContent, data) {
+				data[key.(string)] = nil
+			}
+		}
+		content, err := helpers.MapToJsonString(version.Resource.Data)
+		if err != nil {
+			return nil, err
+		}
+		d.Set("content", content)
+		return data, nil
+	}
+	return nil, attributeError("revert_to_version", fmt.Errorf("version %s not found in the last %d versions of file %s", versionId, fileRevertVersionsLimit, d.Id()))
+}
+
+// if keep_history is set, store the most recent versions of the file
+func storeFileVersions(d *schema.ResourceData, meta interface{}) error {
+	if !d.Get("keep_history").(bool) {
+		d.Set("versions", nil)
+		return nil
+	}
+	client := meta.(*apiClient.Client)
+	versions, err := client.ReadResourceVersions(d.Id(), fileVersionsLimit)
+	if err != nil {
+		return err
+	}
+	var result []map[string]interface{}
+	for _, version := range versions {
+		result = append(result, map[string]interface{}{
+			"version_id": version.Turbot.ResourceNewVersionId,
+			"timestamp":  version.Turbot.CreateTimestamp,
+		})
+	}
+	d.Set("versions", result)
+	return nil
+}
+
+// content may be JSON or YAML, so compare the parsed content
+// while a previous version is restored, the content attribute is ignored
+func suppressIfFileContentMatches(k, old, new string, d *schema.ResourceData) bool {
+	if d.Get("revert_to_version").(string) != "" {
+		return true
+	}
+	if old == "" || new == "" {
+		return false
+	}
+	return helpers.JsonOrYamlStringsAreEqual(old, new)
+}
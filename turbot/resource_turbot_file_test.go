@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
 	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+	"regexp"
 	"testing"
 )
 
@@ -51,6 +52,69 @@ func TestAccFileResourcefile_Basic(t *testing.T) {
 	})
 }
 
+func TestAccFileResourcefile_Yaml(t *testing.T) {
+	resourceName := "turbot_file.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckFileResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFileResourceConfigfile(fileContentYaml),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFileResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "content", fileContentYaml),
+				),
+			},
+			// the equivalent JSON content does not cause a diff
+			{
+				Config:   testAccFileResourceConfigfile(fileContent),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccFileResourcefile_KeepHistory(t *testing.T) {
+	resourceName := "turbot_file.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckFileResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFileResourceConfigHistory(fileContent, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFileResourceExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "version_id"),
+				),
+			},
+			{
+				Config: testAccFileResourceConfigHistory(fileContentUpdated, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "content", helpers.FormatJson(fileContentUpdated)),
+					resource.TestCheckResourceAttrSet(resourceName, "version_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "versions.0.version_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFileResourcefile_RevertWithoutHistory(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckFileResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccFileResourceConfigRevertWithoutHistory(),
+				ExpectError: regexp.MustCompile("keep_history must be true"),
+			},
+		},
+	})
+}
+
 var fileContent = `{
  "foo": "provider_test",
  "bar": "test resource"
@@ -61,12 +125,39 @@ var fileContentUpdated = `{
  "bar": "test resource"
 }
 `
+var fileContentYaml = `foo: provider_test
+bar: test resource
+`
 var fileContentDeleteKey = `{
  "foo": "provider_test"
 }
 `
 
 // configs
+func testAccFileResourceConfigHistory(content, revertToVersion string) string {
+	return fmt.Sprintf(`
+resource "turbot_file" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	title  = "provider_file"
+	keep_history = true
+	revert_to_version = "%s"
+	content =  <<EOF
+%sEOF
+}
+`, revertToVersion, content)
+}
+
+func testAccFileResourceConfigRevertWithoutHistory() string {
+	return `
+resource "turbot_file" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	title  = "provider_file"
+	revert_to_version = "20200301120000000"
+	content = "{}"
+}
+`
+}
+
 func testAccFileResourceConfigfile(Content string) string {
 	config := fmt.Sprintf(`
 resource "turbot_file" "test" {
@@ -1,14 +1,20 @@
 package turbot
 
 import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/customdiff"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+	"strings"
 )
 
 // properties which must be passed to a create/update call
 var folderDataProperties = []interface{}{"title", "description"}
 var folderInputProperties = []interface{}{"parent", "tags"}
 
+const folderResourceType = "tmod:@turbot/turbot#/resource/types/folder"
+
 func resourceTurbotFolder() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceTurbotFolderCreate,
@@ -20,38 +26,98 @@ func resourceTurbotFolder() *schema.Resource {
 			State: resourceTurbotFolderImport,
 		},
 		Schema: map[string]*schema.Schema{
-			// aka of the parent resource
+			// aka of the parent resource - if not set, the provider default_parent is used
 			"parent": {
 				Type:     schema.TypeString,
-				Required: true,
-				// when doing a diff, the state file will contain the id of the parent but the config contains the aka,
-				// so we need custom diff code
-				DiffSuppressFunc: suppressIfAkaMatches("parent_akas"),
-			},
-			// when doing a read, fetch the parent akas to use in suppressIfAkaMatches
-			"parent_akas": {
-				Type:     schema.TypeList,
+				Optional: true,
+				// the state holds the id of the parent, which parentCustomizeDiff compares to the configured parent
 				Computed: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
 			},
 			"title": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			// description may be markdown - ignore whitespace-only differences
 			"description": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressIfWhitespaceMatches,
 			},
 			"tags": {
 				Type:     schema.TypeMap,
 				Optional: true,
 			},
+			// the tags applied in Turbot, including the provider default_tags
+			"tags_all": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+			// disable the check for an existing folder with the same title under the parent
+			"allow_duplicate_titles": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// how changes made outside of Terraform are handled: "revert", "ignore" or "fail"
+			"on_external_change": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  externalChangeRevert,
+			},
+			// reading the children lists every child of the folder on each refresh, so is only done if requested
+			"include_children": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// if set, only children of these resource types are included in 'children'
+			"children_resource_types": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// map of the title of each child resource to its id, including children created outside of Terraform,
+			// e.g. by discovery - only set if include_children is true
+			"children": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"turbot": turbotMetadataSchema(),
 		},
+		CustomizeDiff: customdiff.All(validateParent, parentCustomizeDiff, resourceTurbotFolderCustomizeDiff, tagsCustomizeDiff, turbotMetadataCustomizeDiff),
 	}
 }
 
+func resourceTurbotFolderCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if err := validateExternalChangeMode(d); err != nil {
+		return err
+	}
+	if d.Id() != "" && (d.HasChange("include_children") || d.HasChange("children_resource_types")) {
+		if err := d.SetNewComputed("children"); err != nil {
+			return err
+		}
+	}
+	// validate the description against the folder schema, so descriptions which are too long are reported at plan time
+	if d.NewValueKnown("description") && (d.Id() == "" || d.HasChange("description")) {
+		if description, ok := d.GetOk("description"); ok {
+			if err := validateFolderDescription(description.(string), meta); err != nil {
+				return attributeError("description", err)
+			}
+		}
+	}
+	// check there is no existing folder with the same title under the parent
+	if !d.Get("allow_duplicate_titles").(bool) && d.NewValueKnown("parent") && d.NewValueKnown("title") &&
+		(d.Id() == "" || d.HasChange("parent") || d.HasChange("title")) {
+		return attributeError("title", checkTitleUnique(folderResourceType, parentOrDefault(d.Get("parent").(string), meta), d.Get("title").(string), d.Id(), meta))
+	}
+	return nil
+}
+
 func resourceTurbotFolderExists(d *schema.ResourceData, meta interface{}) (b bool, e error) {
 	client := meta.(*apiClient.Client)
 	id := d.Id()
@@ -60,36 +126,49 @@ func resourceTurbotFolderExists(d *schema.ResourceData, meta interface{}) (b boo
 
 func resourceTurbotFolderCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
+	if err := setParent(d, meta); err != nil {
+		return err
+	}
 
 	// build mutation input
 	input := mapFromResourceData(d, folderInputProperties)
 	input["data"] = mapFromResourceData(d, folderDataProperties)
+	input["tags"] = buildTagsInput(d, meta)
 
 	folder, err := client.CreateFolder(input)
 	if err != nil {
 		return err
 	}
 
-	// set parent_akas property by loading resource and fetching the akas
-	if err := storeAkas(folder.Turbot.ParentId, "parent_akas", d, meta); err != nil {
-		return err
-	}
-
 	// assign the id
 	d.SetId(folder.Turbot.Id)
+	storeTurbotMetadata(d, folder.Turbot)
 	// set FolderProperties the way we get in Read query
 	d.Set("parent", folder.Parent)
 	d.Set("title", folder.Title)
 	d.Set("description", folder.Description)
-	return nil
+	return storeFolderChildren(d, client)
 }
 
 func resourceTurbotFolderUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
+	if err := setParent(d, meta); err != nil {
+		return err
+	}
 
 	// build mutation payload
 	input := mapFromResourceData(d, folderInputProperties)
-	input["data"] = mapFromResourceData(d, folderDataProperties)
+	data := mapFromResourceData(d, folderDataProperties)
+	// a description which has been removed must be explicitly cleared
+	if _, ok := data["description"]; !ok && d.HasChange("description") {
+		data["description"] = nil
+	}
+	input["data"] = data
+	if d.HasChange("tags") || d.HasChange("tags_all") {
+		input["tags"] = buildTagsUpdateInput(d, meta)
+	} else {
+		delete(input, "tags")
+	}
 	input["id"] = d.Id()
 
 	folder, err := client.UpdateFolder(input)
@@ -100,8 +179,11 @@ func resourceTurbotFolderUpdate(d *schema.ResourceData, meta interface{}) error
 	d.Set("parent", folder.Parent)
 	d.Set("title", folder.Title)
 	d.Set("description", folder.Description)
-	// set parent_akas property by loading resource and fetching the akas
-	return storeAkas(folder.Turbot.ParentId, "parent_akas", d, meta)
+	if err := storeFolderChildren(d, client); err != nil {
+		return err
+	}
+	storeTurbotMetadata(d, folder.Turbot)
+	return nil
 }
 
 func resourceTurbotFolderRead(d *schema.ResourceData, meta interface{}) error {
@@ -111,26 +193,36 @@ func resourceTurbotFolderRead(d *schema.ResourceData, meta interface{}) error {
 	folder, err := client.ReadFolder(id)
 	if err != nil {
 		if apiClient.NotFoundError(err) {
-			// folder was not found - clear id
+			// folder was not found - remove it from the state
 			d.SetId("")
+			return nil
 		}
 		return err
 	}
 
 	// assign results back into ResourceData
 	d.Set("parent", folder.Parent)
-	d.Set("title", folder.Title)
-	d.Set("description", folder.Description)
-	d.Set("tags", folder.Turbot.Tags)
-	// set parent_akas property by loading resource and fetching the akas
-	return storeAkas(folder.Turbot.ParentId, "parent_akas", d, meta)
+	err = setExternallyChangedAttributes(d, map[string]interface{}{
+		"title":       folder.Title,
+		"description": folder.Description,
+		"tags":        resourceTags(d, folder.Turbot.Tags, meta),
+		"tags_all":    folder.Turbot.Tags,
+	}, meta)
+	if err != nil {
+		return err
+	}
+	if err := storeFolderChildren(d, client); err != nil {
+		return err
+	}
+	storeTurbotMetadata(d, folder.Turbot)
+	return nil
 }
 
 func resourceTurbotFolderDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
 	id := d.Id()
 	err := client.DeleteResource(id)
-	if err != nil {
+	if err != nil && !apiClient.NotFoundError(err) {
 		return err
 	}
 
@@ -146,3 +238,86 @@ func resourceTurbotFolderImport(d *schema.ResourceData, meta interface{}) ([]*sc
 	}
 	return []*schema.ResourceData{d}, nil
 }
+
+// if include_children is set, store the map of child title -> id, for the children of the types in
+// children_resource_types (or all children)
+// if more than one child has the same title, the child with the lowest id is used
+func storeFolderChildren(d *schema.ResourceData, client *apiClient.Client) error {
+	if !d.Get("include_children").(bool) {
+		return d.Set("children", nil)
+	}
+	filter := fmt.Sprintf("resourceId:%s level:child", d.Id())
+	if resourceTypes := d.Get("children_resource_types").([]interface{}); len(resourceTypes) > 0 {
+		var types []string
+		for _, resourceType := range resourceTypes {
+			types = append(types, resourceType.(string))
+		}
+		filter = fmt.Sprintf("%s resourceType:%s", filter, strings.Join(types, ","))
+	}
+	resources, err := client.ReadResourceList(filter, nil)
+	if err != nil {
+		return err
+	}
+	children := map[string]interface{}{}
+	for _, resource := range resources {
+		title, id := resource.Turbot.Title, resource.Turbot.Id
+		if existing, ok := children[title]; ok {
+			client.Logf(helpers.LogResources, "[WARN] folder %s has more than one child titled '%s': %s and %s", d.Id(), title, existing, id)
+			if turbotIdLess(existing.(string), id) {
+				continue
+			}
+		}
+		children[title] = id
+	}
+	return d.Set("children", children)
+}
+
+// turbot ids are numeric strings, so a shorter id is lower
+func turbotIdLess(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+func validateFolderDescription(description string, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	resourceSchema, err := client.ReadResourceTypeSchema(folderResourceType)
+	if err != nil {
+		client.Logf(helpers.LogResources, "[WARN] failed to read folder schema, skipping description validation: %s", err.Error())
+		return nil
+	}
+	createSchema, _ := resourceSchema.Resource.CreateSchema.(map[string]interface{})
+	descriptionSchema, ok := getSchemaProperty(createSchema, "description")
+	if !ok {
+		return nil
+	}
+	if validationErrors := helpers.ValidateJsonSchemaProperty("description", helpers.NormalizeWhitespace(description), descriptionSchema); len(validationErrors) > 0 {
+		return fmt.Errorf("%s", strings.Join(validationErrors, "\n"))
+	}
+	return nil
+}
+
+// find the schema for a property, looking in the top level properties and any allOf sub-schemas
+func getSchemaProperty(resourceSchema map[string]interface{}, name string) (map[string]interface{}, bool) {
+	if properties, ok := resourceSchema["properties"].(map[string]interface{}); ok {
+		if propertySchema, ok := properties[name].(map[string]interface{}); ok {
+			return propertySchema, true
+		}
+	}
+	if allOf, ok := resourceSchema["allOf"].([]interface{}); ok {
+		for _, s := range allOf {
+			if subSchema, ok := s.(map[string]interface{}); ok {
+				if propertySchema, ok := getSchemaProperty(subSchema, name); ok {
+					return propertySchema, true
+				}
+			}
+		}
+	}
+	return nil, false
+}
+
+// compare the old and new values after normalizing whitespace, so markdown values round trip without diffs
+func suppressIfWhitespaceMatches(k, old, new string, d *schema.ResourceData) bool {
+	return helpers.NormalizeWhitespace(old) == helpers.NormalizeWhitespace(new)
+}
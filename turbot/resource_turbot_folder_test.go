@@ -5,6 +5,8 @@ import (
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -24,6 +26,12 @@ func TestAccFolder_Basic(t *testing.T) {
 						"turbot_folder.test", "title", "provider_test"),
 					resource.TestCheckResourceAttr(
 						"turbot_folder.test", "description", "test folder"),
+					resource.TestCheckResourceAttrPair(
+						"turbot_folder.test", "turbot.id", "turbot_folder.test", "id"),
+					resource.TestCheckResourceAttrSet(
+						"turbot_folder.test", "turbot.path"),
+					resource.TestCheckResourceAttrSet(
+						"turbot_folder.test", "turbot.version_id"),
 				),
 			},
 			{
@@ -50,6 +58,18 @@ func TestAccFolder_Basic(t *testing.T) {
 						"turbot_folder.test", "tags.Environment", "foo"),
 				),
 			},
+			{
+				// removed tags and description must be deleted from the folder
+				Config: testAccFolderRemovedTagConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"turbot_folder.test", "tags.%", "1"),
+					resource.TestCheckResourceAttr(
+						"turbot_folder.test", "tags.Name", "Provider Test"),
+					resource.TestCheckResourceAttr(
+						"turbot_folder.test", "description", ""),
+				),
+			},
 		},
 	})
 }
@@ -109,7 +129,381 @@ func TestAccFolder_Dependencies(t *testing.T) {
 	})
 }
 
+func TestAccFolder_MarkdownDescription(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckFolderDestroy,
+		Steps: []resource.TestStep{
+			{
+				// the heredoc adds a trailing newline and the config contains trailing whitespace - neither should cause a diff
+				Config: testAccFolderMarkdownDescriptionConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFolderExists("turbot_folder.test"),
+					resource.TestCheckResourceAttr(
+						"turbot_folder.test", "title", "provider_test"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFolder_DescriptionTooLong(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckFolderDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccFolderLongDescriptionConfig(),
+				ExpectError: regexp.MustCompile("description: length must be at most"),
+			},
+		},
+	})
+}
+
+func TestAccFolder_DuplicateTitle(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckFolderDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFolderConfig(),
+				Check:  testAccCheckFolderExists("turbot_folder.test"),
+			},
+			{
+				Config:      testAccFolderDuplicateTitleConfig(false),
+				ExpectError: regexp.MustCompile("a resource with title 'provider_test' already exists"),
+			},
+			{
+				Config: testAccFolderDuplicateTitleConfig(true),
+				Check:  testAccCheckFolderExists("turbot_folder.duplicate"),
+			},
+		},
+	})
+}
+
+func TestAccFolder_OnExternalChange(t *testing.T) {
+	var folderId string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckFolderDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFolderOnExternalChangeConfig("ignore"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFolderExists("turbot_folder.test"),
+					testAccGetResourceId("turbot_folder.test", &folderId),
+				),
+			},
+			{
+				// rename the folder outside of Terraform - the change is ignored so the plan is empty
+				PreConfig: func() { testAccRenameFolder(t, folderId, "provider_test_renamed") },
+				Config:    testAccFolderOnExternalChangeConfig("ignore"),
+				PlanOnly:  true,
+			},
+			{
+				Config:      testAccFolderOnExternalChangeConfig("fail"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("title of .* changed outside of Terraform"),
+			},
+			{
+				Config:      testAccFolderOnExternalChangeConfig("invalid"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("must be one of 'revert', 'ignore' or 'fail'"),
+			},
+		},
+	})
+}
+
+func TestAccFolder_DefaultTags(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckFolderDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFolderDefaultTagsConfig("foo"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFolderExists("turbot_folder.test"),
+					// default tags are applied, but only the configured tags are in 'tags'
+					// (tags_all includes the sweeper tag added by the test provider)
+					resource.TestCheckResourceAttr("turbot_folder.test", "tags.%", "1"),
+					resource.TestCheckResourceAttr("turbot_folder.test", "tags_all.%", "4"),
+					resource.TestCheckResourceAttr("turbot_folder.test", "tags_all.Owner", "provider_test"),
+					// the resource tag takes precedence over the default tag
+					resource.TestCheckResourceAttr("turbot_folder.test", "tags_all.Environment", "foo"),
+				),
+			},
+			{
+				// a change to the default tags updates the folder
+				Config: testAccFolderDefaultTagsConfig("bar"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("turbot_folder.test", "tags_all.%", "4"),
+					resource.TestCheckResourceAttr("turbot_folder.test", "tags_all.Team", "bar"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFolder_DefaultParent(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckFolderDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFolderDefaultParentConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFolderExists("turbot_folder.parent"),
+					testAccCheckFolderExists("turbot_folder.test"),
+					// the folder without a parent is created in the default parent
+					resource.TestCheckResourceAttrPair("turbot_folder.test", "parent", "data.turbot_resource_akas.root", "id"),
+					// a parent set on the resource overrides the default parent
+					resource.TestCheckResourceAttrPair("turbot_folder.child", "parent", "turbot_folder.parent", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFolder_RootParent(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckFolderDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFolderRootParentConfig("turbot"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFolderExists("turbot_folder.test"),
+					resource.TestCheckResourceAttrPair("turbot_folder.test", "parent", "data.turbot_resource_akas.root", "id"),
+				),
+			},
+			{
+				Config:      testAccFolderRootParentConfig("Turbot"),
+				ExpectError: regexp.MustCompile("invalid parent 'Turbot' - use 'turbot' for the Turbot root"),
+			},
+		},
+	})
+}
+
+func TestAccFolder_ChangeParent(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckFolderDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFolderChangeParentConfig(`"turbot:/provider_test_parent_a"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFolderExists("turbot_folder.test"),
+					resource.TestCheckResourceAttrPair("turbot_folder.test", "parent", "turbot_folder.parent_a", "id"),
+				),
+			},
+			{
+				Config: testAccFolderChangeParentConfig(`"turbot:/provider_test_parent_b"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("turbot_folder.test", "parent", "turbot_folder.parent_b", "id"),
+				),
+			},
+			{
+				// the same parent given by id plans no change
+				Config:   testAccFolderChangeParentConfig("turbot_folder.parent_b.id"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccFolder_Children(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckFolderDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFolderChildrenConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFolderExists("turbot_folder.parent"),
+					testAccCheckFolderExists("turbot_folder.child"),
+				),
+			},
+			{
+				// the child is created after the parent, so is only in the children map once the parent is refreshed
+				Config: testAccFolderChildrenConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("turbot_folder.parent", "children.%", "1"),
+					resource.TestCheckResourceAttrPair("turbot_folder.parent", "children.provider_test_child", "turbot_folder.child", "id"),
+				),
+			},
+		},
+	})
+}
+
 // configs
+func testAccFolderChildrenConfig() string {
+	return `
+resource "turbot_folder" "parent" {
+	parent = "tmod:@turbot/turbot#/"
+	title = "provider_test_parent"
+	description = "test folder"
+	include_children = true
+	children_resource_types = ["tmod:@turbot/turbot#/resource/types/folder"]
+}
+
+resource "turbot_folder" "child" {
+	parent = turbot_folder.parent.id
+	title = "provider_test_child"
+	description = "test folder"
+}
+`
+}
+
+func testAccFolderDefaultParentConfig() string {
+	return `
+provider "turbot" {
+	default_parent = "tmod:@turbot/turbot#/"
+}
+
+data "turbot_resource_akas" "root" {
+	aka = "tmod:@turbot/turbot#/"
+}
+
+resource "turbot_folder" "parent" {
+	title = "provider_test_parent"
+	description = "test folder"
+}
+
+resource "turbot_folder" "test" {
+	title = "provider_test"
+	description = "test folder"
+}
+
+resource "turbot_folder" "child" {
+	parent = turbot_folder.parent.id
+	title = "provider_test_child"
+	description = "test folder"
+}
+`
+}
+
+func testAccFolderChangeParentConfig(parent string) string {
+	return fmt.Sprintf(`
+resource "turbot_folder" "parent_a" {
+	parent = "turbot"
+	title = "provider_test_parent_a"
+	description = "test folder"
+}
+
+resource "turbot_folder" "parent_b" {
+	parent = "turbot"
+	title = "provider_test_parent_b"
+	description = "test folder"
+}
+
+resource "turbot_folder" "test" {
+	parent = %s
+	title = "provider_test"
+	description = "test folder"
+	depends_on = [turbot_folder.parent_a, turbot_folder.parent_b]
+}
+`, parent)
+}
+
+func testAccFolderRootParentConfig(parent string) string {
+	return fmt.Sprintf(`
+data "turbot_resource_akas" "root" {
+	aka = "tmod:@turbot/turbot#/"
+}
+
+resource "turbot_folder" "test" {
+	parent = "%s"
+	title = "provider_test"
+	description = "test folder"
+}
+`, parent)
+}
+
+func testAccFolderDefaultTagsConfig(team string) string {
+	return fmt.Sprintf(`
+provider "turbot" {
+	default_tags = {
+		"Owner" = "provider_test"
+		"Environment" = "default"
+		"Team" = "%s"
+	}
+}
+
+resource "turbot_folder" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	title = "provider_test"
+	description = "test folder"
+	tags = {
+		"Environment" = "foo"
+	}
+}
+`, team)
+}
+
+func testAccFolderDuplicateTitleConfig(allowDuplicateTitles bool) string {
+	return fmt.Sprintf(`
+resource "turbot_folder" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	title = "provider_test"
+	description = "test folder"
+}
+
+resource "turbot_folder" "duplicate" {
+	parent = "tmod:@turbot/turbot#/"
+	title = "provider_test"
+	description = "test folder"
+	allow_duplicate_titles = %t
+	depends_on = [turbot_folder.test]
+}
+`, allowDuplicateTitles)
+}
+
+func testAccFolderOnExternalChangeConfig(onExternalChange string) string {
+	return fmt.Sprintf(`
+resource "turbot_folder" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	title = "provider_test"
+	description = "test folder"
+	on_external_change = "%s"
+}
+`, onExternalChange)
+}
+
+func testAccFolderMarkdownDescriptionConfig() string {
+	return `
+resource "turbot_folder" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	title = "provider_test"
+	description = <<EOF
+# Provider test  
+Folder used by the **turbot terraform provider** tests.
+
+- item 1
+- item 2
+EOF
+}
+`
+}
+
+func testAccFolderLongDescriptionConfig() string {
+	return fmt.Sprintf(`
+resource "turbot_folder" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	title = "provider_test"
+	description = "%s"
+}
+`, strings.Repeat("x", 10000))
+}
+
 func testAccFolderConfig() string {
 	return `
 resource "turbot_folder" "test" {
@@ -154,6 +548,18 @@ resource "turbot_folder" "test" {
 `
 }
 
+func testAccFolderRemovedTagConfig() string {
+	return `
+resource "turbot_folder" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	title = "provider_test_upd"
+	tags = {
+      "Name" = "Provider Test"
+    }
+}
+`
+}
+
 func testAccFolderWithDependenciesConfig() string {
 	return `
 resource "turbot_folder" "parent" {
@@ -199,6 +605,25 @@ resource "turbot_folder" "child" {
 }
 
 // helper functions
+func testAccGetResourceId(resource string, id *string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("not found: %s", resource)
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+// change the title of a folder outside of Terraform, as if it was renamed in the console
+func testAccRenameFolder(t *testing.T, id, title string) {
+	client := testAccProvider.Meta().(*apiClient.Client)
+	if _, err := client.UpdateFolder(map[string]interface{}{"id": id, "title": title}); err != nil {
+		t.Fatalf("error renaming folder %s: %s", id, err.Error())
+	}
+}
+
 func testAccCheckFolderExists(resource string) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		rs, ok := state.RootModule().Resources[resource]
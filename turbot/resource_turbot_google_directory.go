@@ -1,6 +1,7 @@
 package turbot
 
 import (
+	"github.com/hashicorp/terraform/helper/customdiff"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
 	"github.com/terraform-providers/terraform-provider-turbot/helpers"
@@ -33,21 +34,12 @@ func resourceGoogleDirectory() *schema.Resource {
 			State: resourceTurbotGoogleDirectoryImport,
 		},
 		Schema: map[string]*schema.Schema{
-			// aka of the parent resource
+			// aka of the parent resource - if not set, the provider default_parent is used
 			"parent": {
 				Type:     schema.TypeString,
-				Required: true,
-				// when doing a diff, the state file will contain the id of the parent but the config contains the aka,
-				// so we need custom diff code
-				DiffSuppressFunc: suppressIfAkaMatches("parent_akas"),
-			},
-			// when doing a read, fetch the parent akas to use in suppressIfAkaMatches
-			"parent_akas": {
-				Type:     schema.TypeList,
+				Optional: true,
+				// the state holds the id of the parent, which parentCustomizeDiff compares to the configured parent
 				Computed: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
 			},
 			"title": {
 				Type:     schema.TypeString,
@@ -106,7 +98,14 @@ func resourceGoogleDirectory() *schema.Resource {
 				Type:     schema.TypeMap,
 				Optional: true,
 			},
+			// the tags applied in Turbot, including the provider default_tags
+			"tags_all": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+			"turbot": turbotMetadataSchema(),
 		},
+		CustomizeDiff: customdiff.All(validateParent, parentCustomizeDiff, tagsCustomizeDiff, turbotMetadataCustomizeDiff),
 	}
 }
 
@@ -118,23 +117,24 @@ func resourceTurbotGoogleDirectoryExists(d *schema.ResourceData, meta interface{
 
 func resourceTurbotGoogleDirectoryCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
+	if err := setParent(d, meta); err != nil {
+		return err
+	}
 	// build mutation input
 	input := mapFromResourceData(d, googleDirectoryInputProperties)
+	input["tags"] = buildTagsInput(d, meta)
 	input["status"] = "ACTIVE"
 	turbotMetadata, err := client.CreateGoogleDirectory(input)
 	if err != nil {
 		return err
-		// assign computed properties
-		if err = storeClientSecret(d, input["clientSecret"].(string)); err != nil {
-			return err
-		}
 	}
-	// set parent_akas property by loading parent resource and fetching the akas
-	if err := storeAkas(turbotMetadata.ParentId, "parent_akas", d, meta); err != nil {
+	// assign computed properties
+	if err = storeClientSecret(d, input["clientSecret"].(string)); err != nil {
 		return err
 	}
 	// assign the id
 	d.SetId(turbotMetadata.Id)
+	storeTurbotMetadata(d, *turbotMetadata)
 	d.Set("status", input["status"])
 	d.Set("directory_type", input["directoryType"])
 	return nil
@@ -147,8 +147,9 @@ func resourceTurbotGoogleDirectoryRead(d *schema.ResourceData, meta interface{})
 	googleDirectory, err := client.ReadGoogleDirectory(id)
 	if err != nil {
 		if apiClient.NotFoundError(err) {
-			// directory was not found - clear id
+			// directory was not found - remove it from the state
 			d.SetId("")
+			return nil
 		}
 		return err
 	}
@@ -165,13 +166,16 @@ func resourceTurbotGoogleDirectoryRead(d *schema.ResourceData, meta interface{})
 	d.Set("group_id_template", googleDirectory.GroupIdTemplate)
 	d.Set("login_name_template", googleDirectory.LoginNameTemplate)
 	d.Set("hosted_name", googleDirectory.HostedName)
-	d.Set("tags", googleDirectory.Turbot.Tags)
-	// set parent_akas property by loading parent resource and fetching the akas
-	return storeAkas(googleDirectory.Turbot.ParentId, "parent_akas", d, meta)
+	storeTags(d, googleDirectory.Turbot.Tags, meta)
+	storeTurbotMetadata(d, googleDirectory.Turbot)
+	return nil
 }
 
 func resourceTurbotGoogleDirectoryUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
+	if err := setParent(d, meta); err != nil {
+		return err
+	}
 
 	// build mutation payload
 	input := mapFromResourceData(d, getGoogleDirectoryUpdateProperties())
@@ -181,11 +185,12 @@ func resourceTurbotGoogleDirectoryUpdate(d *schema.ResourceData, meta interface{
 	if err != nil {
 		return err
 	}
-	clientSecret := input["clientSecret"].(string)
-	// set parent_akas property by loading parent resource and fetching the akas
-	if err := storeAkas(turbotMetadata.ParentId, "parent_akas", d, meta); err != nil {
+	// the directory update mutation does not accept tags
+	if err := updateResourceTags(d, meta); err != nil {
 		return err
 	}
+	clientSecret := input["clientSecret"].(string)
+	storeTurbotMetadata(d, *turbotMetadata)
 	// store client secret, encrypting if a pgp key was provided
 	return storeClientSecret(d, clientSecret)
 }
@@ -194,7 +199,7 @@ func resourceTurbotGoogleDirectoryDelete(d *schema.ResourceData, meta interface{
 	client := meta.(*apiClient.Client)
 	id := d.Id()
 	err := client.DeleteResource(id)
-	if err != nil {
+	if err != nil && !apiClient.NotFoundError(err) {
 		return err
 	}
 
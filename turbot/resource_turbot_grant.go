@@ -1,8 +1,10 @@
 package turbot
 
 import (
+	"fmt"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"strings"
 )
 
 // map of Terraform properties to Turbot properties that we pass to create and update mutations
@@ -130,8 +132,9 @@ func resourceTurbotGrantRead(d *schema.ResourceData, meta interface{}) error {
 	Grant, err := client.ReadGrant(id)
 	if err != nil {
 		if apiClient.NotFoundError(err) {
-			// Grant was not found - clear id
+			// Grant was not found - remove it from the state
 			d.SetId("")
+			return nil
 		}
 		return err
 	}
@@ -159,7 +162,7 @@ func resourceTurbotGrantDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
 	id := d.Id()
 	err := client.DeleteGrant(id)
-	if err != nil {
+	if err != nil && !apiClient.NotFoundError(err) {
 		return err
 	}
 
@@ -169,7 +172,20 @@ func resourceTurbotGrantDelete(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+// grants may be imported either by id or by a composite key: profileAka|resourceAka|permissionTypeAka|permissionLevelAka
 func resourceTurbotGrantImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if strings.Contains(d.Id(), "|") {
+		client := meta.(*apiClient.Client)
+		segments := strings.Split(d.Id(), "|")
+		if len(segments) != 4 {
+			return nil, fmt.Errorf("invalid grant import id '%s' - expected either a grant id or profileAka|resourceAka|permissionType|permissionLevel", d.Id())
+		}
+		grant, err := client.FindGrant(segments[0], segments[1], segments[2], segments[3])
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(grant.Turbot.Id)
+	}
 	if err := resourceTurbotGrantRead(d, meta); err != nil {
 		return nil, err
 	}
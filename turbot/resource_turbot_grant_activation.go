@@ -79,8 +79,9 @@ func resourceTurbotGrantActivateRead(d *schema.ResourceData, meta interface{}) e
 	activeGrant, err := client.ReadGrantActivation(id)
 	if err != nil {
 		if apiClient.NotFoundError(err) {
-			// Grant was not found - clear id
+			// Grant was not found - remove it from the state
 			d.SetId("")
+			return nil
 		}
 		return err
 	}
@@ -96,7 +97,7 @@ func resourceTurbotGrantActivateDelete(d *schema.ResourceData, meta interface{})
 	client := meta.(*apiClient.Client)
 	id := d.Id()
 	err := client.DeleteGrantActivation(id)
-	if err != nil {
+	if err != nil && !apiClient.NotFoundError(err) {
 		return err
 	}
 
@@ -0,0 +1,136 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+	"time"
+)
+
+const (
+	grantRequestPending  = "pending"
+	grantRequestApproved = "approved"
+)
+
+// a grant request is a grant which gives no access until an approver activates it - filing the request and
+// (optionally) waiting for the activation allows just-in-time access to be requested by Terraform.
+// the grant is deleted when the request is destroyed, which revokes the access
+func resourceTurbotGrantRequest() *schema.Resource {
+	grantSchema := resourceTurbotGrant().Schema
+	// if true, creation waits (up to the create timeout) for the request to be approved
+	grantSchema["wait_for_approval"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	// pending or approved
+	grantSchema["status"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	// the ids of the activations of the grant
+	grantSchema["activation_ids"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+	return &schema.Resource{
+		Create: resourceTurbotGrantRequestCreate,
+		Read:   resourceTurbotGrantRequestRead,
+		Update: resourceTurbotGrantRequestUpdate,
+		Delete: resourceTurbotGrantDelete,
+		Exists: resourceTurbotGrantExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceTurbotGrantRequestImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Schema: grantSchema,
+	}
+}
+
+func resourceTurbotGrantRequestCreate(d *schema.ResourceData, meta interface{}) error {
+	if err := resourceTurbotGrantCreate(d, meta); err != nil {
+		return err
+	}
+	if d.Get("wait_for_approval").(bool) {
+		if err := waitForGrantApproval(d.Id(), d.Timeout(schema.TimeoutCreate), meta.(*apiClient.Client)); err != nil {
+			return err
+		}
+	}
+	return resourceTurbotGrantRequestRead(d, meta)
+}
+
+func resourceTurbotGrantRequestRead(d *schema.ResourceData, meta interface{}) error {
+	if err := resourceTurbotGrantRead(d, meta); err != nil || d.Id() == "" {
+		return err
+	}
+	return storeGrantRequestStatus(d, meta)
+}
+
+// only wait_for_approval can be updated - if it is set on a pending request, wait for the approval
+func resourceTurbotGrantRequestUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.Get("wait_for_approval").(bool) && d.Get("status").(string) != grantRequestApproved {
+		if err := waitForGrantApproval(d.Id(), d.Timeout(schema.TimeoutUpdate), meta.(*apiClient.Client)); err != nil {
+			return err
+		}
+	}
+	return resourceTurbotGrantRequestRead(d, meta)
+}
+
+func resourceTurbotGrantRequestImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceTurbotGrantRequestRead(d, meta); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// the request is approved once the grant has been activated on any resource
+func storeGrantRequestStatus(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	activations, err := client.ReadGrantActivationList(d.Id())
+	if err != nil {
+		return err
+	}
+	var activationIds []string
+	for _, activation := range activations {
+		activationIds = append(activationIds, activation.Turbot.Id)
+	}
+	status := grantRequestPending
+	if len(activationIds) > 0 {
+		status = grantRequestApproved
+	}
+	d.Set("status", status)
+	d.Set("activation_ids", activationIds)
+	return nil
+}
+
+// poll the activations of the grant until there is one, or the timeout passes
+// the interval between polls starts at 5 seconds and doubles after each poll, up to a minute
+func waitForGrantApproval(grantId string, timeout time.Duration, client *apiClient.Client) error {
+	deadline := time.Now().Add(timeout)
+	interval := 5 * time.Second
+	for {
+		activations, err := client.ReadGrantActivationList(grantId)
+		if err != nil {
+			return err
+		}
+		if len(activations) > 0 {
+			client.Logf(helpers.LogWaiters, "[INFO] grant request %s was approved", grantId)
+			return nil
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return fmt.Errorf("timeout after %s waiting for grant request %s to be approved", timeout, grantId)
+		}
+		client.Logf(helpers.LogWaiters, "[DEBUG] grant request %s is pending approval, checking again in %s", grantId, interval)
+		time.Sleep(interval)
+		if interval *= 2; interval > time.Minute {
+			interval = time.Minute
+		}
+	}
+}
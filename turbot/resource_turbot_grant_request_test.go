@@ -0,0 +1,51 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/resource"
+	"strings"
+	"testing"
+)
+
+func TestAccGrantRequest_Basic(t *testing.T) {
+	resourceName := "turbot_grant_request.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(testAccCheckLocalGrantDestroy, testAccCheckActiveGrantDestroy),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGrantRequestConfig(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLocalGrantExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "status", "pending"),
+					resource.TestCheckResourceAttr(resourceName, "activation_ids.#", "0"),
+				),
+			},
+			// approve the request
+			{
+				Config: testAccGrantRequestConfig(true),
+			},
+			{
+				Config: testAccGrantRequestConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "status", "approved"),
+					resource.TestCheckResourceAttrPair(resourceName, "activation_ids.0", "turbot_grant_activation.approval", "id"),
+				),
+			},
+		},
+	})
+}
+
+// configs
+func testAccGrantRequestConfig(approved bool) string {
+	config := strings.Replace(testAccGrantConfig(), `resource "turbot_grant" "test_grant"`, `resource "turbot_grant_request" "test"`, 1)
+	if approved {
+		config += `
+resource "turbot_grant_activation" "approval" {
+	resource = turbot_grant_request.test.resource
+	grant    = turbot_grant_request.test.id
+}
+`
+	}
+	return config
+}
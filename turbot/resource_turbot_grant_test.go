@@ -29,6 +29,12 @@ func TestAccGrant_Basic(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "170759063660234|tmod:@turbot/turbot#/|tmod:@turbot/turbot-iam#/permission/types/turbot|tmod:@turbot/turbot-iam#/permission/levels/owner",
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
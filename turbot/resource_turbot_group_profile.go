@@ -0,0 +1,175 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/customdiff"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+)
+
+// properties which must be passed to a create/update call
+var groupProfileInputProperties = []interface{}{"parent"}
+var groupProfileDataProperties = []interface{}{"title", "status", "group_profile_id", "directory_pool_id"}
+
+func getGroupProfileUpdateProperties() []interface{} {
+	excludedProperties := []string{"group_profile_id"}
+	return helpers.RemoveProperties(groupProfileDataProperties, excludedProperties)
+}
+
+// a group profile maps a group of a SAML, LDAP or Google directory into Turbot - permissions granted to the group
+// profile (using turbot_grant) apply to every member of the group
+func resourceTurbotGroupProfile() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTurbotGroupProfileCreate,
+		Read:   resourceTurbotGroupProfileRead,
+		Update: resourceTurbotGroupProfileUpdate,
+		Delete: resourceTurbotGroupProfileDelete,
+		Exists: resourceTurbotGroupProfileExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceTurbotGroupProfileImport,
+		},
+		Schema: map[string]*schema.Schema{
+			// the id or aka of the directory
+			"parent": {
+				Type:     schema.TypeString,
+				Optional: true,
+				// the state holds the id of the parent, which parentCustomizeDiff compares to the configured parent
+				Computed: true,
+				ForceNew: true,
+			},
+			"title": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// the id of the group in the directory, e.g. the group name sent in the SAML assertion
+			"group_profile_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"directory_pool_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Active",
+			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+			// the tags applied in Turbot, including the provider default_tags
+			"tags_all": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+			"turbot": turbotMetadataSchema(),
+		},
+		CustomizeDiff: customdiff.All(validateRequiredParent, parentCustomizeDiff, tagsCustomizeDiff, turbotMetadataCustomizeDiff),
+	}
+}
+
+func resourceTurbotGroupProfileExists(d *schema.ResourceData, meta interface{}) (b bool, e error) {
+	client := meta.(*apiClient.Client)
+	id := d.Id()
+	return client.ResourceExists(id)
+}
+
+func resourceTurbotGroupProfileCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	if err := setRequiredParent(d); err != nil {
+		return err
+	}
+	// build mutation data
+	input := mapFromResourceData(d, groupProfileInputProperties)
+	input["data"] = mapFromResourceData(d, groupProfileDataProperties)
+	input["tags"] = buildTagsInput(d, meta)
+
+	groupProfile, err := client.CreateGroupProfile(input)
+	if err != nil {
+		return err
+	}
+
+	// assign the id
+	d.SetId(groupProfile.Turbot.Id)
+	storeTurbotMetadata(d, groupProfile.Turbot)
+	// assign results back into ResourceData
+	d.Set("parent", groupProfile.Parent)
+	d.Set("title", groupProfile.Title)
+	d.Set("status", groupProfile.Status)
+	d.Set("group_profile_id", groupProfile.GroupProfileId)
+	return nil
+}
+
+func resourceTurbotGroupProfileRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	id := d.Id()
+
+	groupProfile, err := client.ReadGroupProfile(id)
+	if err != nil {
+		if apiClient.NotFoundError(err) {
+			// group profile was not found - remove it from the state
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	// if the group profile was imported using an aka, replace the id with the group profile id
+	d.SetId(groupProfile.Turbot.Id)
+	// assign results back into ResourceData
+	d.Set("parent", groupProfile.Parent)
+	d.Set("title", groupProfile.Title)
+	d.Set("status", groupProfile.Status)
+	d.Set("group_profile_id", groupProfile.GroupProfileId)
+	d.Set("directory_pool_id", groupProfile.DirectoryPoolId)
+	storeTags(d, groupProfile.Turbot.Tags, meta)
+	storeTurbotMetadata(d, groupProfile.Turbot)
+	return nil
+}
+
+func resourceTurbotGroupProfileUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	// build mutation data
+	input := mapFromResourceData(d, groupProfileInputProperties)
+	input["data"] = mapFromResourceData(d, getGroupProfileUpdateProperties())
+	if d.HasChange("tags") || d.HasChange("tags_all") {
+		input["tags"] = buildTagsUpdateInput(d, meta)
+	}
+	input["id"] = d.Id()
+
+	groupProfile, err := client.UpdateGroupProfile(input)
+	if err != nil {
+		return err
+	}
+
+	// assign results back into ResourceData
+	d.Set("parent", groupProfile.Parent)
+	d.Set("title", groupProfile.Title)
+	d.Set("status", groupProfile.Status)
+	d.Set("directory_pool_id", groupProfile.DirectoryPoolId)
+	storeTurbotMetadata(d, groupProfile.Turbot)
+	return nil
+}
+
+func resourceTurbotGroupProfileDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	id := d.Id()
+	err := client.DeleteResource(id)
+	if err != nil && !apiClient.NotFoundError(err) {
+		return err
+	}
+
+	// clear the id to show we have deleted
+	d.SetId("")
+	return nil
+}
+
+func resourceTurbotGroupProfileImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceTurbotGroupProfileRead(d, meta); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
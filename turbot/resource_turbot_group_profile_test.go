@@ -0,0 +1,99 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"testing"
+)
+
+// test suites
+func TestAccGroupProfile_Basic(t *testing.T) {
+	resourceName := "turbot_group_profile.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGroupProfileDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGroupProfileConfig("Active"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGroupProfileExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "title", "provider_test_admins"),
+					resource.TestCheckResourceAttr(resourceName, "group_profile_id", "provider_test_admins"),
+					resource.TestCheckResourceAttr(resourceName, "status", "Active"),
+					resource.TestCheckResourceAttrPair(resourceName, "parent", "turbot_saml_directory.test", "id"),
+					resource.TestCheckResourceAttrPair("turbot_grant.test", "identity", resourceName, "id"),
+				),
+			},
+			{
+				Config: testAccGroupProfileConfig("Inactive"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGroupProfileExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "status", "Inactive"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// configs
+func testAccGroupProfileConfig(status string) string {
+	return testAccSamlDirectoryOptionalAttributeConfig() + fmt.Sprintf(`
+resource "turbot_group_profile" "test" {
+	parent           = turbot_saml_directory.test.id
+	title            = "provider_test_admins"
+	group_profile_id = "provider_test_admins"
+	status           = "%s"
+}
+
+resource "turbot_grant" "test" {
+	resource = "tmod:@turbot/turbot#/"
+	type     = "tmod:@turbot/turbot-iam#/permission/types/turbot"
+	level    = "tmod:@turbot/turbot-iam#/permission/levels/operator"
+	identity = turbot_group_profile.test.id
+}
+`, status)
+}
+
+// helper functions
+func testAccCheckGroupProfileExists(resource string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resource)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+		client := testAccProvider.Meta().(*apiClient.Client)
+		_, err := client.ReadGroupProfile(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error fetching item with resource %s. %s", resource, err)
+		}
+		return nil
+	}
+}
+
+func testAccCheckGroupProfileDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*apiClient.Client)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "turbot_group_profile" {
+			_, err := client.ReadGroupProfile(rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("Group profile still exists")
+			}
+			if !apiClient.NotFoundError(err) {
+				return fmt.Errorf("expected 'not found' error, got %s", err)
+			}
+		}
+	}
+
+	return nil
+}
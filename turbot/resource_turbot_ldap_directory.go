@@ -0,0 +1,295 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/customdiff"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"strings"
+)
+
+// input properties which must be passed to a create/update call
+var ldapDirectoryInputProperties = []interface{}{"parent", "title", "description", "profile_id_template", "group_profile_id_template", "url", "distinguished_name", "password", "base", "user_object_filter", "disabled_user_filter", "user_match_filter", "user_search_filter", "user_search_attributes", "user_canonical_name_attribute", "user_email_attribute", "user_display_name_attribute", "user_given_name_attribute", "user_family_name_attribute", "group_object_filter", "group_search_filter", "group_sync_filter", "connectivity_test_filter", "tls_server_certificate", "tags"}
+
+func resourceTurbotLdapDirectory() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTurbotLdapDirectoryCreate,
+		Read:   resourceTurbotLdapDirectoryRead,
+		Update: resourceTurbotLdapDirectoryUpdate,
+		Delete: resourceTurbotLdapDirectoryDelete,
+		Exists: resourceTurbotLdapDirectoryExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceTurbotLdapDirectoryImport,
+		},
+		Schema: map[string]*schema.Schema{
+			// aka of the parent resource - if not set, the provider default_parent is used
+			"parent": {
+				Type:     schema.TypeString,
+				Optional: true,
+				// the state holds the id of the parent, which parentCustomizeDiff compares to the configured parent
+				Computed: true,
+			},
+			"title": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"directory_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"profile_id_template": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"group_profile_id_template": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"url": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// the bind DN
+			"distinguished_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// the bind password is write-only - it is never returned by the API, so the state holds the value last applied
+			"password": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			// the base DN
+			"base": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"user_object_filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"disabled_user_filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"user_match_filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"user_search_filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"user_search_attributes": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"user_canonical_name_attribute": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"user_email_attribute": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"user_display_name_attribute": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"user_given_name_attribute": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"user_family_name_attribute": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"group_object_filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"group_search_filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"group_sync_filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"connectivity_test_filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tls_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"tls_server_certificate": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// reject connections to servers whose certificate is not signed by a trusted authority
+			"reject_unauthorized": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+			// the tags applied in Turbot, including the provider default_tags
+			"tags_all": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+			"turbot": turbotMetadataSchema(),
+		},
+		CustomizeDiff: customdiff.All(validateParent, parentCustomizeDiff, tagsCustomizeDiff, turbotMetadataCustomizeDiff),
+	}
+}
+
+func resourceTurbotLdapDirectoryExists(d *schema.ResourceData, meta interface{}) (b bool, e error) {
+	client := meta.(*apiClient.Client)
+	id := d.Id()
+	return client.ResourceExists(id)
+}
+
+func resourceTurbotLdapDirectoryCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	if err := setParent(d, meta); err != nil {
+		return err
+	}
+
+	input := buildLdapDirectoryInput(d)
+	input["tags"] = buildTagsInput(d, meta)
+	// set computed properties
+	input["status"] = "ACTIVE"
+	ldapDirectory, err := client.CreateLdapDirectory(input)
+	if err != nil {
+		return err
+	}
+
+	// assign the id
+	d.SetId(ldapDirectory.Turbot.Id)
+	storeTurbotMetadata(d, ldapDirectory.Turbot)
+	// assign Read query properties
+	d.Set("status", strings.ToUpper(ldapDirectory.Status))
+	d.Set("directory_type", ldapDirectory.DirectoryType)
+	d.Set("parent", ldapDirectory.Parent)
+	return nil
+}
+
+func resourceTurbotLdapDirectoryRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	id := d.Id()
+
+	ldapDirectory, err := client.ReadLdapDirectory(id)
+	if err != nil {
+		if apiClient.NotFoundError(err) {
+			// ldap directory was not found - remove it from the state
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	// assign results back into ResourceData
+	// NOTE: the password is not returned so we leave the value in the state unchanged
+	d.Set("parent", ldapDirectory.Parent)
+	d.Set("title", ldapDirectory.Title)
+	d.Set("description", ldapDirectory.Description)
+	d.Set("status", strings.ToUpper(ldapDirectory.Status))
+	d.Set("directory_type", ldapDirectory.DirectoryType)
+	d.Set("profile_id_template", ldapDirectory.ProfileIdTemplate)
+	d.Set("group_profile_id_template", ldapDirectory.GroupProfileIdTemplate)
+	d.Set("url", ldapDirectory.Url)
+	d.Set("distinguished_name", ldapDirectory.DistinguishedName)
+	d.Set("base", ldapDirectory.Base)
+	d.Set("user_object_filter", ldapDirectory.UserObjectFilter)
+	d.Set("disabled_user_filter", ldapDirectory.DisabledUserFilter)
+	d.Set("user_match_filter", ldapDirectory.UserMatchFilter)
+	d.Set("user_search_filter", ldapDirectory.UserSearchFilter)
+	d.Set("user_search_attributes", ldapDirectory.UserSearchAttributes)
+	d.Set("user_canonical_name_attribute", ldapDirectory.UserCanonicalNameAttribute)
+	d.Set("user_email_attribute", ldapDirectory.UserEmailAttribute)
+	d.Set("user_display_name_attribute", ldapDirectory.UserDisplayNameAttribute)
+	d.Set("user_given_name_attribute", ldapDirectory.UserGivenNameAttribute)
+	d.Set("user_family_name_attribute", ldapDirectory.UserFamilyNameAttribute)
+	d.Set("group_object_filter", ldapDirectory.GroupObjectFilter)
+	d.Set("group_search_filter", ldapDirectory.GroupSearchFilter)
+	d.Set("group_sync_filter", ldapDirectory.GroupSyncFilter)
+	d.Set("connectivity_test_filter", ldapDirectory.ConnectivityTestFilter)
+	d.Set("tls_enabled", ldapDirectory.TlsEnabled)
+	d.Set("tls_server_certificate", ldapDirectory.TlsServerCertificate)
+	d.Set("reject_unauthorized", ldapDirectory.RejectUnauthorized)
+	storeTags(d, ldapDirectory.Turbot.Tags, meta)
+	storeTurbotMetadata(d, ldapDirectory.Turbot)
+	return nil
+}
+
+func resourceTurbotLdapDirectoryUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	if err := setParent(d, meta); err != nil {
+		return err
+	}
+
+	input := buildLdapDirectoryInput(d)
+	if d.HasChange("tags") || d.HasChange("tags_all") {
+		input["tags"] = buildTagsUpdateInput(d, meta)
+	} else {
+		delete(input, "tags")
+	}
+	input["id"] = d.Id()
+
+	ldapDirectory, err := client.UpdateLdapDirectory(input)
+	if err != nil {
+		return err
+	}
+
+	// assign Read query properties
+	d.Set("parent", ldapDirectory.Parent)
+	d.Set("status", strings.ToUpper(ldapDirectory.Status))
+	storeTurbotMetadata(d, ldapDirectory.Turbot)
+	return nil
+}
+
+func resourceTurbotLdapDirectoryDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	id := d.Id()
+	err := client.DeleteResource(id)
+	if err != nil && !apiClient.NotFoundError(err) {
+		return err
+	}
+
+	// clear the id to show we have deleted
+	d.SetId("")
+	return nil
+}
+
+func resourceTurbotLdapDirectoryImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceTurbotLdapDirectoryRead(d, meta); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func buildLdapDirectoryInput(d *schema.ResourceData) map[string]interface{} {
+	input := mapFromResourceData(d, ldapDirectoryInputProperties)
+	// mapFromResourceData omits false values, so always pass the booleans
+	input["tlsEnabled"] = d.Get("tls_enabled").(bool)
+	input["rejectUnauthorized"] = d.Get("reject_unauthorized").(bool)
+	return input
+}
@@ -0,0 +1,122 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"testing"
+)
+
+// test suites
+func TestAccLdapDirectory_Basic(t *testing.T) {
+	resourceName := "turbot_ldap_directory.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLdapDirectoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLdapDirectoryConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLdapDirectoryExists("turbot_ldap_directory.test"),
+					resource.TestCheckResourceAttr("turbot_ldap_directory.test", "title", "provider-test"),
+					resource.TestCheckResourceAttr("turbot_ldap_directory.test", "profile_id_template", "{{profile.email}}"),
+					resource.TestCheckResourceAttr("turbot_ldap_directory.test", "url", "ldaps://ldap.example.com:636"),
+					resource.TestCheckResourceAttr("turbot_ldap_directory.test", "base", "dc=example,dc=com"),
+					resource.TestCheckResourceAttr("turbot_ldap_directory.test", "user_search_attributes.#", "2"),
+					resource.TestCheckResourceAttr("turbot_ldap_directory.test", "tls_enabled", "true"),
+					resource.TestCheckResourceAttr("turbot_ldap_directory.test", "status", "ACTIVE"),
+				),
+			},
+			{
+				Config: testAccLdapDirectoryUpdateConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLdapDirectoryExists("turbot_ldap_directory.test"),
+					resource.TestCheckResourceAttr("turbot_ldap_directory.test", "description", "LDAP Directory Testing updated"),
+					resource.TestCheckResourceAttr("turbot_ldap_directory.test", "group_object_filter", "(objectClass=groupOfNames)"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
+
+// configs
+func testAccLdapDirectoryConfig() string {
+	return `
+resource "turbot_ldap_directory" "test" {
+	title                  = "provider-test"
+	parent                 = "tmod:@turbot/turbot#/"
+	description            = "LDAP Directory Testing"
+	profile_id_template    = "{{profile.email}}"
+	url                    = "ldaps://ldap.example.com:636"
+	distinguished_name     = "cn=turbot,ou=service,dc=example,dc=com"
+	password               = "provider-test-password"
+	base                   = "dc=example,dc=com"
+	user_object_filter     = "(objectClass=person)"
+	user_search_attributes = ["mail", "uid"]
+	tls_enabled            = true
+}
+`
+}
+
+func testAccLdapDirectoryUpdateConfig() string {
+	return `
+resource "turbot_ldap_directory" "test" {
+	title                  = "provider-test"
+	parent                 = "tmod:@turbot/turbot#/"
+	description            = "LDAP Directory Testing updated"
+	profile_id_template    = "{{profile.email}}"
+	url                    = "ldaps://ldap.example.com:636"
+	distinguished_name     = "cn=turbot,ou=service,dc=example,dc=com"
+	password               = "provider-test-password"
+	base                   = "dc=example,dc=com"
+	user_object_filter     = "(objectClass=person)"
+	user_search_attributes = ["mail", "uid"]
+	group_object_filter    = "(objectClass=groupOfNames)"
+	tls_enabled            = true
+}
+`
+}
+
+// helper functions
+func testAccCheckLdapDirectoryExists(resource string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resource)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+		client := testAccProvider.Meta().(*apiClient.Client)
+		_, err := client.ReadLdapDirectory(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error fetching item with resource %s. %s", resource, err)
+		}
+		return nil
+	}
+}
+
+func testAccCheckLdapDirectoryDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*apiClient.Client)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "turbot_ldap_directory" {
+			_, err := client.ReadLdapDirectory(rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("Alert still exists")
+			}
+			if !apiClient.NotFoundError(err) {
+				return fmt.Errorf("expected 'not found' error, got %s", err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package turbot
 
 import (
+	"github.com/hashicorp/terraform/helper/customdiff"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
 	"github.com/terraform-providers/terraform-provider-turbot/helpers"
@@ -27,21 +28,12 @@ func resourceTurbotLocalDirectory() *schema.Resource {
 			State: resourceTurbotLocalDirectoryImport,
 		},
 		Schema: map[string]*schema.Schema{
-			// aka of the parent resource
+			// aka of the parent resource - if not set, the provider default_parent is used
 			"parent": {
 				Type:     schema.TypeString,
-				Required: true,
-				// when doing a diff, the state file will contain the id of the parent but the config contains the aka,
-				// so we need custom diff code
-				DiffSuppressFunc: suppressIfAkaMatches("parent_akas"),
-			},
-			// when doing a read, fetch the parent akas to use in suppressIfAkaMatches
-			"parent_akas": {
-				Type:     schema.TypeList,
+				Optional: true,
+				// the state holds the id of the parent, which parentCustomizeDiff compares to the configured parent
 				Computed: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
 			},
 			"title": {
 				Type:     schema.TypeString,
@@ -67,7 +59,14 @@ func resourceTurbotLocalDirectory() *schema.Resource {
 				Type:     schema.TypeMap,
 				Optional: true,
 			},
+			// the tags applied in Turbot, including the provider default_tags
+			"tags_all": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+			"turbot": turbotMetadataSchema(),
 		},
+		CustomizeDiff: customdiff.All(validateParent, parentCustomizeDiff, tagsCustomizeDiff, turbotMetadataCustomizeDiff),
 	}
 }
 
@@ -79,10 +78,14 @@ func resourceTurbotLocalDirectoryExists(d *schema.ResourceData, meta interface{}
 
 func resourceTurbotLocalDirectoryCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
+	if err := setParent(d, meta); err != nil {
+		return err
+	}
 
 	// build mutation input
 
 	input := mapFromResourceData(d, localDirectoryInputProperties)
+	input["tags"] = buildTagsInput(d, meta)
 	input["status"] = "ACTIVE"
 
 	localDirectory, err := client.CreateLocalDirectory(input)
@@ -90,12 +93,9 @@ func resourceTurbotLocalDirectoryCreate(d *schema.ResourceData, meta interface{}
 		return err
 	}
 
-	// set parent_akas property by loading resource and fetching the akas
-	if err := storeAkas(localDirectory.Turbot.ParentId, "parent_akas", d, meta); err != nil {
-		return err
-	}
 	// assign the id
 	d.SetId(localDirectory.Turbot.Id)
+	storeTurbotMetadata(d, localDirectory.Turbot)
 	// assign properties coming back from create graphQl API
 	d.Set("parent", localDirectory.Parent)
 	d.Set("title", localDirectory.Title)
@@ -112,8 +112,9 @@ func resourceTurbotLocalDirectoryRead(d *schema.ResourceData, meta interface{})
 	localDirectory, err := client.ReadLocalDirectory(id)
 	if err != nil {
 		if apiClient.NotFoundError(err) {
-			// local directory was not found - clear id
+			// local directory was not found - remove it from the state
 			d.SetId("")
+			return nil
 		}
 		return err
 	}
@@ -125,13 +126,16 @@ func resourceTurbotLocalDirectoryRead(d *schema.ResourceData, meta interface{})
 	d.Set("status", strings.ToUpper(localDirectory.Status))
 	d.Set("profile_id_template", localDirectory.ProfileIdTemplate)
 	d.Set("directory_type", localDirectory.DirectoryType)
-	d.Set("tags", localDirectory.Turbot.Tags)
-	// set parent_akas property by loading resource and fetching the akas
-	return storeAkas(localDirectory.Turbot.ParentId, "parent_akas", d, meta)
+	storeTags(d, localDirectory.Turbot.Tags, meta)
+	storeTurbotMetadata(d, localDirectory.Turbot)
+	return nil
 }
 
 func resourceTurbotLocalDirectoryUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
+	if err := setParent(d, meta); err != nil {
+		return err
+	}
 
 	// build mutation payload
 	input := mapFromResourceData(d, getLocalDirectoryUpdateProperties())
@@ -141,21 +145,25 @@ func resourceTurbotLocalDirectoryUpdate(d *schema.ResourceData, meta interface{}
 	if err != nil {
 		return err
 	}
+	// the directory update mutation does not accept tags
+	if err := updateResourceTags(d, meta); err != nil {
+		return err
+	}
 
 	// assign properties coming back from update graphQl API
 	d.Set("parent", localDirectory.Parent)
 	d.Set("title", localDirectory.Title)
 	d.Set("status", strings.ToUpper(localDirectory.Status))
 	d.Set("directory_type", localDirectory.DirectoryType)
-	// set parent_akas property by loading resource and fetching the akas
-	return storeAkas(localDirectory.Turbot.ParentId, "parent_akas", d, meta)
+	storeTurbotMetadata(d, localDirectory.Turbot)
+	return nil
 }
 
 func resourceTurbotLocalDirectoryDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
 	id := d.Id()
 	err := client.DeleteResource(id)
-	if err != nil {
+	if err != nil && !apiClient.NotFoundError(err) {
 		return err
 	}
 
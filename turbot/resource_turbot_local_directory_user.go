@@ -1,6 +1,7 @@
 package turbot
 
 import (
+	"github.com/hashicorp/terraform/helper/customdiff"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
 )
@@ -16,25 +17,16 @@ func resourceTurbotLocalDirectoryUser() *schema.Resource {
 		Update: resourceTurbotLocalDirectoryUserUpdate,
 		Delete: resourceTurbotLocalDirectoryUserDelete,
 		Exists: resourceTurbotLocalDirectoryUserExists,
-		Importer: &schema.ResourceImporter{ //need to understand
+		Importer: &schema.ResourceImporter{
 			State: resourceTurbotLocalDirectoryUserImport,
 		},
 		Schema: map[string]*schema.Schema{
 			// aka of the parent resource
 			"parent": {
 				Type:     schema.TypeString,
-				Required: true,
-				// when doing a diff, the state file will contain the id of the parent but the config contains the aka,
-				// so we need custom diff code
-				DiffSuppressFunc: suppressIfAkaMatches("parent_akas"),
-			},
-			// when doing a read, fetch the parent akas to use in suppressIfAkaMatches
-			"parent_akas": {
-				Type:     schema.TypeList,
+				Optional: true,
+				// the state holds the id of the parent, which parentCustomizeDiff compares to the configured parent
 				Computed: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
 			},
 			"title": {
 				Type:     schema.TypeString,
@@ -76,7 +68,14 @@ func resourceTurbotLocalDirectoryUser() *schema.Resource {
 				Type:     schema.TypeMap,
 				Optional: true,
 			},
+			// the tags applied in Turbot, including the provider default_tags
+			"tags_all": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+			"turbot": turbotMetadataSchema(),
 		},
+		CustomizeDiff: customdiff.All(validateRequiredParent, parentCustomizeDiff, tagsCustomizeDiff, turbotMetadataCustomizeDiff),
 	}
 }
 
@@ -88,9 +87,13 @@ func resourceTurbotLocalDirectoryUserExists(d *schema.ResourceData, meta interfa
 
 func resourceTurbotLocalDirectoryUserCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
+	if err := setRequiredParent(d); err != nil {
+		return err
+	}
 
 	// build mutation input
 	input := mapFromResourceData(d, localDirectoryUserInputProperties)
+	input["tags"] = buildTagsInput(d, meta)
 	data := mapFromResourceData(d, localDirectoryUserDataProperties)
 	// set computed properties
 	data["status"] = "Active"
@@ -101,12 +104,9 @@ func resourceTurbotLocalDirectoryUserCreate(d *schema.ResourceData, meta interfa
 	if err != nil {
 		return err
 	}
-	// set parent_akas property by loading parent resource and fetching the akas
-	if err := storeAkas(localDirectoryUser.Turbot.ParentId, "parent_akas", d, meta); err != nil {
-		return err
-	}
 	// assign the id
 	d.SetId(localDirectoryUser.Turbot.Id)
+	storeTurbotMetadata(d, localDirectoryUser.Turbot)
 
 	d.Set("parent", localDirectoryUser.Parent)
 	d.Set("title", localDirectoryUser.Title)
@@ -126,6 +126,11 @@ func resourceTurbotLocalDirectoryUserUpdate(d *schema.ResourceData, meta interfa
 	// build mutation payload
 	input := mapFromResourceData(d, localDirectoryUserInputProperties)
 	input["data"] = mapFromResourceData(d, localDirectoryUserDataProperties)
+	if d.HasChange("tags") || d.HasChange("tags_all") {
+		input["tags"] = buildTagsUpdateInput(d, meta)
+	} else {
+		delete(input, "tags")
+	}
 	input["id"] = d.Id()
 
 	// do update
@@ -142,8 +147,8 @@ func resourceTurbotLocalDirectoryUserUpdate(d *schema.ResourceData, meta interfa
 	d.Set("middle_name", localDirectoryUser.MiddleName)
 	d.Set("family_name", localDirectoryUser.FamilyName)
 	d.Set("picture", localDirectoryUser.Picture)
-	// set parent_akas property by loading parent resource and fetching the akas
-	return storeAkas(localDirectoryUser.Turbot.ParentId, "parent_akas", d, meta)
+	storeTurbotMetadata(d, localDirectoryUser.Turbot)
+	return nil
 }
 
 func resourceTurbotLocalDirectoryUserRead(d *schema.ResourceData, meta interface{}) error {
@@ -152,16 +157,13 @@ func resourceTurbotLocalDirectoryUserRead(d *schema.ResourceData, meta interface
 	localDirectoryUser, err := client.ReadLocalDirectoryUser(id)
 	if err != nil {
 		if apiClient.NotFoundError(err) {
-			// folder was not found - clear id
+			// user was not found - remove it from the state
 			d.SetId("")
+			return nil
 		}
 		return err
 	}
 	// assign results back into ResourceData
-	// set parent_akas property by loading parent resource and fetching the akas
-	if err := storeAkas(localDirectoryUser.Turbot.ParentId, "parent_akas", d, meta); err != nil {
-		return err
-	}
 
 	d.Set("parent", localDirectoryUser.Parent)
 	d.Set("title", localDirectoryUser.Title)
@@ -172,7 +174,8 @@ func resourceTurbotLocalDirectoryUserRead(d *schema.ResourceData, meta interface
 	d.Set("middle_name", localDirectoryUser.MiddleName)
 	d.Set("family_name", localDirectoryUser.FamilyName)
 	d.Set("picture", localDirectoryUser.Picture)
-	d.Set("tags", localDirectoryUser.Turbot.Tags)
+	storeTags(d, localDirectoryUser.Turbot.Tags, meta)
+	storeTurbotMetadata(d, localDirectoryUser.Turbot)
 	return nil
 }
 
@@ -180,7 +183,7 @@ func resourceTurbotLocalDirectoryUserDelete(d *schema.ResourceData, meta interfa
 	client := meta.(*apiClient.Client)
 	id := d.Id()
 	err := client.DeleteResource(id)
-	if err != nil {
+	if err != nil && !apiClient.NotFoundError(err) {
 		return err
 	}
 	// clear the id to show we have deleted
@@ -0,0 +1,278 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/customdiff"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/iancoleman/strcase"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+)
+
+// the properties of each user block, which are passed as the data of the create/update call
+var localDirectoryUsersDataProperties = []string{"title", "email", "display_name", "given_name", "middle_name", "family_name", "picture"}
+
+// manage all the users of a local directory in a single resource
+// users are identified by email - the id of the user created for each email is stored in the 'user_ids' map
+func resourceTurbotLocalDirectoryUsers() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTurbotLocalDirectoryUsersCreate,
+		Read:   resourceTurbotLocalDirectoryUsersRead,
+		Update: resourceTurbotLocalDirectoryUsersUpdate,
+		Delete: resourceTurbotLocalDirectoryUsersDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceTurbotLocalDirectoryUsersImport,
+		},
+		Schema: map[string]*schema.Schema{
+			// aka of the local directory
+			"parent": {
+				Type:     schema.TypeString,
+				Optional: true,
+				// the state holds the id of the parent, which parentCustomizeDiff compares to the configured parent
+				Computed: true,
+				ForceNew: true,
+			},
+			"user": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"email": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"title": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"display_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"given_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"middle_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"family_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"picture": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			// map of email -> user id
+			"user_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		CustomizeDiff: customdiff.All(validateRequiredParent, parentCustomizeDiff),
+	}
+}
+
+func resourceTurbotLocalDirectoryUsersCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	if err := setRequiredParent(d); err != nil {
+		return err
+	}
+	parent := d.Get("parent").(string)
+
+	directory, err := client.ReadResource(parent, nil)
+	if err != nil {
+		return err
+	}
+	// use the directory id as the id - there can only be one set of users for a directory
+	d.SetId(directory.Turbot.Id)
+
+	userIds := map[string]interface{}{}
+	// store the users which were created, even if some failed, so they can be deleted later
+	defer func() { d.Set("user_ids", userIds) }()
+	for _, user := range getLocalDirectoryUsers(d) {
+		if err := createLocalDirectoryUser(parent, user, userIds, client); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceTurbotLocalDirectoryUsersRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+
+	userIds := map[string]interface{}{}
+	var users []interface{}
+	for email, id := range d.Get("user_ids").(map[string]interface{}) {
+		localDirectoryUser, err := client.ReadLocalDirectoryUser(id.(string))
+		if err != nil {
+			if apiClient.NotFoundError(err) {
+				// the user has been deleted outside of terraform - it will be recreated on the next apply
+				client.Logf(helpers.LogResources, "[WARN] local directory user %s (%s) not found", email, id)
+				continue
+			}
+			return err
+		}
+		userIds[localDirectoryUser.Email] = id
+		users = append(users, map[string]interface{}{
+			"email":        localDirectoryUser.Email,
+			"title":        localDirectoryUser.Title,
+			"display_name": localDirectoryUser.DisplayName,
+			"given_name":   localDirectoryUser.GivenName,
+			"middle_name":  localDirectoryUser.MiddleName,
+			"family_name":  localDirectoryUser.FamilyName,
+			"picture":      localDirectoryUser.Picture,
+		})
+	}
+	d.Set("user_ids", userIds)
+	d.Set("user", users)
+	return nil
+}
+
+func resourceTurbotLocalDirectoryUsersUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	parent := d.Get("parent").(string)
+
+	userIds := map[string]interface{}{}
+	for email, id := range d.Get("user_ids").(map[string]interface{}) {
+		userIds[email] = id
+	}
+	// always store the user ids, so partial failures are reflected in the state
+	defer func() { d.Set("user_ids", userIds) }()
+
+	oldUsers, _ := d.GetChange("user")
+	previous := map[string]map[string]interface{}{}
+	for _, user := range oldUsers.(*schema.Set).List() {
+		previous[user.(map[string]interface{})["email"].(string)] = user.(map[string]interface{})
+	}
+	users := getLocalDirectoryUsers(d)
+
+	// delete the users which have been removed from the config
+	for email, id := range userIds {
+		if _, ok := users[email]; ok {
+			continue
+		}
+		if err := client.DeleteResource(id.(string)); err != nil && !apiClient.NotFoundError(err) {
+			return fmt.Errorf("error deleting local directory user %s: %w", email, err)
+		}
+		delete(userIds, email)
+	}
+
+	for email, user := range users {
+		id, ok := userIds[email]
+		// create new users
+		if !ok {
+			if err := createLocalDirectoryUser(parent, user, userIds, client); err != nil {
+				return err
+			}
+			continue
+		}
+		// update users whose properties have changed
+		if localDirectoryUserChanged(previous[email], user) {
+			input := map[string]interface{}{
+				"id":   id,
+				"data": buildLocalDirectoryUserData(user),
+			}
+			if _, err := client.UpdateLocalDirectoryUserResource(input); err != nil {
+				return fmt.Errorf("error updating local directory user %s: %w", email, err)
+			}
+		}
+	}
+	return nil
+}
+
+func resourceTurbotLocalDirectoryUsersDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	userIds := d.Get("user_ids").(map[string]interface{})
+	for email, id := range userIds {
+		if err := client.DeleteResource(id.(string)); err != nil && !apiClient.NotFoundError(err) {
+			d.Set("user_ids", userIds)
+			return fmt.Errorf("error deleting local directory user %s: %w", email, err)
+		}
+		delete(userIds, email)
+	}
+
+	// clear the id to show we have deleted
+	d.SetId("")
+	return nil
+}
+
+// import all the users of a local directory, using the id of the directory
+func resourceTurbotLocalDirectoryUsersImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*apiClient.Client)
+	directory, err := client.ReadResource(d.Id(), nil)
+	if err != nil {
+		return nil, err
+	}
+	filter := fmt.Sprintf("resourceType:tmod:@turbot/turbot-iam#/resource/types/localDirectoryUser resourceId:%s level:descendant", directory.Turbot.Id)
+	localDirectoryUsers, err := client.ReadResourceList(filter, map[string]string{"email": "email"})
+	if err != nil {
+		return nil, err
+	}
+	userIds := map[string]interface{}{}
+	for _, localDirectoryUser := range localDirectoryUsers {
+		userIds[localDirectoryUser.GetString("email")] = localDirectoryUser.Turbot.Id
+	}
+
+	d.SetId(directory.Turbot.Id)
+	d.Set("parent", directory.Turbot.Id)
+	d.Set("user_ids", userIds)
+	if err := resourceTurbotLocalDirectoryUsersRead(d, meta); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// return the configured users, keyed by email
+func getLocalDirectoryUsers(d *schema.ResourceData) map[string]map[string]interface{} {
+	users := map[string]map[string]interface{}{}
+	for _, element := range d.Get("user").(*schema.Set).List() {
+		user := element.(map[string]interface{})
+		users[user["email"].(string)] = user
+	}
+	return users
+}
+
+func createLocalDirectoryUser(parent string, user map[string]interface{}, userIds map[string]interface{}, client *apiClient.Client) error {
+	data := buildLocalDirectoryUserData(user)
+	data["status"] = "Active"
+	input := map[string]interface{}{
+		"parent": parent,
+		"data":   data,
+	}
+	localDirectoryUser, err := client.CreateLocalDirectoryUser(input)
+	if err != nil {
+		return fmt.Errorf("error creating local directory user %s: %w", user["email"], err)
+	}
+	userIds[user["email"].(string)] = localDirectoryUser.Turbot.Id
+	return nil
+}
+
+// convert a user block into the turbot data format, e.g. display_name -> displayName
+func buildLocalDirectoryUserData(user map[string]interface{}) map[string]interface{} {
+	data := map[string]interface{}{}
+	for _, property := range localDirectoryUsersDataProperties {
+		if value, ok := user[property]; ok && value != "" {
+			data[strcase.ToLowerCamel(property)] = value
+		}
+	}
+	return data
+}
+
+func localDirectoryUserChanged(old, new map[string]interface{}) bool {
+	for _, property := range localDirectoryUsersDataProperties {
+		if old[property] != new[property] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"strings"
+	"testing"
+)
+
+// test suites
+func TestAccLocalDirectoryUsers_Basic(t *testing.T) {
+	resourceName := "turbot_local_directory_users.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLocalDirectoryUsersDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLocalDirectoryUsersConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLocalDirectoryUsersExist(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "user.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "user_ids.%", "2"),
+				),
+			},
+			{
+				Config: testAccLocalDirectoryUsersUpdateConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLocalDirectoryUsersExist(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "user.#", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "user_ids.kai@turbot.com"),
+					resource.TestCheckResourceAttrSet(resourceName, "user_ids.lee@turbot.com"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// configs
+func testAccLocalDirectoryUsersConfig() string {
+	return `
+resource "turbot_local_directory" "test" {
+	parent              = "tmod:@turbot/turbot#/"
+	title               = "provider_test_local_directory_users"
+	description         = "provider_test_local_directory_users"
+	profile_id_template = "{{profile.email}}"
+}
+
+resource "turbot_local_directory_users" "test" {
+	parent = turbot_local_directory.test.id
+	user {
+		title        = "Kai Daguerre"
+		email        = "kai@turbot.com"
+		display_name = "Kai Daguerre"
+	}
+	user {
+		title        = "Jo Smith"
+		email        = "jo@turbot.com"
+		display_name = "Jo Smith"
+	}
+}
+`
+}
+
+func testAccLocalDirectoryUsersUpdateConfig() string {
+	return `
+resource "turbot_local_directory" "test" {
+	parent              = "tmod:@turbot/turbot#/"
+	title               = "provider_test_local_directory_users"
+	description         = "provider_test_local_directory_users"
+	profile_id_template = "{{profile.email}}"
+}
+
+resource "turbot_local_directory_users" "test" {
+	parent = turbot_local_directory.test.id
+	user {
+		title        = "Kai Daguerre2"
+		email        = "kai@turbot.com"
+		display_name = "Kai Daguerre"
+	}
+	user {
+		title        = "Lee Jones"
+		email        = "lee@turbot.com"
+		display_name = "Lee Jones"
+	}
+}
+`
+}
+
+// helper functions
+func testAccCheckLocalDirectoryUsersExist(resource string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resource)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+		client := testAccProvider.Meta().(*apiClient.Client)
+		for _, id := range localDirectoryUserIds(rs.Primary.Attributes) {
+			if _, err := client.ReadLocalDirectoryUser(id); err != nil {
+				return fmt.Errorf("error fetching user %s. %s", id, err)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckLocalDirectoryUsersDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*apiClient.Client)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "turbot_local_directory_users" {
+			for _, id := range localDirectoryUserIds(rs.Primary.Attributes) {
+				_, err := client.ReadLocalDirectoryUser(id)
+				if err == nil {
+					return fmt.Errorf("Alert still exists")
+				}
+				if !apiClient.NotFoundError(err) {
+					return fmt.Errorf("expected 'not found' error, got %s", err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func localDirectoryUserIds(attributes map[string]string) []string {
+	var userIds []string
+	for key, value := range attributes {
+		if strings.HasPrefix(key, "user_ids.") && key != "user_ids.%" {
+			userIds = append(userIds, value)
+		}
+	}
+	return userIds
+}
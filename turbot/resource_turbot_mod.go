@@ -1,12 +1,12 @@
 package turbot
 
 import (
-	"errors"
 	"fmt"
 	"github.com/Masterminds/semver"
-	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/customdiff"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
 	"log"
 	"strings"
 	"time"
@@ -14,6 +14,17 @@ import (
 
 var modInputProperties = []interface{}{"parent", "org", "mod", "version"}
 
+// the control which installs a mod - if the installation fails, this control is in the error state
+const modInstalledControlType = "tmod:@turbot/turbot#/control/types/modInstalled"
+
+// the release channels a mod version is chosen from
+const (
+	// release versions only, unless the version requirement is itself a pre-release, e.g. 5.1.0-beta.1
+	modChannelStable = "stable"
+	// pre-release versions are also installed, if their release version satisfies the version requirement
+	modChannelBeta = "beta"
+)
+
 func resourceTurbotMod() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceTurbotModInstall,
@@ -26,25 +37,16 @@ func resourceTurbotMod() *schema.Resource {
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(15 * time.Minute),
+			Update: schema.DefaultTimeout(15 * time.Minute),
 		},
 		Schema: map[string]*schema.Schema{
-			// aka of the parent resource
+			// aka of the parent resource - if not set, the Turbot root is used
 			"parent": {
 				Type:     schema.TypeString,
 				Optional: true,
-				// when doing a diff, the state file will contain the id of the parent but the config contains the aka,
-				// so we need custom diff code
-				DiffSuppressFunc: suppressIfAkaMatches("parent_akas"),
-				ForceNew:         true,
-				Default:          "tmod:@turbot/turbot#/",
-			},
-			// when doing a read, fetch the parent akas to use in suppressIfAkaMatches
-			"parent_akas": {
-				Type:     schema.TypeList,
+				// the state holds the id of the parent, which parentCustomizeDiff compares to the configured parent
 				Computed: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
+				ForceNew: true,
 			},
 			"uri": {
 				Type:     schema.TypeString,
@@ -66,6 +68,12 @@ func resourceTurbotMod() *schema.Resource {
 				// default the version to any version
 				Default: "*",
 			},
+			// the release channel the version is chosen from: "stable" or "beta"
+			"channel": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  modChannelStable,
+			},
 			// store the version currently installed (as the 'version' property may be a range)
 			"version_current": {
 				Type:     schema.TypeString,
@@ -76,22 +84,90 @@ func resourceTurbotMod() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			// how long to wait for the installation to complete, in seconds - overrides the create/update timeouts
+			"timeout": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			// how often to check whether the installation has completed, in seconds
+			// if not set, the interval increases from 0.5 to 10 seconds
+			"poll_interval": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			// if true, after the installation wait for the mod installed control to be 'ok' - catching installations
+			// which complete but leave the mod unhealthy
+			"wait_for_healthy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// the state of the mod installed control, e.g. "ok" or "error"
+			"install_state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			// if false, a newer version which satisfies the version requirement is not installed
+			// unless the installed version no longer satisfies the requirement
+			"auto_update": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			// the peer dependencies of the installed version - these are installed and healthy before the mod is installed
+			"dependencies": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						// the name of the dependency, e.g. @turbot/aws
+						"mod": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						// the version range required
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			// if true, uninstall the mod even if other installed mods depend on it
+			"force_uninstall": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"skip_uninstall"},
+			},
+			// if true, destroying the resource removes the mod from the state but leaves it installed - uninstalling a
+			// mod deletes all resources of the types it defines
+			"skip_uninstall": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"turbot": turbotMetadataSchema(),
 		},
-		CustomizeDiff: resourceTurbotModCustomizeDiff,
+		CustomizeDiff: customdiff.All(parentCustomizeDiff, resourceTurbotModCustomizeDiff, turbotMetadataCustomizeDiff),
 	}
 }
 
 func resourceTurbotModCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	channel := d.Get("channel").(string)
+	if channel != modChannelStable && channel != modChannelBeta {
+		return attributeError("channel", fmt.Errorf("invalid value '%s' - must be one of '%s' or '%s'", channel, modChannelStable, modChannelBeta))
+	}
 
 	versionCurrent := d.Get("version_current").(string)
 	var versionLatest string
-	// if the version has changed, re-fetch the latest compatible version to detect if we need to change the installed version
-	if d.HasChange("version") {
+	// if the version or channel has changed, re-fetch the latest compatible version to detect if we need to change the installed version
+	if d.HasChange("version") || d.HasChange("channel") {
 		var err error
 		org := d.Get("org").(string)
 		modName := d.Get("mod").(string)
 		version := d.Get("version").(string)
-		versionLatest, err = getLatestCompatibleVersion(org, modName, version, meta)
+		versionLatest, err = getLatestCompatibleVersion(org, modName, version, channel, meta)
 		if err != nil {
 			return err
 		}
@@ -99,11 +175,23 @@ func resourceTurbotModCustomizeDiff(d *schema.ResourceDiff, meta interface{}) er
 		// otherwise if version has not changed, use the saved value of version_latest
 		versionLatest = d.Get("version_latest").(string)
 	}
+	// if auto update is disabled, keep the installed version as long as it satisfies the version requirements
+	if !d.Get("auto_update").(bool) && d.Id() != "" && versionCurrent != "" && versionSatisfies(versionCurrent, d.Get("version").(string), channel) {
+		meta.(*apiClient.Client).Logf(helpers.LogResources, "[DEBUG] auto_update is disabled - keeping installed version %s (latest compatible version %s)", versionCurrent, versionLatest)
+		versionLatest = versionCurrent
+	}
 	// if the current version is not the latest which satisfied the version requirements, raise a diff
 	if versionCurrent != versionLatest {
 		if err := d.SetNew("version_current", versionLatest); err != nil {
 			return err
 		}
+		if err := d.SetNewComputed("dependencies"); err != nil {
+			return err
+		}
+	}
+	// check the dependencies of the version being installed do not conflict with the other mods in the configuration
+	if versionLatest != "" {
+		return checkModDependencyConflicts(d.Get("org").(string), d.Get("mod").(string), versionLatest, meta)
 	}
 	return nil
 }
@@ -132,19 +220,46 @@ func resourceTurbotModInstall(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	return modInstall(d, meta)
+	return modInstall(d, meta, schema.TimeoutCreate)
 }
 
 func resourceTurbotModUpdate(d *schema.ResourceData, meta interface{}) error {
-	return modInstall(d, meta)
+	// if the version to install has not changed (e.g. only auto_update, or a range which the installed version
+	// still satisfies, has changed) there is nothing to install
+	if !d.HasChange("version_current") {
+		return resourceTurbotModRead(d, meta)
+	}
+	return modInstall(d, meta, schema.TimeoutUpdate)
 }
 
 // do the actual mode installation
-func modInstall(d *schema.ResourceData, meta interface{}) error {
+func modInstall(d *schema.ResourceData, meta interface{}, timeoutKey string) error {
 	client := meta.(*apiClient.Client)
+	// mods are installed in the Turbot root unless another parent is given
+	setParentOrDefault(d, turbotRootAka)
 
 	// install mod returns turbot resource metadata containing the id
 	input := mapFromResourceData(d, modInputProperties)
+	// install the exact version resolved at plan time, rather than the latest version satisfying the range
+	if versionCurrent := d.Get("version_current").(string); versionCurrent != "" {
+		input["version"] = versionCurrent
+	}
+	// installing a mod fails if its dependencies are not installed - if they are still installing, wait for them
+	// first. the wait shares the installation timeout
+	timeout := getModInstallTimeout(d, timeoutKey)
+	start := time.Now()
+	if version, ok := input["version"].(string); ok && version != "" {
+		dependencies, err := client.GetModVersionDependencies(d.Get("org").(string), d.Get("mod").(string), version)
+		if err != nil {
+			return err
+		}
+		if err := waitForModDependencies(d.Get("org").(string), d.Get("mod").(string), version, dependencies, timeout, client); err != nil {
+			return err
+		}
+		// the installed version is the version resolved at plan time, so Read does not need to read its dependencies
+		setModDependencies(d, dependencies)
+	}
+	installStart := time.Now()
 	mod, err := client.InstallMod(input)
 	if err != nil {
 		log.Println("[ERROR] Turbot mod installation failed...", err)
@@ -154,37 +269,77 @@ func modInstall(d *schema.ResourceData, meta interface{}) error {
 	modId := mod.Turbot.Id
 	// now poll the mod resource to wait for the correct version
 	targetBuild := mod.Build
-	log.Printf("Wait for mod installation, targetBuild: %s", targetBuild)
-	err = resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
-		installedVersion, installedBuild, err := getInstalledModVersion(modId, client)
+	client.Logf(helpers.LogWaiters, "[DEBUG] Wait for mod installation, targetBuild: %s", targetBuild)
+	// if the workspace supports long polling, each read of the mod waits for it to change. a failed installation does
+	// not change the mod, so is only detected when a read is not answered before the long polling wait ends
+	minInterval, maxInterval := 500*time.Millisecond, 10*time.Second
+	if pollInterval := time.Duration(d.Get("poll_interval").(int)) * time.Second; pollInterval > 0 {
+		minInterval, maxInterval = pollInterval, pollInterval
+	}
+	watch := apiClient.NewWatch()
+	installed, err := pollWithWatch(watch, timeout-time.Since(start), minInterval, maxInterval, func() (bool, error) {
+		installedVersion, installedBuild, err := getInstalledModVersion(modId, watch, client)
+		if err != nil {
+			return false, err
+		}
 		if installedBuild == targetBuild {
-			log.Printf("installed version: %s, installed build: %s, target build: %s, mod is installed!", installedVersion, installedBuild, targetBuild)
-			// success
-			return nil
+			client.Logf(helpers.LogWaiters, "[DEBUG] installed version: %s, installed build: %s, target build: %s, mod is installed!", installedVersion, installedBuild, targetBuild)
+			return true, nil
 		}
-		if err == nil {
-			err = errors.New("Turbot mod installation timed out")
-			return resource.RetryableError(err)
+		// fail fast if the installation has failed, rather than waiting for the timeout
+		control, err := getModInstallControl(modId, client)
+		if err != nil {
+			return false, err
 		}
-		return resource.NonRetryableError(err)
+		if control != nil && control.State == "error" && controlUpdatedSince(control, installStart) {
+			return false, fmt.Errorf("Turbot mod installation failed: %s (%s)", control.Reason, control.Details)
+		}
+		return false, nil
 	})
 	if err != nil {
 		return err
 	}
+	if !installed {
+		return fmt.Errorf("Turbot mod installation timed out after %s waiting for build %s. Increase the timeout using the 'timeout' argument or the timeouts block", timeout, targetBuild)
+	}
 
 	// assign the id
 	d.SetId(modId)
+	if d.Get("wait_for_healthy").(bool) {
+		// the health check shares the installation timeout
+		remaining := timeout - time.Since(start)
+		if err := waitForModHealthy(modId, remaining, client); err != nil {
+			return err
+		}
+	}
 	return resourceTurbotModRead(d, meta)
 }
 
+// wait for the mod installed control of an installed mod to be 'ok'
+func waitForModHealthy(modId string, timeout time.Duration, client *apiClient.Client) error {
+	c := controlReference{Type: modInstalledControlType, Resource: modId}
+	control, healthy, err := waitForControlState(c, []string{"ok"}, timeout, time.Second, 10*time.Second, client)
+	if err != nil {
+		return err
+	}
+	if healthy {
+		return nil
+	}
+	if control == nil {
+		return fmt.Errorf("Turbot mod %s is installed but its installed control was not found after %s", modId, timeout)
+	}
+	return fmt.Errorf("Turbot mod %s is installed but is not healthy - its installed control is in state '%s': %s", modId, control.State, control.Reason)
+}
+
 func resourceTurbotModRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
 	id := d.Id()
 	mod, err := client.ReadMod(id)
 	if err != nil {
 		if apiClient.NotFoundError(err) {
-			// mod was not found - clear id
+			// mod was not found - remove it from the state
 			d.SetId("")
+			return nil
 		}
 		return err
 	}
@@ -194,35 +349,70 @@ func resourceTurbotModRead(d *schema.ResourceData, meta interface{}) error {
 	if version := d.Get("version").(string); version != "" {
 		org := d.Get("org").(string)
 		modName := d.Get("mod").(string)
-		targetVersion, err = getLatestCompatibleVersion(org, modName, version, meta)
-		log.Printf("resourceTurbotModRead config version %s installed version %s latest version%s", version, mod.Version, targetVersion)
+		targetVersion, err = getLatestCompatibleVersion(org, modName, version, d.Get("channel").(string), meta)
+		client.Logf(helpers.LogResources, "[DEBUG] resourceTurbotModRead config version %s installed version %s latest version%s", version, mod.Version, targetVersion)
 		if err != nil {
 			return err
 		}
 	} else {
-		log.Printf("resourceTurbotModRead no version in resource data mod.Version %s", mod.Version)
+		client.Logf(helpers.LogResources, "[DEBUG] resourceTurbotModRead no version in resource data mod.Version %s", mod.Version)
 		// if version is NOT set in resource data (e.g. for an import), just use the actual mod version and targetVersion
 		targetVersion = mod.Version
 	}
 
 	// assign results back into ResourceData
 
+	// the dependencies of a mod version do not change, so they are only read from the registry if the installed version
+	// is not the version in the state, e.g. on import or if the mod was updated outside of Terraform
+	if mod.Version != d.Get("version_current").(string) {
+		if err := storeModDependencies(d, mod, client); err != nil {
+			return err
+		}
+	}
 	d.Set("parent", mod.Parent)
 	d.Set("org", mod.Org)
 	d.Set("mod", mod.Mod)
 	d.Set("version_current", mod.Version)
 	d.Set("version_latest", targetVersion)
 	d.Set("uri", mod.Uri)
+	installControl, err := getModInstallControl(id, client)
+	if err != nil {
+		return err
+	}
+	if installControl != nil {
+		d.Set("install_state", installControl.State)
+	}
 
-	// set parent_akas property by loading resource and fetching the akas
-	return storeAkas(mod.Parent, "parent_akas", d, meta)
+	storeTurbotMetadata(d, mod.Turbot)
+	return nil
 }
 
 func resourceTurbotModUninstall(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
 	id := d.Id()
+	name := buildModName(d.Get("org").(string), d.Get("mod").(string))
+
+	if d.Get("skip_uninstall").(bool) {
+		client.Logf(helpers.LogResources, "[WARN] skip_uninstall is set - removing %s from the state, but leaving it installed", name)
+		d.SetId("")
+		return nil
+	}
+
+	// uninstalling a mod which other mods depend on breaks them - fail, unless force_uninstall is set
+	if d.Get("force_uninstall").(bool) {
+		client.Logf(helpers.LogResources, "[WARN] force uninstalling %s, without checking whether installed mods require it", name)
+	} else {
+		dependents, err := getInstalledModDependents(name, client)
+		if err != nil {
+			return err
+		}
+		if len(dependents) > 0 {
+			return fmt.Errorf("cannot uninstall %s, it is required by the installed mods: %s. Uninstall these mods first (if they are in this configuration, add this mod to their depends_on), or set force_uninstall = true to uninstall it anyway", name, strings.Join(dependents, ", "))
+		}
+	}
+
 	err := client.UninstallMod(id)
-	if err != nil {
+	if err != nil && !apiClient.NotFoundError(err) {
 		return err
 	}
 
@@ -239,31 +429,52 @@ func resourceTurbotModImport(d *schema.ResourceData, meta interface{}) ([]*schem
 	return []*schema.ResourceData{d}, nil
 }
 
+// read the mod installed control of the mod - returns nil if the control has not been created yet
+func getModInstallControl(modId string, client *apiClient.Client) (*apiClient.Control, error) {
+	control, err := client.ReadControl(fmt.Sprintf(`uri: "%s", resourceId: "%s"`, modInstalledControlType, modId))
+	if err != nil {
+		if apiClient.NotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return control, nil
+}
+
+// has the control been updated since the given time - an error from a previous installation is ignored
+func controlUpdatedSince(control *apiClient.Control, since time.Time) bool {
+	updated, err := time.Parse(time.RFC3339, control.Turbot.UpdateTimestamp)
+	return err == nil && !updated.Before(since)
+}
+
+// the 'timeout' argument takes precedence over the create/update timeouts
+func getModInstallTimeout(d *schema.ResourceData, timeoutKey string) time.Duration {
+	if timeout := d.Get("timeout").(int); timeout > 0 {
+		return time.Duration(timeout) * time.Second
+	}
+	return d.Timeout(timeoutKey)
+}
+
 func buildModAka(org, mod string) string {
 	return fmt.Sprintf("tmod:@%s/%s", org, mod)
 }
 
-func getInstalledModVersion(modId string, client *apiClient.Client) (version, build string, err error) {
+// read the installed version of the mod - if watch is not nil, the read waits for the mod to change (see apiClient.Watch)
+func getInstalledModVersion(modId string, watch *apiClient.Watch, client *apiClient.Client) (version, build string, err error) {
 	properties := map[string]string{
 		"version": "version",
 		"build":   "build",
 	}
 
-	resource, err := client.ReadResource(modId, properties)
+	resource, err := client.WatchResource(watch, modId, properties)
 	if err != nil {
 		return "", "", err
 	}
-	versionData := resource.Data["version"]
-	buildData := resource.Data["build"]
-	if (versionData == nil) || (buildData == nil) {
-		return "", "", nil
-	}
-	version = versionData.(string)
-	build = buildData.(string)
-	return
+	// if the mod is still installing, the version and build will not be set
+	return resource.GetString("version"), resource.GetString("build"), nil
 }
 
-func getLatestCompatibleVersion(org, modName, version string, meta interface{}) (string, error) {
+func getLatestCompatibleVersion(org, modName, version, channel string, meta interface{}) (string, error) {
 	client := meta.(*apiClient.Client)
 	modVersions, err := client.GetModVersions(org, modName)
 	if err != nil {
@@ -287,7 +498,7 @@ func getLatestCompatibleVersion(org, modName, version string, meta interface{})
 				return "", err
 			}
 			// does this version meet the requirement
-			if c.Check(v) && (latestVersion == nil || v.GreaterThan(latestVersion)) {
+			if modVersionMatches(c, v, channel) && (latestVersion == nil || v.GreaterThan(latestVersion)) {
 				latestVersion = v
 			}
 		}
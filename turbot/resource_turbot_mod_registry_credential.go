@@ -0,0 +1,147 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/customdiff"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+)
+
+// properties which must be passed to a create/update call
+var modRegistryCredentialInputProperties = []interface{}{"parent"}
+var modRegistryCredentialDataProperties = []interface{}{"title", "registry", "org", "access_key", "secret_key"}
+
+func resourceTurbotModRegistryCredential() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTurbotModRegistryCredentialCreate,
+		Read:   resourceTurbotModRegistryCredentialRead,
+		Update: resourceTurbotModRegistryCredentialUpdate,
+		Delete: resourceTurbotModRegistryCredentialDelete,
+		Exists: resourceTurbotModRegistryCredentialExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceTurbotModRegistryCredentialImport,
+		},
+		Schema: map[string]*schema.Schema{
+			// aka of the parent resource - if not set, the Turbot root is used
+			"parent": {
+				Type:     schema.TypeString,
+				Optional: true,
+				// the state holds the id of the parent, which parentCustomizeDiff compares to the configured parent
+				Computed: true,
+				ForceNew: true,
+			},
+			"title": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"registry": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"org": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"access_key": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// the secret key is write-only - it is never returned by the API, so the state holds the value last applied
+			"secret_key": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"turbot": turbotMetadataSchema(),
+		},
+		CustomizeDiff: customdiff.All(parentCustomizeDiff, turbotMetadataCustomizeDiff),
+	}
+}
+
+func resourceTurbotModRegistryCredentialExists(d *schema.ResourceData, meta interface{}) (b bool, e error) {
+	client := meta.(*apiClient.Client)
+	id := d.Id()
+	return client.ResourceExists(id)
+}
+
+func resourceTurbotModRegistryCredentialCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	// the credential is created in the Turbot root unless another parent is given
+	setParentOrDefault(d, turbotRootAka)
+
+	// build mutation input
+	input := mapFromResourceData(d, modRegistryCredentialInputProperties)
+	input["data"] = mapFromResourceData(d, modRegistryCredentialDataProperties)
+
+	credential, err := client.CreateModRegistryCredential(input)
+	if err != nil {
+		return err
+	}
+
+	// assign the id
+	d.SetId(credential.Turbot.Id)
+	storeTurbotMetadata(d, credential.Turbot)
+	d.Set("parent", credential.Parent)
+	return nil
+}
+
+func resourceTurbotModRegistryCredentialRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	id := d.Id()
+
+	credential, err := client.ReadModRegistryCredential(id)
+	if err != nil {
+		if apiClient.NotFoundError(err) {
+			// credential was not found - remove it from the state
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	// assign results back into ResourceData
+	// NOTE: the secret key is not returned so we leave the value in the state unchanged
+	d.Set("parent", credential.Parent)
+	d.Set("title", credential.Title)
+	d.Set("registry", credential.Registry)
+	d.Set("org", credential.Org)
+	d.Set("access_key", credential.AccessKey)
+	storeTurbotMetadata(d, credential.Turbot)
+	return nil
+}
+
+func resourceTurbotModRegistryCredentialUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+
+	// build mutation payload
+	input := mapFromResourceData(d, modRegistryCredentialInputProperties)
+	input["data"] = mapFromResourceData(d, modRegistryCredentialDataProperties)
+	input["id"] = d.Id()
+
+	credential, err := client.UpdateModRegistryCredential(input)
+	if err != nil {
+		return err
+	}
+	d.Set("parent", credential.Parent)
+	storeTurbotMetadata(d, credential.Turbot)
+	return nil
+}
+
+func resourceTurbotModRegistryCredentialDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	id := d.Id()
+	err := client.DeleteResource(id)
+	if err != nil && !apiClient.NotFoundError(err) {
+		return err
+	}
+
+	// clear the id to show we have deleted
+	d.SetId("")
+	return nil
+}
+
+func resourceTurbotModRegistryCredentialImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceTurbotModRegistryCredentialRead(d, meta); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
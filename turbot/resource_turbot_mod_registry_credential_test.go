@@ -0,0 +1,92 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"testing"
+)
+
+// test suites
+func TestAccModRegistryCredential_Basic(t *testing.T) {
+	resourceName := "turbot_mod_registry_credential.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckModRegistryCredentialDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccModRegistryCredentialConfig("provider_test"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckModRegistryCredentialExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "title", "provider_test"),
+					resource.TestCheckResourceAttr(resourceName, "org", "turbot-provider-test"),
+					resource.TestCheckResourceAttr(resourceName, "secret_key", "provider-test-secret"),
+				),
+			},
+			{
+				Config: testAccModRegistryCredentialConfig("provider_test_upd"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckModRegistryCredentialExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "title", "provider_test_upd"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret_key"},
+			},
+		},
+	})
+}
+
+// configs
+func testAccModRegistryCredentialConfig(title string) string {
+	return fmt.Sprintf(`
+resource "turbot_mod_registry_credential" "test" {
+	title      = "%s"
+	registry   = "registry.turbot.com"
+	org        = "turbot-provider-test"
+	access_key = "provider-test-access-key"
+	secret_key = "provider-test-secret"
+}
+`, title)
+}
+
+// helper functions
+func testAccCheckModRegistryCredentialExists(resource string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resource)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+		client := testAccProvider.Meta().(*apiClient.Client)
+		_, err := client.ReadModRegistryCredential(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error fetching item with resource %s. %s", resource, err)
+		}
+		return nil
+	}
+}
+
+func testAccCheckModRegistryCredentialDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*apiClient.Client)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "turbot_mod_registry_credential" {
+			_, err := client.ReadModRegistryCredential(rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("Alert still exists")
+			}
+			if !apiClient.NotFoundError(err) {
+				return fmt.Errorf("expected 'not found' error, got %s", err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"regexp"
 	"testing"
 )
 
@@ -130,6 +131,44 @@ func TestAccMod_Basic(t *testing.T) {
 	})
 }
 
+func TestAccMod_AutoUpdateDisabled(t *testing.T) {
+	latestProviderTestVersion := "5.0.2"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccModDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMod_v5_0_0_Config(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccModExists("turbot_mod.test"),
+					resource.TestCheckResourceAttr(
+						"turbot_mod.test", "version_current", "5.0.0"),
+				),
+			},
+			{
+				// the installed version satisfies the range, so it is not updated
+				Config: testAccModAutoUpdateConfig(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccModExists("turbot_mod.test"),
+					resource.TestCheckResourceAttr(
+						"turbot_mod.test", "version_current", "5.0.0"),
+					resource.TestCheckResourceAttr(
+						"turbot_mod.test", "version_latest", latestProviderTestVersion),
+				),
+			},
+			{
+				Config: testAccModAutoUpdateConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccModExists("turbot_mod.test"),
+					resource.TestCheckResourceAttr(
+						"turbot_mod.test", "version_current", latestProviderTestVersion),
+				),
+			},
+		},
+	})
+}
+
 // configs
 func testAccMod_v5_0_0_Config() string {
 	return `
@@ -164,6 +203,50 @@ resource "turbot_mod" "test" {
 `
 }
 
+func TestAccMod_WaitForHealthy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccModDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccModWaitForHealthyConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccModExists("turbot_mod.test"),
+					resource.TestCheckResourceAttr(
+						"turbot_mod.test", "version_current", "5.0.0"),
+					resource.TestCheckResourceAttr(
+						"turbot_mod.test", "install_state", "ok"),
+				),
+			},
+		},
+	})
+}
+
+func testAccModWaitForHealthyConfig() string {
+	return `
+resource "turbot_mod" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	org = "turbot"
+	mod = "turbot-terraform-provider-test"
+	version = "5.0.0"
+	wait_for_healthy = true
+}
+`
+}
+
+func testAccModAutoUpdateConfig(autoUpdate bool) string {
+	return fmt.Sprintf(`
+resource "turbot_mod" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	org = "turbot"
+	mod = "turbot-terraform-provider-test"
+	version = ">=5.0.0"
+	auto_update = %t
+}
+`, autoUpdate)
+}
+
 func testAccMod_lt_v5_0_3_Config() string {
 	return `
 resource "turbot_mod" "test" {
@@ -252,3 +335,139 @@ func testAccModDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+// aws-s3 depends on aws - they are installed in one apply, with aws in the depends_on of aws-s3, so the aws-s3
+// installation must wait for aws to be healthy
+func TestAccMod_Dependencies(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccModDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccModDependenciesConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccModExists("turbot_mod.aws"),
+					testAccModExists("turbot_mod.aws_s3"),
+					resource.TestCheckResourceAttr(
+						"turbot_mod.aws_s3", "dependencies.0.mod", "@turbot/aws"),
+					resource.TestCheckResourceAttrSet(
+						"turbot_mod.aws_s3", "dependencies.0.version"),
+				),
+			},
+		},
+	})
+}
+
+func testAccModDependenciesConfig() string {
+	return `
+resource "turbot_mod" "aws" {
+	parent = "tmod:@turbot/turbot#/"
+	org = "turbot"
+	mod = "aws"
+}
+
+resource "turbot_mod" "aws_s3" {
+	parent = "tmod:@turbot/turbot#/"
+	org = "turbot"
+	mod = "aws-s3"
+	depends_on = [turbot_mod.aws]
+}
+`
+}
+
+func TestAccMod_SkipUninstall(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccModDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccModSkipUninstallConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccModExists("turbot_mod.test"),
+					resource.TestCheckResourceAttr(
+						"turbot_mod.test", "skip_uninstall", "true"),
+				),
+			},
+			{
+				// removing the resource must leave the mod installed
+				Config: testAccModRemovedConfig(),
+				Check:  testAccCheckModInstalledAndUninstall("tmod:@turbot/turbot-terraform-provider-test"),
+			},
+		},
+	})
+}
+
+func testAccModSkipUninstallConfig() string {
+	return `
+resource "turbot_mod" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	org = "turbot"
+	mod = "turbot-terraform-provider-test"
+	version = "5.0.0"
+	skip_uninstall = true
+}
+`
+}
+
+func testAccModRemovedConfig() string {
+	return `
+resource "turbot_folder" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	title = "provider_test_mod_removed"
+	description = "test folder for turbot terraform provider"
+}
+`
+}
+
+// check the mod is still installed, then uninstall it, as it is no longer managed by the test
+func testAccCheckModInstalledAndUninstall(aka string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		client := testAccProvider.Meta().(*apiClient.Client)
+		mod, err := client.ReadResource(aka, nil)
+		if err != nil {
+			return fmt.Errorf("expected mod %s to still be installed: %s", aka, err)
+		}
+		return client.UninstallMod(mod.Turbot.Id)
+	}
+}
+
+func TestAccMod_BetaChannel(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccModDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccModChannelConfig("beta"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccModExists("turbot_mod.test"),
+					resource.TestCheckResourceAttr(
+						"turbot_mod.test", "channel", "beta"),
+					resource.TestCheckResourceAttrPair(
+						"turbot_mod.test", "version_current", "turbot_mod.test", "version_latest"),
+					// the test mod has a pre-release newer than its latest release, which the beta channel installs
+					resource.TestMatchResourceAttr(
+						"turbot_mod.test", "version_current", regexp.MustCompile(`^\d+\.\d+\.\d+-`)),
+				),
+			},
+			{
+				Config:      testAccModChannelConfig("nightly"),
+				ExpectError: regexp.MustCompile("invalid value 'nightly' - must be one of 'stable' or 'beta'"),
+			},
+		},
+	})
+}
+
+func testAccModChannelConfig(channel string) string {
+	return fmt.Sprintf(`
+resource "turbot_mod" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	org = "turbot"
+	mod = "turbot-terraform-provider-test"
+	version = ">=5.0.0"
+	channel = "%s"
+}
+`, channel)
+}
@@ -0,0 +1,276 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/customdiff"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+)
+
+// properties which must be passed to a create/update call
+var policyPackProperties = []interface{}{"title", "description", "parent", "filter"}
+
+func getPolicyPackUpdateProperties() []interface{} {
+	excludedProperties := []string{"parent"}
+	return helpers.RemoveProperties(policyPackProperties, excludedProperties)
+}
+
+// policy packs are the successor to smart folders - a group of policy settings which is attached to resources
+func resourceTurbotPolicyPack() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTurbotPolicyPackCreate,
+		Read:   resourceTurbotPolicyPackRead,
+		Update: resourceTurbotPolicyPackUpdate,
+		Delete: resourceTurbotPolicyPackDelete,
+		Exists: resourceTurbotPolicyPackExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceTurbotPolicyPackImport,
+		},
+		Schema: map[string]*schema.Schema{
+			// aka of the parent resource - if not set, the provider default_parent is used
+			"parent": {
+				Type:     schema.TypeString,
+				Optional: true,
+				// the state holds the id of the parent, which parentCustomizeDiff compares to the configured parent
+				Computed: true,
+			},
+			"title": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"akas": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// number of resources the policy pack is currently attached to
+			"attached_resource_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			// number of policy settings defined in the policy pack
+			"policy_setting_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			// the release number of the policy pack - 1 when it is created, incremented by each change
+			"revision": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			// expose the previous releases of the policy pack in 'versions'
+			"keep_history": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// the most recent releases of the policy pack, most recent first
+			"versions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"revision": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"version_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"timestamp": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"title": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"filter": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"turbot": turbotMetadataSchema(),
+		},
+		CustomizeDiff: customdiff.All(validateParent, parentCustomizeDiff, resourceTurbotPolicyPackCustomizeDiff, turbotMetadataCustomizeDiff),
+	}
+}
+
+// the properties whose change creates a new release of the policy pack
+var policyPackReleaseProperties = []string{"title", "description", "filter"}
+
+func resourceTurbotPolicyPackCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	for _, attribute := range policyPackReleaseProperties {
+		if d.HasChange(attribute) {
+			if err := d.SetNewComputed("revision"); err != nil {
+				return err
+			}
+			return d.SetNewComputed("versions")
+		}
+	}
+	if d.HasChange("keep_history") {
+		return d.SetNewComputed("versions")
+	}
+	return nil
+}
+
+func resourceTurbotPolicyPackExists(d *schema.ResourceData, meta interface{}) (b bool, e error) {
+	client := meta.(*apiClient.Client)
+	id := d.Id()
+	return client.ResourceExists(id)
+}
+
+func resourceTurbotPolicyPackCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	if err := setParent(d, meta); err != nil {
+		return err
+	}
+	// build map of policy pack properties
+	input := mapFromResourceData(d, policyPackProperties)
+
+	policyPack, err := client.CreatePolicyPack(input)
+	if err != nil {
+		return err
+	}
+
+	// assign the id
+	d.SetId(policyPack.Turbot.Id)
+	return resourceTurbotPolicyPackRead(d, meta)
+}
+
+func resourceTurbotPolicyPackUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	id := d.Id()
+
+	// every update creates a new release, so only update the policy pack if a property has changed - not when
+	// keep_history is set
+	if !policyPackHasReleaseChange(d) {
+		return resourceTurbotPolicyPackRead(d, meta)
+	}
+
+	// build map of policy pack properties
+	input := mapFromResourceData(d, getPolicyPackUpdateProperties())
+	input["id"] = id
+
+	_, err := client.UpdatePolicyPack(input)
+	if err != nil {
+		return err
+	}
+	return resourceTurbotPolicyPackRead(d, meta)
+}
+
+func resourceTurbotPolicyPackRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	id := d.Id()
+
+	policyPack, err := client.ReadPolicyPack(id)
+	if err != nil {
+		if apiClient.NotFoundError(err) {
+			// policy pack was not found - remove it from the state
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	// assign results back into ResourceData
+	// NOTE currently turbot accepts array of filters but only uses the first
+	if len(policyPack.Filters) > 0 {
+		d.Set("filter", policyPack.Filters[0])
+	}
+	d.Set("parent", policyPack.Parent)
+	d.Set("title", policyPack.Title)
+	d.Set("description", policyPack.Description)
+	d.Set("akas", policyPack.Turbot.Akas)
+	d.Set("attached_resource_count", policyPack.AttachedResources.Metadata.Stats.Total)
+	d.Set("policy_setting_count", policyPack.PolicySettingCount)
+	d.Set("revision", policyPack.Revision)
+	if len(policyPack.Filters) > 0 && policyPack.AttachedResources.Metadata.Stats.Total == 0 {
+		client.Logf(helpers.LogResources, "[WARN] policy pack %s has filter '%s' but is not attached to any resources", id, policyPack.Filters[0])
+	}
+
+	storeTurbotMetadata(d, policyPack.Turbot)
+	storePolicyPackVersions(d, policyPack)
+	return nil
+}
+
+func resourceTurbotPolicyPackDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	id := d.Id()
+	err := client.DeleteResource(id)
+	if err != nil && !apiClient.NotFoundError(err) {
+		return err
+	}
+
+	// clear the id to show we have deleted
+	d.SetId("")
+
+	return nil
+}
+
+func resourceTurbotPolicyPackImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceTurbotPolicyPackRead(d, meta); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func policyPackHasReleaseChange(d *schema.ResourceData) bool {
+	for _, attribute := range policyPackReleaseProperties {
+		if d.HasChange(attribute) {
+			return true
+		}
+	}
+	return false
+}
+
+// if keep_history is set, store the most recent releases of the policy pack. the releases are the versions of the
+// policy pack in its history, numbered back from the current revision
+func storePolicyPackVersions(d *schema.ResourceData, policyPack *apiClient.PolicyPack) {
+	if !d.Get("keep_history").(bool) {
+		d.Set("versions", nil)
+		return
+	}
+	var result []map[string]interface{}
+	for i, version := range policyPack.Versions {
+		release := map[string]interface{}{
+			"revision":   policyPack.Revision - i,
+			"version_id": version.Turbot.ResourceNewVersionId,
+			"timestamp":  version.Turbot.CreateTimestamp,
+		}
+		// the data of the version holds the properties of the policy pack at the time
+		for _, key := range []string{"title", "description"} {
+			if value, ok := version.Resource.Data[key].(string); ok {
+				release[key] = value
+			}
+		}
+		// NOTE currently turbot accepts array of filters but only uses the first
+		if filters, ok := version.Resource.Data["filters"].([]interface{}); ok && len(filters) > 0 {
+			if filter, ok := filters[0].(string); ok {
+				release["filter"] = filter
+			}
+		}
+		result = append(result, release)
+	}
+	d.Set("versions", result)
+}
@@ -0,0 +1,139 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+)
+
+func resourceTurbotPolicyPackAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTurbotPolicyPackAttachmentCreate,
+		Read:   resourceTurbotPolicyPackAttachmentRead,
+		Delete: resourceTurbotPolicyPackAttachmentDelete,
+		Exists: resourceTurbotPolicyPackAttachmentExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceTurbotPolicyPackAttachmentImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"resource": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressIfAkaMatches("resource_akas"),
+			},
+			"resource_akas": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// the id or aka of the policy pack
+			"policy_pack": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressIfAkaMatches("policy_pack_akas"),
+			},
+			"policy_pack_akas": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceTurbotPolicyPackAttachmentExists(d *schema.ResourceData, meta interface{}) (b bool, e error) {
+	client := meta.(*apiClient.Client)
+	// the id is built from the policy pack id and the resource (see buildId)
+	policyPackId, resource := parseSmartFolderId(d.Id())
+	policyPack, err := client.ReadPolicyPack(policyPackId)
+	if err != nil {
+		// if the policy pack has been deleted, the attachment no longer exists
+		if apiClient.NotFoundError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return policyPack.IsAttached(resource), nil
+}
+
+func resourceTurbotPolicyPackAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	resource := d.Get("resource").(string)
+	policyPackAka := d.Get("policy_pack").(string)
+
+	// the policy pack may be given as an aka - resolve it to the id, which is used in the attachment id
+	policyPack, err := client.ReadResource(policyPackAka, nil)
+	if err != nil {
+		return attributeError("policy_pack", err)
+	}
+	input := map[string]interface{}{
+		"resource":    resource,
+		"policyPacks": []string{policyPack.Turbot.Id},
+	}
+	if _, err := client.CreatePolicyPackAttachment(input); err != nil {
+		return err
+	}
+
+	// assign the id
+	d.SetId(buildId(policyPack.Turbot.Id, resource))
+	return resourceTurbotPolicyPackAttachmentRead(d, meta)
+}
+
+func resourceTurbotPolicyPackAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	// NOTE: This will not be called if the attachment does not exist
+	policyPackId, resource := parseSmartFolderId(d.Id())
+
+	turbotResource, err := client.ReadResource(resource, nil)
+	if err != nil {
+		if apiClient.NotFoundError(err) {
+			// resource was not found - remove the attachment from the state
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	// set resource_akas and policy_pack_akas properties by loading the resources and fetching the akas
+	if err := storeAkas(turbotResource.Turbot.Id, "resource_akas", d, meta); err != nil {
+		return err
+	}
+	if err := storeAkas(policyPackId, "policy_pack_akas", d, meta); err != nil {
+		return err
+	}
+	// assign results directly back into ResourceData
+	d.Set("resource", resource)
+	// keep the configured policy pack aka - when importing there is none, so use the id
+	if d.Get("policy_pack").(string) == "" {
+		d.Set("policy_pack", policyPackId)
+	}
+	return nil
+}
+
+func resourceTurbotPolicyPackAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	policyPackId, resource := parseSmartFolderId(d.Id())
+	input := map[string]interface{}{
+		"resource":    resource,
+		"policyPacks": []string{policyPackId},
+	}
+	err := client.DeletePolicyPackAttachment(input)
+	if err != nil && !apiClient.NotFoundError(err) {
+		return err
+	}
+
+	// clear the id to show we have deleted
+	d.SetId("")
+	return nil
+}
+
+func resourceTurbotPolicyPackAttachmentImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceTurbotPolicyPackAttachmentRead(d, meta); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,107 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"testing"
+)
+
+// test suites
+func TestAccPolicyPackAttachment_Basic(t *testing.T) {
+	resourceName := "turbot_policy_pack_attachment.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPolicyPackAttachmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyPackAttachmentConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicyPackAttachmentExists(resourceName),
+					resource.TestCheckResourceAttrPair(resourceName, "policy_pack", "turbot_policy_pack.test", "akas.0"),
+				),
+			},
+			{
+				// the policy pack is configured using an aka - the plan must be empty
+				Config:             testAccPolicyPackAttachmentConfig(),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// the configured policy pack is an aka, but the imported policy pack is the id
+				ImportStateVerifyIgnore: []string{"policy_pack"},
+			},
+		},
+	})
+}
+
+// configs
+func testAccPolicyPackAttachmentConfig() string {
+	return `
+resource "turbot_folder" "test" {
+  parent = "tmod:@turbot/turbot#/"
+  title = "provider_test"
+  description = "test folder"
+}
+
+resource "turbot_policy_pack" "test" {
+  parent  = "tmod:@turbot/turbot#/"
+  description = "Policy Pack Testing"
+  title = "provider_test_policy_pack"
+}
+
+resource "turbot_policy_pack_attachment" "test" {
+  resource = turbot_folder.test.id
+  policy_pack = turbot_policy_pack.test.akas[0]
+}
+`
+}
+
+// helper functions
+func testAccCheckPolicyPackAttachmentExists(resource string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resource)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+		client := testAccProvider.Meta().(*apiClient.Client)
+		policyPackId, attachedResource := parseSmartFolderId(rs.Primary.ID)
+		policyPack, err := client.ReadPolicyPack(policyPackId)
+		if err != nil {
+			return fmt.Errorf("error fetching item with resource %s. %s", resource, err)
+		}
+		if !policyPack.IsAttached(attachedResource) {
+			return fmt.Errorf("policy pack %s is not attached to %s", policyPackId, attachedResource)
+		}
+		return nil
+	}
+}
+
+func testAccCheckPolicyPackAttachmentDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*apiClient.Client)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "turbot_policy_pack_attachment" {
+			continue
+		}
+		policyPackId, attachedResource := parseSmartFolderId(rs.Primary.ID)
+		policyPack, err := client.ReadPolicyPack(policyPackId)
+		if err != nil {
+			if apiClient.NotFoundError(err) {
+				continue
+			}
+			return err
+		}
+		if policyPack.IsAttached(attachedResource) {
+			return fmt.Errorf("policy pack %s is still attached to %s", policyPackId, attachedResource)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"testing"
+)
+
+// test suites
+func TestAccPolicyPack_Basic(t *testing.T) {
+	resourceName := "turbot_policy_pack.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPolicyPackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyPackConfig("Policy Pack Testing"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicyPackExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "title", "provider_test_policy_pack"),
+					resource.TestCheckResourceAttr(resourceName, "description", "Policy Pack Testing"),
+					resource.TestCheckResourceAttr(resourceName, "filter", "resourceType:181381985925765 $.turbot.tags.a:b"),
+					resource.TestCheckResourceAttrSet(resourceName, "akas.0"),
+					resource.TestCheckResourceAttr(resourceName, "policy_setting_count", "0"),
+					resource.TestCheckResourceAttr(resourceName, "revision", "1"),
+				),
+			},
+			{
+				Config: testAccPolicyPackConfig("Policy Pack updated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicyPackExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "description", "Policy Pack updated"),
+					resource.TestCheckResourceAttr(resourceName, "revision", "2"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"filter"},
+			},
+		},
+	})
+}
+
+func TestAccPolicyPack_History(t *testing.T) {
+	resourceName := "turbot_policy_pack.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPolicyPackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyPackConfig("Policy Pack Testing"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicyPackExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "revision", "1"),
+					resource.TestCheckResourceAttr(resourceName, "versions.#", "0"),
+				),
+			},
+			// setting keep_history does not create a release
+			{
+				Config: testAccPolicyPackHistoryConfig("Policy Pack Testing"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "revision", "1"),
+					resource.TestCheckResourceAttr(resourceName, "versions.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "versions.0.revision", "1"),
+				),
+			},
+			{
+				Config: testAccPolicyPackHistoryConfig("Policy Pack updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "revision", "2"),
+					resource.TestCheckResourceAttr(resourceName, "versions.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "versions.0.revision", "2"),
+					resource.TestCheckResourceAttr(resourceName, "versions.0.description", "Policy Pack updated"),
+					resource.TestCheckResourceAttr(resourceName, "versions.1.revision", "1"),
+					resource.TestCheckResourceAttr(resourceName, "versions.1.description", "Policy Pack Testing"),
+					resource.TestCheckResourceAttrPair(resourceName, "versions.0.version_id", resourceName, "turbot.version_id"),
+				),
+			},
+		},
+	})
+}
+
+// configs
+func testAccPolicyPackConfig(description string) string {
+	return fmt.Sprintf(`
+resource "turbot_policy_pack" "test" {
+	parent      = "tmod:@turbot/turbot#/"
+	filter      = "resourceType:181381985925765 $.turbot.tags.a:b"
+	description = "%s"
+	title       = "provider_test_policy_pack"
+}
+`, description)
+}
+
+func testAccPolicyPackHistoryConfig(description string) string {
+	return fmt.Sprintf(`
+resource "turbot_policy_pack" "test" {
+	parent       = "tmod:@turbot/turbot#/"
+	filter       = "resourceType:181381985925765 $.turbot.tags.a:b"
+	description  = "%s"
+	title        = "provider_test_policy_pack"
+	keep_history = true
+}
+`, description)
+}
+
+// helper functions
+func testAccCheckPolicyPackExists(resource string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("not found: %s", resource)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no Record ID is set")
+		}
+		client := testAccProvider.Meta().(*apiClient.Client)
+		_, err := client.ReadPolicyPack(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error fetching item with resource %s. %s", resource, err)
+		}
+		return nil
+	}
+}
+
+func testAccCheckPolicyPackDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*apiClient.Client)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "turbot_policy_pack" {
+			_, err := client.ReadPolicyPack(rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("policy pack still exists")
+			}
+			if !apiClient.NotFoundError(err) {
+				return fmt.Errorf("expected 'not found' error, got %s", err)
+			}
+		}
+	}
+
+	return nil
+}
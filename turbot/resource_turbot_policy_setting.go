@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
 	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+	"strings"
 )
 
 var policySettingInputProperties = []interface{}{"value", "precedence", "template", "template_input", "note", "valid_from_timestamp", "valid_to_timestamp", "type", "resource"}
@@ -24,6 +25,7 @@ func resourceTurbotPolicySetting() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceTurbotPolicySettingImport,
 		},
+		CustomizeDiff: resourceTurbotPolicySettingCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"type": {
 				Type:     schema.TypeString,
@@ -95,10 +97,75 @@ func resourceTurbotPolicySetting() *schema.Resource {
 				ForceNew: true,
 				Optional: true,
 			},
+			// if true, only a salted hash of value and value_source is stored in the state, and changes are detected by
+			// comparing the hash of the configured value
+			"hash_value": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"pgp_key"},
+			},
+			// if false, the setting is applied in check-only mode: RECOMMENDED precedence and 'Check' rather than 'Enforce'
+			// set to true to escalate to the configured precedence and value
+			"enforce": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			// if enforce is set, the number of controls for the policy in alarm
+			"alarm_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			// if true, when the setting changes the plan includes the number of controls for the policy which will be re-evaluated
+			"preview_affected_controls": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"affected_control_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			// the number of the affected controls which are currently in alarm
+			"affected_alarm_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
 		},
 	}
 }
 
+func resourceTurbotPolicySettingCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("preview_affected_controls").(bool) {
+		return nil
+	}
+	// only preview the effect of a change to the setting
+	if d.Id() != "" && !d.HasChange("value") && !d.HasChange("precedence") && !d.HasChange("template") && !d.HasChange("template_input") && !d.HasChange("enforce") {
+		return nil
+	}
+	// the resource may not exist yet, e.g. if it is created in the same apply
+	if !d.NewValueKnown("resource") || !d.NewValueKnown("type") {
+		return nil
+	}
+	client := meta.(*apiClient.Client)
+	policyTypeUri, resourceAka := d.Get("type").(string), d.Get("resource").(string)
+
+	// Turbot cannot predict the new state of each control, so count the controls which will be re-evaluated -
+	// this is the most which may change state
+	filter := policyControlFilter(policyTypeUri, resourceAka)
+	count, err := client.ReadControlCount(filter)
+	if err != nil {
+		return err
+	}
+	alarmCount, err := client.ReadControlCount(filter + " state:alarm")
+	if err != nil {
+		return err
+	}
+	if err := d.SetNew("affected_control_count", count); err != nil {
+		return err
+	}
+	return d.SetNew("affected_alarm_count", alarmCount)
+}
+
 func resourceTurbotPolicySettingExists(d *schema.ResourceData, meta interface{}) (b bool, e error) {
 	// Exists - This is called to verify a resource still exists. It is called prior to Read,
 	// and lowers the burden of Read to be able to assume the resource exists.
@@ -138,6 +205,8 @@ func resourceTurbotPolicySettingCreate(d *schema.ResourceData, meta interface{})
 	// 1) pass value as 'value'
 	// 2) pass value as 'valueSource'. update d.value to be the yaml parsed version of 'value'
 	input := mapFromResourceData(d, policySettingInputProperties)
+	configuredValue, configuredPrecedence := d.Get("value").(string), d.Get("precedence").(string)
+	applyCheckOnlyRollout(d, input)
 
 	if value, ok := d.GetOk("template_input"); ok {
 		// NOTE: ParseYamlString doesn't validate input as valid YAML format, on error it returns value
@@ -158,13 +227,17 @@ func resourceTurbotPolicySettingCreate(d *schema.ResourceData, meta interface{})
 		policySetting, err = client.CreatePolicySetting(input)
 		if err != nil {
 			d.SetId("")
-			return err
+			return apiValidationError("value", err)
 		}
 		// update state value setting with yaml parsed valueSource
 		setValueFromValueSource(input["valueSource"].(string), d)
 	}
 	// if pgp_key has been supplied, encrypt value and value_source
-	storeValue(d, policySetting)
+	if err := storeValue(d, policySetting); err != nil {
+		// the setting has been created - keep it in the state, so it is not orphaned
+		d.SetId(policySetting.Turbot.Id)
+		return err
+	}
 	// set akas properties by loading resource and fetching the akas
 	if err := storeAkas(resourceAka, "resource_akas", d, meta); err != nil {
 		return err
@@ -188,7 +261,7 @@ func resourceTurbotPolicySettingCreate(d *schema.ResourceData, meta interface{})
 	// assign the id
 	d.SetId(policySetting.Turbot.Id)
 
-	return nil
+	return storeCheckOnlyRollout(d, resourceAka, configuredValue, configuredPrecedence, meta)
 }
 
 func resourceTurbotPolicySettingRead(d *schema.ResourceData, meta interface{}) error {
@@ -198,8 +271,9 @@ func resourceTurbotPolicySettingRead(d *schema.ResourceData, meta interface{}) e
 	policySetting, err := client.ReadPolicySetting(id)
 	if err != nil {
 		if apiClient.NotFoundError(err) {
-			// setting was not found - clear id
+			// setting was not found - remove it from the state
 			d.SetId("")
+			return nil
 		}
 		return err
 	}
@@ -217,9 +291,12 @@ func resourceTurbotPolicySettingRead(d *schema.ResourceData, meta interface{}) e
 	if err != nil {
 		return err
 	}
+	configuredValue, configuredPrecedence := d.Get("value").(string), d.Get("precedence").(string)
 	// assign results back into ResourceData
 	// if pgp_key has been supplied, encrypt value and value_source
-	storeValue(d, policySetting)
+	if err := storeValue(d, policySetting); err != nil {
+		return err
+	}
 	d.Set("precedence", policySetting.Precedence)
 	d.Set("template", policySetting.Template)
 	d.Set("template_input", templateInput)
@@ -227,7 +304,11 @@ func resourceTurbotPolicySettingRead(d *schema.ResourceData, meta interface{}) e
 	d.Set("valid_from_timestamp", policySetting.ValidFromTimestamp)
 	d.Set("valid_to_timestamp", policySetting.ValidToTimestamp)
 	d.Set("type", policySetting.Type.Uri)
-	return nil
+	// when importing there is no configured resource, so use the id
+	if d.Get("resource").(string) == "" {
+		d.Set("resource", policySetting.Turbot.ResourceId)
+	}
+	return storeCheckOnlyRollout(d, policySetting.Turbot.ResourceId, configuredValue, configuredPrecedence, meta)
 }
 
 func resourceTurbotPolicySettingUpdate(d *schema.ResourceData, meta interface{}) error {
@@ -243,6 +324,8 @@ func resourceTurbotPolicySettingUpdate(d *schema.ResourceData, meta interface{})
 	// 2) pass value as 'valueSource'. update d.value to be the yaml parsed version of 'value'
 	input := mapFromResourceData(d, getPolicySettingUpdateProperties())
 	input["id"] = id
+	configuredValue, configuredPrecedence := d.Get("value").(string), d.Get("precedence").(string)
+	applyCheckOnlyRollout(d, input)
 
 	var err error
 	if value, ok := d.GetOk("template_input"); ok {
@@ -254,7 +337,10 @@ func resourceTurbotPolicySettingUpdate(d *schema.ResourceData, meta interface{})
 	policySetting, err := client.UpdatePolicySetting(input)
 	if err != nil {
 		if !apiClient.FailedValidationError(err) {
-			d.SetId("")
+			// only remove the setting from the state if it no longer exists - any other failure leaves it unchanged
+			if apiClient.NotFoundError(err) {
+				d.SetId("")
+			}
 			return err
 		}
 		// so we have a data validation error - try using value as valueSource
@@ -263,12 +349,16 @@ func resourceTurbotPolicySettingUpdate(d *schema.ResourceData, meta interface{})
 		// try again
 		policySetting, err = client.UpdatePolicySetting(input)
 		if err != nil {
-			d.SetId("")
-			return err
+			return apiValidationError("value", err)
 		}
 		// update state value setting with yaml parsed valueSource
 		setValueFromValueSource(input["valueSource"].(string), d)
 	}
+	if d.Get("hash_value").(bool) {
+		if err := storeValue(d, policySetting); err != nil {
+			return err
+		}
+	}
 
 	// NOTE: TemplateInput can be string or array of strings
 	// - In case of string, we return string
@@ -286,7 +376,7 @@ func resourceTurbotPolicySettingUpdate(d *schema.ResourceData, meta interface{})
 	d.Set("valid_from_timestamp", policySetting.ValidFromTimestamp)
 	d.Set("valid_to_timestamp", policySetting.ValidToTimestamp)
 	d.Set("type", policySetting.Type.Uri)
-	return nil
+	return storeCheckOnlyRollout(d, policySetting.Turbot.ResourceId, configuredValue, configuredPrecedence, meta)
 }
 
 func setValueFromValueSource(valueSource string, d *schema.ResourceData) {
@@ -301,7 +391,7 @@ func resourceTurbotPolicySettingDelete(d *schema.ResourceData, meta interface{})
 	client := meta.(*apiClient.Client)
 	id := d.Id()
 	err := client.DeletePolicySetting(id)
-	if err != nil {
+	if err != nil && !apiClient.NotFoundError(err) {
 		return err
 	}
 
@@ -345,17 +435,37 @@ func suppressIfEncryptedOrValueSourceMatches(_, old, new string, d *schema.Resou
 		return false
 	}
 
-	_, keyPresent := d.GetOk("pgp_key")
-
 	// Return true if the diff should be suppressed, false to retain it.
+	if _, keyPresent := d.GetOk("pgp_key"); keyPresent {
+		return true
+	}
+	if d.Get("hash_value").(bool) {
+		if helpers.HashedValueMatches(old, new) {
+			return true
+		}
+		return d.Get("value_source_used").(bool) && helpers.HashedValueMatches(d.Get("value_source").(string), canonicalYaml(new))
+	}
 	if d.Get("value_source_used").(bool) {
-		old = d.Get("value_source").(string)
+		// the value source is yaml, so compare the parsed values, ignoring formatting differences
+		valueSource := d.Get("value_source").(string)
+		if new == valueSource {
+			return true
+		}
+		equivalent, err := helpers.YamlStringsAreEqual(valueSource, new)
+		return err == nil && equivalent
 	}
-	return keyPresent || new == old
+	return new == old
 }
 
-// write value and value_source to ResourceData, encrypting if a pgp key was provided
-func storeValue(d *schema.ResourceData, setting *apiClient.PolicySetting) error {
+// write value and value_source to ResourceData, encrypting if a pgp key was provided, or hashing if hash_value is set
+func storeValue(d *schema.ResourceData, setting *apiClient.PolicySetting) (err error) {
+	// fail closed - if the value cannot be encrypted or hashed, the configured plaintext value must not be left in the state
+	defer func() {
+		if err != nil {
+			d.Set("value", "")
+			d.Set("value_source", "")
+		}
+	}()
 	// NOTE: turbot policy settings have a value and a valueSource property
 	// - value is the type property value, with the type dependent on the policy schema
 	// - valueSource is the yaml representation of the policy.
@@ -376,6 +486,27 @@ func storeValue(d *schema.ResourceData, setting *apiClient.PolicySetting) error
 		}
 		d.Set("value_source", encryptedValueSource)
 		d.Set("value_source_key_fingerprint", valueSourceFingerprint)
+	} else if d.Get("hash_value").(bool) {
+		value, current := helpers.InterfaceToString(setting.Value), d.Get("value").(string)
+		// in check-only mode the 'Check' equivalent of an 'Enforce' value is applied - as only the hash of the
+		// configured value is stored, storeCheckOnlyRollout cannot restore it, so restore it before hashing
+		if policySettingCheckOnly(d) && strings.HasPrefix(value, "Check:") {
+			enforceValue := "Enforce:" + strings.TrimPrefix(value, "Check:")
+			if current == enforceValue || helpers.HashedValueMatches(current, enforceValue) {
+				value = enforceValue
+			}
+		}
+		hashedValue, err := hashStateValue(value, current)
+		if err != nil {
+			return err
+		}
+		// the value source is yaml, so hash its canonical form, allowing formatting differences in the configured value
+		hashedValueSource, err := hashStateValue(canonicalYaml(setting.ValueSource), d.Get("value_source").(string))
+		if err != nil {
+			return err
+		}
+		d.Set("value", hashedValue)
+		d.Set("value_source", hashedValueSource)
 	} else {
 		d.Set("value", helpers.InterfaceToString(setting.Value))
 		d.Set("value_source", setting.ValueSource)
@@ -384,6 +515,32 @@ func storeValue(d *schema.ResourceData, setting *apiClient.PolicySetting) error
 	return nil
 }
 
+// hash a value to be stored in the state, reusing the salt of the hash currently in the state (if any),
+// so the hash of an unchanged value does not change
+func hashStateValue(value, current string) (string, error) {
+	salt, ok := helpers.HashSalt(current)
+	if !ok {
+		var err error
+		if salt, err = helpers.NewHashSalt(); err != nil {
+			return "", err
+		}
+	}
+	return helpers.HashValue(value, salt), nil
+}
+
+// re-marshal a yaml string, so strings which only differ by formatting are equal. invalid yaml is returned unchanged
+func canonicalYaml(value string) string {
+	var i interface{}
+	if err := yaml.Unmarshal([]byte(value), &i); err != nil {
+		return value
+	}
+	canonical, err := yaml.Marshal(i)
+	if err != nil {
+		return value
+	}
+	return string(canonical)
+}
+
 func suppressIfTemplateInputEquivalent(k, old, new string, d *schema.ResourceData) bool {
 	if old == "" {
 		return false
@@ -395,3 +552,59 @@ func suppressIfTemplateInputEquivalent(k, old, new string, d *schema.ResourceDat
 
 	return equivalent
 }
+
+// is the setting being rolled out in check-only mode, i.e. is 'enforce' set to false
+func policySettingCheckOnly(d *schema.ResourceData) bool {
+	enforce, ok := d.GetOkExists("enforce")
+	return ok && !enforce.(bool)
+}
+
+// in check-only mode, apply the setting as RECOMMENDED, replacing an 'Enforce' value with the equivalent 'Check' value
+func applyCheckOnlyRollout(d *schema.ResourceData, input map[string]interface{}) {
+	if !policySettingCheckOnly(d) {
+		return
+	}
+	input["precedence"] = "RECOMMENDED"
+	if value, ok := input["value"].(string); ok {
+		input["value"] = checkOnlyValue(value)
+	}
+}
+
+// convert a guardrail value such as 'Enforce: Enabled' into 'Check: Enabled'
+func checkOnlyValue(value string) string {
+	if strings.HasPrefix(value, "Enforce:") {
+		return "Check:" + strings.TrimPrefix(value, "Enforce:")
+	}
+	return value
+}
+
+// in check-only mode, keep the configured value and precedence in the state if the setting only differs because of
+// the rollout mode (so no diff is raised), and if 'enforce' is set, store the number of controls for the policy in alarm
+func storeCheckOnlyRollout(d *schema.ResourceData, resourceAka, configuredValue, configuredPrecedence string, meta interface{}) error {
+	if _, ok := d.GetOkExists("enforce"); !ok {
+		return nil
+	}
+	if policySettingCheckOnly(d) {
+		if _, encrypted := d.GetOk("pgp_key"); !encrypted && d.Get("value").(string) == checkOnlyValue(configuredValue) {
+			d.Set("value", configuredValue)
+		}
+		if d.Get("precedence").(string) == "RECOMMENDED" {
+			d.Set("precedence", configuredPrecedence)
+		}
+	}
+
+	client := meta.(*apiClient.Client)
+	alarmCount, err := client.ReadControlCount(policyControlFilter(d.Get("type").(string), resourceAka) + " state:alarm")
+	if err != nil {
+		return err
+	}
+	d.Set("alarm_count", alarmCount)
+	return nil
+}
+
+// filter for the guardrail controls for a policy on the resource and its descendants
+// by convention, the guardrail control for a policy has the same name as the policy type
+func policyControlFilter(policyTypeUri, resourceAka string) string {
+	controlTypeUri := strings.Replace(policyTypeUri, "#/policy/types/", "#/control/types/", 1)
+	return fmt.Sprintf("controlTypeId:'%s' resourceId:'%s' level:self,descendant", controlTypeUri, resourceAka)
+}
@@ -0,0 +1,501 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+	"sort"
+	"strings"
+)
+
+// properties which are passed to the create/update call for each policy setting
+var policySettingFanOutInputProperties = []interface{}{"value", "precedence", "template", "template_input", "note", "type"}
+
+func getPolicySettingFanOutUpdateProperties() []interface{} {
+	excludedProperties := []string{"type"}
+	return helpers.RemoveProperties(policySettingFanOutInputProperties, excludedProperties)
+}
+
+// a single policy setting definition applied to every resource matching a filter
+// the id of the setting created for each resource is stored in the 'settings' map (resource id -> setting id)
+func resourceTurbotPolicySettingFanOut() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTurbotPolicySettingFanOutCreate,
+		Read:   resourceTurbotPolicySettingFanOutRead,
+		Update: resourceTurbotPolicySettingFanOutUpdate,
+		Delete: resourceTurbotPolicySettingFanOutDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceTurbotPolicySettingFanOutImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// resources matching this filter will have the setting applied
+			"filter": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"value": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// map of resource aka -> value, for resources which need a different value to the other matching resources
+			// a matching resource uses the value of the nearest aka which is the resource or one of its ancestors
+			"value_by_aka": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				ConflictsWith: []string{"template", "template_input"},
+			},
+			"precedence": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "REQUIRED",
+			},
+			"template": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"template_input": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressIfTemplateInputEquivalent,
+			},
+			"note": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// map of resource id -> policy setting id
+			"settings": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		CustomizeDiff: resourceTurbotPolicySettingFanOutCustomizeDiff,
+	}
+}
+
+// if the resources matching the filter have changed since the settings were created, mark the settings as changing
+// so that Update creates settings for the new resources and deletes the settings of resources which no longer match
+func resourceTurbotPolicySettingFanOutCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || d.HasChange("filter") || d.HasChange("value_by_aka") || !d.NewValueKnown("filter") || !d.NewValueKnown("value_by_aka") {
+		return nil
+	}
+	client := meta.(*apiClient.Client)
+	targets, err := getFanOutTargets(d, client)
+	if err != nil {
+		return err
+	}
+	settings := d.Get("settings").(map[string]interface{})
+	if len(targets) != len(settings) {
+		return d.SetNewComputed("settings")
+	}
+	for resourceId := range targets {
+		if _, ok := settings[resourceId]; !ok {
+			return d.SetNewComputed("settings")
+		}
+	}
+	return nil
+}
+
+func resourceTurbotPolicySettingFanOutCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	filter := d.Get("filter").(string)
+
+	targets, err := getFanOutTargets(d, client)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		if _, ok := d.GetOk("value_by_aka"); ok {
+			return fmt.Errorf("no resources match filter '%s' with a value - set value, or add an aka of a matching resource to value_by_aka", filter)
+		}
+		return fmt.Errorf("no resources match filter '%s'", filter)
+	}
+
+	settings := map[string]interface{}{}
+	// the settings themselves are tracked in the 'settings' map - the filter may change, so is not part of the id
+	d.SetId(newPolicySettingFanOutId(d.Get("type").(string)))
+	err = createFanOutPolicySettings(sortedFanOutResourceIds(targets), targets, settings, d, client)
+	// store the settings which were created, even if some failed, so they can be deleted later
+	d.Set("settings", settings)
+	return err
+}
+
+func resourceTurbotPolicySettingFanOutRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+
+	// with value_by_aka, the value of each setting depends on which aka the resource is nearest to
+	var targets map[string]string
+	if len(d.Get("value_by_aka").(map[string]interface{})) > 0 {
+		var err error
+		if targets, err = getFanOutTargets(d, client); err != nil {
+			return err
+		}
+	}
+	definition := newFanOutDefinition(d)
+	currentSettings := d.Get("settings").(map[string]interface{})
+	settings := map[string]interface{}{}
+	for _, resourceId := range sortedFanOutSettingResourceIds(currentSettings) {
+		settingId := currentSettings[resourceId].(string)
+		policySetting, err := client.ReadPolicySetting(settingId)
+		if err != nil {
+			if apiClient.NotFoundError(err) {
+				// the setting has been deleted outside of terraform - it will be recreated on the next apply
+				client.Logf(helpers.LogResources, "[WARN] policy setting %s for resource %s not found", settingId, resourceId)
+				continue
+			}
+			return err
+		}
+		settings[resourceId] = settingId
+		// the setting of a resource which no longer matches is deleted on the next apply, so its value is not checked
+		valueAka, matches := targets[resourceId]
+		definition.update(policySetting, valueAka, targets == nil || matches)
+	}
+	d.Set("settings", settings)
+	definition.store(d)
+	return nil
+}
+
+func resourceTurbotPolicySettingFanOutUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+
+	targets, err := getFanOutTargets(d, client)
+	if err != nil {
+		return err
+	}
+	resourceIds := sortedFanOutResourceIds(targets)
+	oldSettings, _ := d.GetChange("settings")
+	settings := map[string]interface{}{}
+	for resourceId, settingId := range oldSettings.(map[string]interface{}) {
+		settings[resourceId] = settingId
+	}
+	// always store the settings map, so partial failures are reflected in the state
+	defer func() { d.Set("settings", settings) }()
+
+	// delete the settings for resources which no longer match the filter
+	for resourceId, settingId := range settings {
+		if _, ok := targets[resourceId]; ok {
+			continue
+		}
+		if err := client.DeletePolicySetting(settingId.(string)); err != nil && !apiClient.NotFoundError(err) {
+			return err
+		}
+		delete(settings, resourceId)
+	}
+
+	// update the existing settings if the setting definition has changed
+	// (a change to value_by_aka may change the value of any resource, so all settings are updated)
+	if d.HasChange("value") || d.HasChange("value_by_aka") || d.HasChange("precedence") || d.HasChange("template") || d.HasChange("template_input") || d.HasChange("note") {
+		for _, resourceId := range resourceIds {
+			settingId, ok := settings[resourceId]
+			if !ok {
+				continue
+			}
+			input, err := buildFanOutPolicySettingInput(d, getPolicySettingFanOutUpdateProperties(), targets[resourceId])
+			if err != nil {
+				return err
+			}
+			input["id"] = settingId
+			if _, err := applyFanOutPolicySetting(input, client.UpdatePolicySetting); err != nil {
+				return fmt.Errorf("error updating policy setting %s for resource %s: %w", settingId, resourceId, err)
+			}
+		}
+	}
+
+	// create settings for any new resources
+	var newResourceIds []string
+	for _, resourceId := range resourceIds {
+		if _, ok := settings[resourceId]; !ok {
+			newResourceIds = append(newResourceIds, resourceId)
+		}
+	}
+	return createFanOutPolicySettings(newResourceIds, targets, settings, d, client)
+}
+
+func resourceTurbotPolicySettingFanOutDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	settings := d.Get("settings").(map[string]interface{})
+	for resourceId, settingId := range settings {
+		if err := client.DeletePolicySetting(settingId.(string)); err != nil && !apiClient.NotFoundError(err) {
+			d.Set("settings", settings)
+			return err
+		}
+		delete(settings, resourceId)
+	}
+
+	// clear the id to show we have deleted
+	d.SetId("")
+
+	return nil
+}
+
+// import a fan-out from the existing settings of the policy type on the resources matching the filter
+// the import id is policyType|filter
+func resourceTurbotPolicySettingFanOutImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	segments := strings.SplitN(d.Id(), "|", 2)
+	if len(segments) != 2 || segments[0] == "" || segments[1] == "" {
+		return nil, fmt.Errorf("invalid policy setting fan-out import id '%s' - expected policyType|filter", d.Id())
+	}
+	policyTypeUri, filter := segments[0], segments[1]
+	client := meta.(*apiClient.Client)
+	resources, err := client.ReadResourceList(filter, nil)
+	if err != nil {
+		return nil, err
+	}
+	policySettings, err := client.ReadPolicySettingList(fmt.Sprintf("policyTypeId:'%s'", policyTypeUri))
+	if err != nil {
+		return nil, err
+	}
+	settingsByResource := map[string]apiClient.PolicySetting{}
+	for _, policySetting := range policySettings {
+		settingsByResource[policySetting.Turbot.ResourceId] = policySetting
+	}
+	settings := map[string]interface{}{}
+	for _, matchingResource := range resources {
+		if policySetting, ok := settingsByResource[matchingResource.Turbot.Id]; ok {
+			settings[matchingResource.Turbot.Id] = policySetting.Turbot.Id
+		}
+	}
+	if len(settings) == 0 {
+		return nil, fmt.Errorf("no resources matching filter '%s' have a setting of policy type %s", filter, policyTypeUri)
+	}
+
+	// the setting definition is taken from the setting of the first resource - if the other settings differ, the
+	// read which follows the import shows the difference
+	first := settingsByResource[sortedFanOutSettingResourceIds(settings)[0]]
+	templateInput, err := helpers.InterfaceToStringOrYaml(first.TemplateInput)
+	if err != nil {
+		return nil, err
+	}
+	definition := &fanOutDefinition{}
+	definition.update(&first, "", true)
+
+	d.SetId(newPolicySettingFanOutId(policyTypeUri))
+	d.Set("type", policyTypeUri)
+	d.Set("filter", filter)
+	d.Set("settings", settings)
+	d.Set("template_input", templateInput)
+	d.Set("note", first.Note)
+	definition.store(d)
+	return []*schema.ResourceData{d}, nil
+}
+
+func newPolicySettingFanOutId(policyTypeUri string) string {
+	return resource.PrefixedUniqueId(policyTypeUri + "_")
+}
+
+// return the resources matching the filter which a setting is applied to, mapped to the aka in value_by_aka which
+// gives the value of the setting, or "" if the setting uses the value (or template) of the fan-out
+// a resource uses the value of the nearest aka in value_by_aka which is the resource or one of its ancestors -
+// if there is none and the fan-out has no value or template, no setting is applied to the resource
+func getFanOutTargets(d resourceAttributeGetter, client *apiClient.Client) (map[string]string, error) {
+	resources, err := client.ReadResourceList(d.Get("filter").(string), nil)
+	if err != nil {
+		return nil, err
+	}
+	// resolve the akas to ids
+	resolver := newAkaResolver(client)
+	akasById := map[string]string{}
+	for aka := range d.Get("value_by_aka").(map[string]interface{}) {
+		id, err := resolver.resolve(aka)
+		if err != nil {
+			return nil, attributeError("value_by_aka", err)
+		}
+		if existing, ok := akasById[id]; ok {
+			return nil, attributeError("value_by_aka", fmt.Errorf("'%s' and '%s' are akas of the same resource", existing, aka))
+		}
+		akasById[id] = aka
+	}
+	_, hasValue := d.GetOk("value")
+	_, hasTemplate := d.GetOk("template")
+	return matchFanOutTargets(resources, akasById, hasValue || hasTemplate), nil
+}
+
+// map each resource to the nearest aka in akasById (resource id -> aka) which is the resource or one of its ancestors
+// if there is none, the resource is mapped to "" if hasDefault is set (the fan-out has a value or template),
+// otherwise it is not a target
+func matchFanOutTargets(resources []apiClient.Resource, akasById map[string]string, hasDefault bool) map[string]string {
+	targets := map[string]string{}
+	for _, resource := range resources {
+		if aka, ok := nearestFanOutAka(resource.Turbot, akasById); ok {
+			targets[resource.Turbot.Id] = aka
+		} else if hasDefault {
+			targets[resource.Turbot.Id] = ""
+		}
+	}
+	return targets
+}
+
+// find the aka of the resource, or its nearest ancestor, in the map of resource id -> aka
+// the path of a resource is the ids of its ancestors and itself, separated by '.'
+func nearestFanOutAka(turbot apiClient.TurbotResourceMetadata, akasById map[string]string) (string, bool) {
+	if aka, ok := akasById[turbot.Id]; ok {
+		return aka, true
+	}
+	path := strings.Split(turbot.Path, ".")
+	for i := len(path) - 1; i >= 0; i-- {
+		if aka, ok := akasById[path[i]]; ok {
+			return aka, true
+		}
+	}
+	return "", false
+}
+
+// the setting definition of a fan-out, as read back from its settings
+// a setting which differs from the definition replaces the value which differs, so the drift is shown in the plan
+// and the next apply updates the settings to the configured definition
+type fanOutDefinition struct {
+	value      string
+	valueByAka map[string]interface{}
+	precedence string
+	template   string
+}
+
+func newFanOutDefinition(d *schema.ResourceData) *fanOutDefinition {
+	valueByAka := map[string]interface{}{}
+	for aka, value := range d.Get("value_by_aka").(map[string]interface{}) {
+		valueByAka[aka] = value
+	}
+	return &fanOutDefinition{
+		value:      d.Get("value").(string),
+		valueByAka: valueByAka,
+		precedence: d.Get("precedence").(string),
+		template:   d.Get("template").(string),
+	}
+}
+
+// compare the setting of a resource with the definition, replacing any values which differ
+// valueAka is the aka in value_by_aka which gives the value of the setting, or "" if the setting uses the value.
+// if checkValue is false, only the precedence and template are compared
+func (f *fanOutDefinition) update(setting *apiClient.PolicySetting, valueAka string, checkValue bool) {
+	if setting.Precedence != f.precedence {
+		f.precedence = setting.Precedence
+	}
+	if setting.Template != f.template {
+		f.template = setting.Template
+	}
+	// the value of a templated setting is calculated by the template
+	if !checkValue || setting.Template != "" {
+		return
+	}
+	if valueAka != "" {
+		if value, _ := f.valueByAka[valueAka].(string); !fanOutValueMatches(setting, value) {
+			f.valueByAka[valueAka] = fanOutSettingValue(setting)
+		}
+		return
+	}
+	if !fanOutValueMatches(setting, f.value) {
+		f.value = fanOutSettingValue(setting)
+	}
+}
+
+func (f *fanOutDefinition) store(d *schema.ResourceData) {
+	d.Set("value", f.value)
+	d.Set("value_by_aka", f.valueByAka)
+	d.Set("precedence", f.precedence)
+	d.Set("template", f.template)
+}
+
+// the value of a setting, as it is written in the config - structured values are returned as YAML
+func fanOutSettingValue(setting *apiClient.PolicySetting) string {
+	value, err := helpers.InterfaceToScalarStringOrYaml(setting.Value)
+	if err != nil {
+		return setting.ValueSource
+	}
+	return value
+}
+
+// does the setting have the configured value - the value may be configured as YAML, so the parsed values are compared
+func fanOutValueMatches(setting *apiClient.PolicySetting, value string) bool {
+	settingValue := fanOutSettingValue(setting)
+	if value == settingValue || value == setting.ValueSource {
+		return true
+	}
+	for _, yamlValue := range []string{settingValue, setting.ValueSource} {
+		if yamlValue == "" {
+			continue
+		}
+		if equivalent, err := helpers.YamlStringsAreEqual(yamlValue, value); err == nil && equivalent {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedFanOutSettingResourceIds(settings map[string]interface{}) []string {
+	var resourceIds []string
+	for resourceId := range settings {
+		resourceIds = append(resourceIds, resourceId)
+	}
+	sort.Strings(resourceIds)
+	return resourceIds
+}
+
+func sortedFanOutResourceIds(targets map[string]string) []string {
+	var resourceIds []string
+	for resourceId := range targets {
+		resourceIds = append(resourceIds, resourceId)
+	}
+	sort.Strings(resourceIds)
+	return resourceIds
+}
+
+// create a policy setting for each resource, adding the setting ids to the settings map
+func createFanOutPolicySettings(resourceIds []string, targets map[string]string, settings map[string]interface{}, d *schema.ResourceData, client *apiClient.Client) error {
+	policyTypeUri := d.Get("type").(string)
+	for _, resourceId := range resourceIds {
+		input, err := buildFanOutPolicySettingInput(d, policySettingFanOutInputProperties, targets[resourceId])
+		if err != nil {
+			return err
+		}
+		input["resource"] = resourceId
+		policySetting, err := applyFanOutPolicySetting(input, client.CreatePolicySetting)
+		if err != nil {
+			return fmt.Errorf("error creating policy setting %s for resource %s: %w", policyTypeUri, resourceId, err)
+		}
+		settings[resourceId] = policySetting.Turbot.Id
+	}
+	return nil
+}
+
+// build the input for the setting of a resource - if valueAka is set, the value is taken from value_by_aka
+func buildFanOutPolicySettingInput(d *schema.ResourceData, properties []interface{}, valueAka string) (map[string]interface{}, error) {
+	var err error
+	input := mapFromResourceData(d, properties)
+	if valueAka != "" {
+		input["value"] = d.Get("value_by_aka").(map[string]interface{})[valueAka]
+	}
+	if value, ok := d.GetOk("template_input"); ok {
+		// NOTE: ParseYamlString doesn't validate input as valid YAML format, on error it returns value
+		valueString := fmt.Sprintf("%v", value)
+		input["templateInput"], err = helpers.ParseYamlString(valueString)
+	}
+	return input, err
+}
+
+// call the create/update function, first passing the value as 'value' and if that fails validation, as 'valueSource'
+// (see resourceTurbotPolicySettingCreate)
+func applyFanOutPolicySetting(input map[string]interface{}, apply func(map[string]interface{}) (*apiClient.PolicySetting, error)) (*apiClient.PolicySetting, error) {
+	policySetting, err := apply(input)
+	if err == nil || !apiClient.FailedValidationError(err) {
+		return policySetting, err
+	}
+	if _, ok := input["value"]; !ok {
+		return nil, err
+	}
+	input["valueSource"] = input["value"]
+	delete(input, "value")
+	policySetting, err = apply(input)
+	return policySetting, apiValidationError("value", err)
+}
@@ -0,0 +1,350 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"strings"
+	"testing"
+)
+
+// test suites
+func TestAccPolicySettingFanOut_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPolicySettingFanOutDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicySettingFanOutConfig("testValue"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicySettingFanOutExists("turbot_policy_setting_fan_out.test"),
+					resource.TestCheckResourceAttr(
+						"turbot_policy_setting_fan_out.test", "value", "testValue"),
+					resource.TestCheckResourceAttr(
+						"turbot_policy_setting_fan_out.test", "settings.%", "2"),
+				),
+			},
+			{
+				Config: testAccPolicySettingFanOutConfig("testValue-updated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicySettingFanOutExists("turbot_policy_setting_fan_out.test"),
+					resource.TestCheckResourceAttr(
+						"turbot_policy_setting_fan_out.test", "value", "testValue-updated"),
+					resource.TestCheckResourceAttr(
+						"turbot_policy_setting_fan_out.test", "settings.%", "2"),
+				),
+			},
+			{
+				ResourceName:      "turbot_policy_setting_fan_out.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccPolicySettingFanOutImportId("turbot_policy_setting_fan_out.test"),
+				// the id is generated on import, so the state cannot be verified by id
+				ImportStateCheck: testAccCheckPolicySettingFanOutImported(stringPolicyType, 2),
+			},
+		},
+	})
+}
+
+func TestAccPolicySettingFanOut_ValueByAka(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPolicySettingFanOutDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicySettingFanOutValueByAkaConfig(`value = "testValue"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicySettingFanOutExists("turbot_policy_setting_fan_out.test"),
+					resource.TestCheckResourceAttr(
+						"turbot_policy_setting_fan_out.test", "settings.%", "2"),
+					testAccCheckPolicySettingFanOutValue("turbot_policy_setting_fan_out.test", "turbot_folder.child_1", "child1Value"),
+					testAccCheckPolicySettingFanOutValue("turbot_policy_setting_fan_out.test", "turbot_folder.child_2", "testValue"),
+				),
+			},
+			{
+				// without a value, only the resources in value_by_aka have a setting
+				Config: testAccPolicySettingFanOutValueByAkaConfig(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"turbot_policy_setting_fan_out.test", "settings.%", "1"),
+					testAccCheckPolicySettingFanOutValue("turbot_policy_setting_fan_out.test", "turbot_folder.child_1", "child1Value"),
+				),
+			},
+		},
+	})
+}
+
+func TestNearestFanOutAka(t *testing.T) {
+	// root 1 > folder 2 > folder 3 > account 4
+	account := apiClient.TurbotResourceMetadata{Id: "4", Path: "1.2.3.4"}
+	type test struct {
+		name        string
+		turbot      apiClient.TurbotResourceMetadata
+		akasById    map[string]string
+		expectedAka string
+		expectedOk  bool
+	}
+	tests := []test{
+		{"no akas", account, map[string]string{}, "", false},
+		{"the resource", account, map[string]string{"4": "arn:aws:::123456789012"}, "arn:aws:::123456789012", true},
+		{"parent", account, map[string]string{"3": "folder_3"}, "folder_3", true},
+		{"ancestor", account, map[string]string{"1": "tmod:@turbot/turbot#/"}, "tmod:@turbot/turbot#/", true},
+		{"nearest ancestor", account, map[string]string{"1": "tmod:@turbot/turbot#/", "2": "folder_2"}, "folder_2", true},
+		{"the resource before its ancestors", account, map[string]string{"2": "folder_2", "4": "arn:aws:::123456789012"}, "arn:aws:::123456789012", true},
+		{"unrelated resource", account, map[string]string{"5": "folder_5"}, "", false},
+		{"empty id", account, map[string]string{"": "empty"}, "", false},
+		{"resource without a path", apiClient.TurbotResourceMetadata{Id: "4"}, map[string]string{"3": "folder_3"}, "", false},
+	}
+	for _, test := range tests {
+		aka, ok := nearestFanOutAka(test.turbot, test.akasById)
+		assert.Equal(t, test.expectedAka, aka, test.name)
+		assert.Equal(t, test.expectedOk, ok, test.name)
+	}
+}
+
+func TestMatchFanOutTargets(t *testing.T) {
+	// folder 2 contains accounts 4 and 5, folder 3 contains account 6
+	resources := []apiClient.Resource{
+		{Turbot: apiClient.TurbotResourceMetadata{Id: "4", Path: "1.2.4"}},
+		{Turbot: apiClient.TurbotResourceMetadata{Id: "5", Path: "1.2.5"}},
+		{Turbot: apiClient.TurbotResourceMetadata{Id: "6", Path: "1.3.6"}},
+	}
+	type test struct {
+		name       string
+		resources  []apiClient.Resource
+		akasById   map[string]string
+		hasDefault bool
+		expected   map[string]string
+	}
+	tests := []test{
+		{"no resources", nil, map[string]string{"2": "folder_2"}, true, map[string]string{}},
+		{"value only", resources, map[string]string{}, true, map[string]string{"4": "", "5": "", "6": ""}},
+		{"no value or akas", resources, map[string]string{}, false, map[string]string{}},
+		{"ancestor aka with value", resources, map[string]string{"2": "folder_2"}, true, map[string]string{"4": "folder_2", "5": "folder_2", "6": ""}},
+		{"ancestor aka without value", resources, map[string]string{"2": "folder_2"}, false, map[string]string{"4": "folder_2", "5": "folder_2"}},
+		{"resource aka nearer than ancestor aka", resources, map[string]string{"2": "folder_2", "5": "account_5"}, false, map[string]string{"4": "folder_2", "5": "account_5"}},
+		{"common ancestor aka", resources, map[string]string{"1": "root", "3": "folder_3"}, false, map[string]string{"4": "root", "5": "root", "6": "folder_3"}},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, matchFanOutTargets(test.resources, test.akasById, test.hasDefault), test.name)
+	}
+}
+
+func TestFanOutDefinitionUpdate(t *testing.T) {
+	type test struct {
+		name       string
+		setting    apiClient.PolicySetting
+		valueAka   string
+		checkValue bool
+		expected   fanOutDefinition
+	}
+	configured := fanOutDefinition{
+		value:      "['us-east-1']",
+		valueByAka: map[string]interface{}{"folder_2": "['eu-west-1']"},
+		precedence: "REQUIRED",
+	}
+	tests := []test{
+		{"unchanged", apiClient.PolicySetting{Value: []interface{}{"us-east-1"}, ValueSource: "- us-east-1\n", Precedence: "REQUIRED"}, "", true, configured},
+		{"unchanged value_by_aka", apiClient.PolicySetting{Value: []interface{}{"eu-west-1"}, ValueSource: "['eu-west-1']", Precedence: "REQUIRED"}, "folder_2", true, configured},
+		{"value changed", apiClient.PolicySetting{Value: []interface{}{"us-west-2"}, ValueSource: "['us-west-2']", Precedence: "REQUIRED"}, "", true, fanOutDefinition{
+			value:      "- us-west-2\n",
+			valueByAka: configured.valueByAka,
+			precedence: "REQUIRED",
+		}},
+		{"value_by_aka changed", apiClient.PolicySetting{Value: []interface{}{"us-west-2"}, ValueSource: "['us-west-2']", Precedence: "REQUIRED"}, "folder_2", true, fanOutDefinition{
+			value:      configured.value,
+			valueByAka: map[string]interface{}{"folder_2": "- us-west-2\n"},
+			precedence: "REQUIRED",
+		}},
+		{"precedence changed", apiClient.PolicySetting{Value: []interface{}{"us-east-1"}, ValueSource: "['us-east-1']", Precedence: "RECOMMENDED"}, "", true, fanOutDefinition{
+			value:      configured.value,
+			valueByAka: configured.valueByAka,
+			precedence: "RECOMMENDED",
+		}},
+		{"value not checked", apiClient.PolicySetting{Value: []interface{}{"us-west-2"}, ValueSource: "['us-west-2']", Precedence: "REQUIRED"}, "", false, configured},
+		{"template added", apiClient.PolicySetting{Value: []interface{}{"us-west-2"}, Template: "['us-west-2']", Precedence: "REQUIRED"}, "", true, fanOutDefinition{
+			value:      configured.value,
+			valueByAka: configured.valueByAka,
+			precedence: "REQUIRED",
+			template:   "['us-west-2']",
+		}},
+	}
+	for _, test := range tests {
+		definition := configured
+		definition.valueByAka = map[string]interface{}{}
+		for aka, value := range configured.valueByAka {
+			definition.valueByAka[aka] = value
+		}
+		definition.update(&test.setting, test.valueAka, test.checkValue)
+		assert.Equal(t, test.expected, definition, test.name)
+	}
+}
+
+// configs
+func testAccPolicySettingFanOutValueByAkaConfig(value string) string {
+	return fmt.Sprintf(`
+resource "turbot_folder" "parent" {
+	parent = "tmod:@turbot/turbot#/"
+	title = "provider_test_fan_out"
+	description = "provider_test_fan_out"
+}
+
+resource "turbot_folder" "child_1" {
+	parent = turbot_folder.parent.id
+	title = "provider_test_fan_out_1"
+	description = "provider_test_fan_out_1"
+}
+
+resource "turbot_folder" "child_2" {
+	parent = turbot_folder.parent.id
+	title = "provider_test_fan_out_2"
+	description = "provider_test_fan_out_2"
+}
+
+resource "turbot_policy_setting_fan_out" "test" {
+	type = "%s"
+	filter = "resourceType:tmod:@turbot/turbot#/resource/types/folder resourceId:${turbot_folder.parent.id} level:descendant"
+	%s
+	value_by_aka = {
+		(turbot_folder.child_1.id) = "child1Value"
+	}
+	depends_on = [turbot_folder.child_1, turbot_folder.child_2]
+}
+`, stringPolicyType, value)
+}
+
+func testAccPolicySettingFanOutConfig(value string) string {
+	config := fmt.Sprintf(`
+resource "turbot_folder" "parent" {
+	parent = "tmod:@turbot/turbot#/"
+	title = "provider_test_fan_out"
+	description = "provider_test_fan_out"
+}
+
+resource "turbot_folder" "child_1" {
+	parent = turbot_folder.parent.id
+	title = "provider_test_fan_out_1"
+	description = "provider_test_fan_out_1"
+}
+
+resource "turbot_folder" "child_2" {
+	parent = turbot_folder.parent.id
+	title = "provider_test_fan_out_2"
+	description = "provider_test_fan_out_2"
+}
+
+resource "turbot_policy_setting_fan_out" "test" {
+	type = "%s"
+	filter = "resourceType:tmod:@turbot/turbot#/resource/types/folder resourceId:${turbot_folder.parent.id} level:descendant"
+	value = "%s"
+	depends_on = [turbot_folder.child_1, turbot_folder.child_2]
+}
+`, stringPolicyType, value)
+	return config
+}
+
+// helper functions
+func testAccCheckPolicySettingFanOutImported(policyTypeUri string, settingCount int) resource.ImportStateCheckFunc {
+	return func(states []*terraform.InstanceState) error {
+		if len(states) != 1 {
+			return fmt.Errorf("expected 1 imported fan-out, got %d", len(states))
+		}
+		attributes := states[0].Attributes
+		if attributes["type"] != policyTypeUri {
+			return fmt.Errorf("expected type %s, got %s", policyTypeUri, attributes["type"])
+		}
+		if attributes["settings.%"] != fmt.Sprintf("%d", settingCount) {
+			return fmt.Errorf("expected %d settings, got %s", settingCount, attributes["settings.%"])
+		}
+		return nil
+	}
+}
+
+func testAccPolicySettingFanOutImportId(resource string) resource.ImportStateIdFunc {
+	return func(state *terraform.State) (string, error) {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return "", fmt.Errorf("not found: %s", resource)
+		}
+		return fmt.Sprintf("%s|%s", rs.Primary.Attributes["type"], rs.Primary.Attributes["filter"]), nil
+	}
+}
+
+func testAccCheckPolicySettingFanOutExists(resource string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resource)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+		client := testAccProvider.Meta().(*apiClient.Client)
+		for _, settingId := range fanOutSettingIds(rs.Primary.Attributes) {
+			if _, err := client.ReadPolicySetting(settingId); err != nil {
+				return fmt.Errorf("error fetching policy setting %s. %s", settingId, err)
+			}
+		}
+		return nil
+	}
+}
+
+// check the value of the setting created for a resource
+func testAccCheckPolicySettingFanOutValue(fanOut, target, expected string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[fanOut]
+		if !ok {
+			return fmt.Errorf("Not found: %s", fanOut)
+		}
+		targetRs, ok := state.RootModule().Resources[target]
+		if !ok {
+			return fmt.Errorf("Not found: %s", target)
+		}
+		settingId, ok := rs.Primary.Attributes["settings."+targetRs.Primary.ID]
+		if !ok {
+			return fmt.Errorf("no policy setting for %s", target)
+		}
+		client := testAccProvider.Meta().(*apiClient.Client)
+		setting, err := client.ReadPolicySetting(settingId)
+		if err != nil {
+			return err
+		}
+		if value := fmt.Sprintf("%v", setting.Value); value != expected {
+			return fmt.Errorf("expected the setting for %s to be '%s', got '%s'", target, expected, value)
+		}
+		return nil
+	}
+}
+
+func testAccCheckPolicySettingFanOutDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*apiClient.Client)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "turbot_policy_setting_fan_out" {
+			for _, settingId := range fanOutSettingIds(rs.Primary.Attributes) {
+				_, err := client.ReadPolicySetting(settingId)
+				if err == nil {
+					return fmt.Errorf("Alert still exists")
+				}
+				if !apiClient.NotFoundError(err) {
+					return fmt.Errorf("expected 'not found' error, got %s", err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// get the policy setting ids from the flatmap state attributes
+func fanOutSettingIds(attributes map[string]string) []string {
+	var settingIds []string
+	for key, value := range attributes {
+		if strings.HasPrefix(key, "settings.") && key != "settings.%" {
+			settingIds = append(settingIds, value)
+		}
+	}
+	return settingIds
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
 	"strings"
 	"testing"
 )
@@ -26,6 +27,13 @@ func TestAccPolicySetting_String(t *testing.T) {
 						"turbot_policy_setting.test_policy", "precedence", "REQUIRED"),
 				),
 			},
+			{
+				ResourceName:      "turbot_policy_setting.test_policy",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// the configured resource is an aka, but the imported resource is the id
+				ImportStateVerifyIgnore: []string{"resource"},
+			},
 			{
 				Config: testAccPolicySettingStringConfig(stringPolicyType, "testValue-updated", "REQUIRED"),
 				Check: resource.ComposeTestCheckFunc(
@@ -132,6 +140,11 @@ func TestAccPolicySetting_Array(t *testing.T) {
 						"turbot_policy_setting.test_policy", "precedence", "REQUIRED"),
 				),
 			},
+			// the same value in a different YAML format does not cause a diff
+			{
+				Config:   testAccPolicySettingStringConfig(stringArrayPolicyType, "[b, a, d]", "REQUIRED"),
+				PlanOnly: true,
+			},
 		},
 	})
 }
@@ -199,6 +212,35 @@ func TestAccPolicySetting_SecretEncrypted(t *testing.T) {
 	})
 }
 
+func TestAccPolicySetting_SecretHashed(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPolicySettingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicySettingHashedConfig(secretPolicyType, "test1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicySettingExists("turbot_policy_setting.test_policy"),
+					testAccCheckPolicySettingValueHash("turbot_policy_setting.test_policy", "test1"),
+				),
+			},
+			// the unchanged value does not cause a diff
+			{
+				Config:   testAccPolicySettingHashedConfig(secretPolicyType, "test1"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccPolicySettingHashedConfig(secretPolicyType, "test2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicySettingExists("turbot_policy_setting.test_policy"),
+					testAccCheckPolicySettingValueHash("turbot_policy_setting.test_policy", "test2"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccPolicySetting_Precedence_Value_Check(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -217,6 +259,69 @@ func TestAccPolicySetting_Precedence_Value_Check(t *testing.T) {
 	})
 }
 
+func TestAccPolicySetting_CheckOnlyRollout(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPolicySettingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicySettingRolloutConfig(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicySettingExists("turbot_policy_setting.test_policy"),
+					resource.TestCheckResourceAttr(
+						"turbot_policy_setting.test_policy", "value", "Enforce: Enabled"),
+					resource.TestCheckResourceAttr(
+						"turbot_policy_setting.test_policy", "precedence", "REQUIRED"),
+					resource.TestCheckResourceAttrSet(
+						"turbot_policy_setting.test_policy", "alarm_count"),
+				),
+			},
+			{
+				Config: testAccPolicySettingRolloutConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicySettingExists("turbot_policy_setting.test_policy"),
+					resource.TestCheckResourceAttr(
+						"turbot_policy_setting.test_policy", "enforce", "true"),
+					resource.TestCheckResourceAttr(
+						"turbot_policy_setting.test_policy", "value", "Enforce: Enabled"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPolicySetting_PreviewAffectedControls(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPolicySettingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicySettingPreviewConfig("Check: Enabled"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPolicySettingExists("turbot_policy_setting.test_policy"),
+					resource.TestCheckResourceAttrSet(
+						"turbot_policy_setting.test_policy", "affected_control_count"),
+					resource.TestCheckResourceAttrSet(
+						"turbot_policy_setting.test_policy", "affected_alarm_count"),
+				),
+			},
+			{
+				Config: testAccPolicySettingPreviewConfig("Enforce: Enabled"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"turbot_policy_setting.test_policy", "value", "Enforce: Enabled"),
+					resource.TestCheckResourceAttrSet(
+						"turbot_policy_setting.test_policy", "affected_control_count"),
+					resource.TestCheckResourceAttrSet(
+						"turbot_policy_setting.test_policy", "affected_alarm_count"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccPolicySetting_TemplateInputJsonValue(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -405,6 +510,16 @@ resource "turbot_policy_setting" "test_policy" {
 	return config
 }
 
+func testAccPolicySettingHashedConfig(policyType, value string) string {
+	return fmt.Sprintf(`
+resource "turbot_policy_setting" "test_policy" {
+	resource = "tmod:@turbot/turbot#/"
+	type = "%s"
+	value = "%s"
+	hash_value = true
+}`, policyType, value)
+}
+
 func testAccPolicySettingIntConfig(policyType string, value int, precedence string) string {
 	return buildConfig(policyType, fmt.Sprintf("%d", value), precedence)
 }
@@ -433,6 +548,26 @@ resource "turbot_policy_setting" "test_policy" {
 	return config
 }
 
+func testAccPolicySettingRolloutConfig(enforce bool) string {
+	return fmt.Sprintf(`
+resource "turbot_policy_setting" "test_policy" {
+	resource = "tmod:@turbot/turbot#/"
+	type = "tmod:@turbot/aws-s3#/policy/types/bucketVersioning"
+	value = "Enforce: Enabled"
+	enforce = %t
+}`, enforce)
+}
+
+func testAccPolicySettingPreviewConfig(value string) string {
+	return fmt.Sprintf(`
+resource "turbot_policy_setting" "test_policy" {
+	resource = "tmod:@turbot/turbot#/"
+	type = "tmod:@turbot/aws-s3#/policy/types/bucketVersioning"
+	value = "%s"
+	preview_affected_controls = true
+}`, value)
+}
+
 // helper functions
 func testAccCheckPolicySettingExists(resource string) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
@@ -452,6 +587,20 @@ func testAccCheckPolicySettingExists(resource string) resource.TestCheckFunc {
 	}
 }
 
+// check the state holds a hash of the value rather than the value
+func testAccCheckPolicySettingValueHash(resource, value string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resource)
+		}
+		if hashed := rs.Primary.Attributes["value"]; !helpers.HashedValueMatches(hashed, value) {
+			return fmt.Errorf("expected value to be a hash of '%s', got '%s'", value, hashed)
+		}
+		return nil
+	}
+}
+
 func testAccCheckPolicySettingDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*apiClient.Client)
 	for _, rs := range s.RootModule().Resources {
@@ -1,6 +1,7 @@
 package turbot
 
 import (
+	"github.com/hashicorp/terraform/helper/customdiff"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
 	"github.com/terraform-providers/terraform-provider-turbot/helpers"
@@ -28,18 +29,9 @@ func resourceTurbotProfile() *schema.Resource {
 			// aka of the parent resource
 			"parent": {
 				Type:     schema.TypeString,
-				Required: true,
-				// when doing a diff, the state file will contain the id of the parent but the config contains the aka,
-				// so we need custom diff code
-				DiffSuppressFunc: suppressIfAkaMatches("parent_akas"),
-			},
-			// when doing a read, fetch the parent akas to use in suppressIfAkaMatches
-			"parent_akas": {
-				Type:     schema.TypeList,
+				Optional: true,
+				// the state holds the id of the parent, which parentCustomizeDiff compares to the configured parent
 				Computed: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
 			},
 			"title": {
 				Type:     schema.TypeString,
@@ -90,7 +82,18 @@ func resourceTurbotProfile() *schema.Resource {
 				Optional: true,
 				Default:  "Active",
 			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+			// the tags applied in Turbot, including the provider default_tags
+			"tags_all": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+			"turbot": turbotMetadataSchema(),
 		},
+		CustomizeDiff: customdiff.All(validateRequiredParent, parentCustomizeDiff, tagsCustomizeDiff, turbotMetadataCustomizeDiff),
 	}
 }
 
@@ -102,9 +105,13 @@ func resourceTurbotProfileExists(d *schema.ResourceData, meta interface{}) (b bo
 
 func resourceTurbotProfileCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
+	if err := setRequiredParent(d); err != nil {
+		return err
+	}
 	// build mutation data
 	input := mapFromResourceData(d, profileInputProperties)
 	input["data"] = mapFromResourceData(d, profileDataProperties)
+	input["tags"] = buildTagsInput(d, meta)
 
 	// do create
 	profile, err := client.CreateProfile(input)
@@ -112,12 +119,9 @@ func resourceTurbotProfileCreate(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
-	// set parent_akas property by loading resource and fetching the akas
-	if err := storeAkas(profile.Turbot.ParentId, "parent_akas", d, meta); err != nil {
-		return err
-	}
 	// assign the id
 	d.SetId(profile.Turbot.Id)
+	storeTurbotMetadata(d, profile.Turbot)
 	// assign results back into ResourceData
 	d.Set("parent", profile.Parent)
 	d.Set("title", profile.Title)
@@ -137,12 +141,15 @@ func resourceTurbotProfileRead(d *schema.ResourceData, meta interface{}) error {
 	profile, err := client.ReadProfile(id)
 	if err != nil {
 		if apiClient.NotFoundError(err) {
-			// profile was not found - clear id
+			// profile was not found - remove it from the state
 			d.SetId("")
+			return nil
 		}
 		return err
 	}
 
+	// if the profile was imported using an aka, replace the id with the profile id
+	d.SetId(profile.Turbot.Id)
 	// assign results back into ResourceData
 	d.Set("parent", profile.Parent)
 	d.Set("title", profile.Title)
@@ -155,10 +162,11 @@ func resourceTurbotProfileRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("picture", profile.Picture)
 	d.Set("external_id", profile.ExternalId)
 	d.Set("middle_name", profile.MiddleName)
-	d.Set("family_name", profile.FamilyName)
+	d.Set("directory_pool_id", profile.DirectoryPoolId)
 	d.Set("last_login_timestamp", profile.LastLoginTimestamp)
-	/// set parent_akas property by loading resource and fetching the akas
-	return storeAkas(profile.Turbot.ParentId, "parent_akas", d, meta)
+	storeTags(d, profile.Turbot.Tags, meta)
+	storeTurbotMetadata(d, profile.Turbot)
+	return nil
 }
 
 func resourceTurbotProfileUpdate(d *schema.ResourceData, meta interface{}) error {
@@ -166,6 +174,9 @@ func resourceTurbotProfileUpdate(d *schema.ResourceData, meta interface{}) error
 	// build mutation data
 	input := mapFromResourceData(d, profileInputProperties)
 	input["data"] = mapFromResourceData(d, getProfileUpdateProperties())
+	if d.HasChange("tags") || d.HasChange("tags_all") {
+		input["tags"] = buildTagsUpdateInput(d, meta)
+	}
 	input["id"] = d.Id()
 
 	// do create
@@ -182,15 +193,15 @@ func resourceTurbotProfileUpdate(d *schema.ResourceData, meta interface{}) error
 	d.Set("given_name", profile.GivenName)
 	d.Set("family_name", profile.FamilyName)
 	d.Set("directory_pool_id", profile.DirectoryPoolId)
-	// set parent_akas property by loading resource and fetching the akas
-	return storeAkas(profile.Turbot.ParentId, "parent_akas", d, meta)
+	storeTurbotMetadata(d, profile.Turbot)
+	return nil
 }
 
 func resourceTurbotProfileDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
 	id := d.Id()
 	err := client.DeleteResource(id)
-	if err != nil {
+	if err != nil && !apiClient.NotFoundError(err) {
 		return err
 	}
 
@@ -1,10 +1,16 @@
 package turbot
 
 import (
+	"encoding/json"
 	"fmt"
+	"github.com/hashicorp/terraform/helper/customdiff"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
 	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
 )
 
 var resourceProperties = []interface{}{"parent", "type", "tags", "akas"}
@@ -24,53 +30,218 @@ func resourceTurbotResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceTurbotResourceImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
-			// aka of the parent resource
+			// aka of the parent resource, or a path of folder titles from the Turbot root, e.g. "turbot:/Prod/AWS"
+			// if not set, the provider default_parent is used
 			"parent": {
 				Type:     schema.TypeString,
-				Required: true,
-				// when doing a diff, the state file will contain the id of the parent but the config contains the aka,
-				// so we need custom diff code
-				DiffSuppressFunc: suppressIfAkaMatches("parent_akas"),
-			},
-			// when doing a read, fetch the parent akas to use in suppressIfAkaMatches
-			"parent_akas": {
-				Type:     schema.TypeList,
+				Optional: true,
+				// the state holds the id of the parent, which parentCustomizeDiff compares to the configured parent
 				Computed: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
 			},
 			"type": {
 				Type:     schema.TypeString,
 				Required: true,
 				ForceNew: true,
 			},
+			// exactly one of 'data' (a json or yaml string) or 'data_map' must be set
+			// the state contains the data as formatted json
 			"data": {
 				Type:             schema.TypeString,
-				Required:         true,
+				Optional:         true,
 				DiffSuppressFunc: suppressIfDataMatches,
 			},
+			// the data as it was written in the configuration, e.g. as yaml
+			"data_source": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			// the data as a map - values are converted to the types defined by the resource type schema
+			"data_map": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				DiffSuppressFunc: suppressIfJsonValueMatches,
+			},
 			"metadata": {
 				Type:             schema.TypeString,
 				Optional:         true,
 				DiffSuppressFunc: suppressIfDataMatches,
 			},
+			"metadata_map": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				DiffSuppressFunc: suppressIfJsonValueMatches,
+			},
 			"tags": {
 				Type:     schema.TypeMap,
 				Optional: true,
 			},
+			// the tags applied in Turbot, including the provider default_tags
+			"tags_all": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+			// if not set, the akas assigned by Turbot are read back
 			"akas": {
 				Type:     schema.TypeList,
 				Optional: true,
+				Computed: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
+			// the data read back from Turbot as a map - values which are not strings are json encoded
+			"object": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// if true, properties removed from the data are deleted from the resource. Otherwise they are left unchanged
+			"full_resource": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// paths of data properties managed by Turbot (e.g. discovery enrichments), which are neither read back nor
+			// compared - changes to them, in Turbot or in the configuration, do not cause a diff
+			"ignore_properties": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// disable the client side validation of data against the resource type schema
+			"skip_validation": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// how properties in the data which the resource type schema does not define (e.g. typos) are handled:
+			// "ignore", "warn" or "error"
+			"unknown_properties": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  unknownPropertiesIgnore,
+			},
+			// if unknown_properties is "warn", the number of properties in the data which the resource type schema does
+			// not define, so they are shown in the plan
+			"unknown_property_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			// disable the check for an existing resource with the same title under the parent
+			"allow_duplicate_titles": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// how changes made outside of Terraform are handled: "revert", "ignore" or "fail"
+			"on_external_change": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  externalChangeRevert,
+			},
+			// controls which must be in the 'ok' state before the resource is created
+			"depends_on_control": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"resource": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"turbot": turbotMetadataSchema(),
 		},
+		CustomizeDiff: customdiff.All(validateParent, parentCustomizeDiff, resourceTurbotResourceCustomizeDiff, tagsCustomizeDiff, turbotMetadataCustomizeDiff),
 	}
 }
 
+// validate the data against the schema of the resource type and check for duplicate titles,
+// so these errors are reported at plan time
+func resourceTurbotResourceCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if err := validateExternalChangeMode(d); err != nil {
+		return err
+	}
+	if err := validateUnknownPropertiesMode(d); err != nil {
+		return err
+	}
+	if err := validateDataAttributes(d); err != nil {
+		return err
+	}
+	if d.Id() != "" && (d.HasChange("data") || d.HasChange("data_map")) {
+		if err := d.SetNewComputed("object"); err != nil {
+			return err
+		}
+		if err := d.SetNewComputed("data_source"); err != nil {
+			return err
+		}
+	}
+	// if the type or data are interpolated from other resources they may not be known until apply
+	if !d.NewValueKnown("type") || !d.NewValueKnown("data") || !d.NewValueKnown("data_map") {
+		return nil
+	}
+	dataAttribute := getDataAttribute(d)
+	dataString, err := getResourceDataString(d, meta)
+	if err != nil {
+		return attributeError(dataAttribute, err)
+	}
+	if !d.Get("skip_validation").(bool) && (d.Id() == "" || d.HasChange(dataAttribute) || d.HasChange("unknown_properties")) {
+		unknown, err := validateResourceData(d.Get("type").(string), dataString, d.Get("unknown_properties").(string), d.Id() != "", meta)
+		if err != nil {
+			return attributeError(dataAttribute, err)
+		}
+		if err := d.SetNew("unknown_property_count", len(unknown)); err != nil {
+			return err
+		}
+	}
+	if !d.NewValueKnown("parent") {
+		return nil
+	}
+	// resolve a parent path, so an invalid path is reported at plan time
+	parent := parentOrDefault(d.Get("parent").(string), meta)
+	if isParentPath(parent) && (d.Id() == "" || d.HasChange("parent")) {
+		var err error
+		if parent, err = resolveParentPath(parent, meta); err != nil {
+			return attributeError("parent", err)
+		}
+	}
+	// check there is no existing resource of the same type with the same title under the parent
+	if !d.Get("allow_duplicate_titles").(bool) && (d.Id() == "" || d.HasChange("parent") || d.HasChange(dataAttribute)) {
+		data, err := helpers.JsonOrYamlStringToMap(dataString)
+		if err != nil {
+			return attributeError(dataAttribute, fmt.Errorf("failed to unmarshal data: %s", err.Error()))
+		}
+		if title, ok := data["title"].(string); ok && title != "" {
+			return attributeError(dataAttribute, checkTitleUnique(d.Get("type").(string), parent, title, d.Id(), meta))
+		}
+	}
+	return nil
+}
+
 func resourceTurbotResourceExists(d *schema.ResourceData, meta interface{}) (b bool, e error) {
 	client := meta.(*apiClient.Client)
 	id := d.Id()
@@ -79,30 +250,42 @@ func resourceTurbotResourceExists(d *schema.ResourceData, meta interface{}) (b b
 
 func resourceTurbotResourceCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
+	if err := setParent(d, meta); err != nil {
+		return err
+	}
 	typeUri := d.Get("type")
 	var err error
 
+	// wait for any controls this resource depends on
+	if controls := getControlDependencies(d); len(controls) > 0 {
+		if err := waitForControlsOk(controls, d.Timeout(schema.TimeoutCreate), client); err != nil {
+			return attributeError("depends_on_control", fmt.Errorf("error waiting for depends_on_control: %s", err.Error()))
+		}
+	}
+
 	// build input map to pass to mutation
-	input, err := buildResourceInput(d, resourceProperties)
+	input, err := buildResourceInput(d, resourceProperties, meta)
 	if err != nil {
 		return err
 	}
+	input["tags"] = buildTagsInput(d, meta)
 
 	turbotMetadata, err := client.CreateResource(input)
 	if err != nil {
-		return err
+		return apiValidationError(getDataAttribute(d), err)
 	}
 
-	// set parent_akas property by loading resource and fetching the akas
-	if err := storeAkas(turbotMetadata.ParentId, "parent_akas", d, meta); err != nil {
-		return err
-	}
 	// assign the id
 	d.SetId(turbotMetadata.Id)
+	storeTurbotMetadata(d, *turbotMetadata)
+	if err := setResourceObject(d, input["data"].(map[string]interface{})); err != nil {
+		return err
+	}
 	// save the formatted data: this is to ensure the acceptance tests behave in a consistent way regardless of the ordering of the json data
-	d.Set("data", helpers.FormatJson(d.Get("data").(string)))
+	d.Set("data_source", d.Get("data"))
+	d.Set("data", helpers.FormatJsonOrYaml(d.Get("data").(string)))
 	if metadata, ok := d.GetOk("metadata"); ok {
-		d.Set("metadata", helpers.FormatJson(metadata.(string)))
+		d.Set("metadata", helpers.FormatJsonOrYaml(metadata.(string)))
 	}
 	d.Set("type", typeUri)
 	return nil
@@ -112,53 +295,91 @@ func resourceTurbotResourceRead(d *schema.ResourceData, meta interface{}) error
 	client := meta.(*apiClient.Client)
 	id := d.Id()
 
-	// build required properties from data.
-	// properties is a map of property name -> property path
-
-	var properties map[string]string = nil
-
+	var resource *apiClient.Resource
+	var err error
 	if _, ok := d.GetOk("data"); ok {
-		var err error = nil
-		properties, err = helpers.PropertyMapFromJson(d.Get("data").(string))
-		if err != nil {
-			return fmt.Errorf("error retrieving properties from resource data: %s", err.Error())
+		// read only the nested properties set in data, so properties populated by Turbot alongside them do not cause a diff
+		paths, pathsErr := helpers.PropertyPathsFromJson(helpers.FormatJsonOrYaml(d.Get("data").(string)))
+		if pathsErr != nil {
+			return fmt.Errorf("error retrieving properties from resource data: %s", pathsErr.Error())
 		}
+		resource, err = client.ReadResourcePaths(id, paths)
+	} else {
+		// build required properties from data_map.
+		// properties is a map of property name -> property path
+		var properties map[string]string = nil
+		if dataMap, ok := d.GetOk("data_map"); ok {
+			properties = map[string]string{}
+			for k := range dataMap.(map[string]interface{}) {
+				properties[k] = k
+			}
+		}
+		resource, err = client.ReadResource(id, properties)
 	}
-
-	resource, err := client.ReadResource(id, properties)
 	if err != nil {
 		if apiClient.NotFoundError(err) {
-			// resource was not found - clear id
+			// resource was not found - remove it from the state
 			d.SetId("")
+			return nil
 		}
 		return err
 	}
 
+	// keep the values of the ignored properties in the state, so changes made by Turbot do not cause a diff
+	resourceData := resource.Data
+	if ignored := ignoredProperties(d); len(ignored) > 0 {
+		if resourceData, err = restoreIgnoredProperties(d, resource.Data, ignored); err != nil {
+			return fmt.Errorf("error building resource data: %s", err.Error())
+		}
+	}
 	// rebuild data from the resource
-	data, err := helpers.MapToJsonString(resource.Data)
+	data, err := helpers.MapToJsonString(resourceData)
 	if err != nil {
 		return fmt.Errorf("error building resource data: %s", err.Error())
 	}
 
 	// assign results back into ResourceData
-
-	// set parent_akas property by loading resource and fetching the akas
-	if err := storeAkas(resource.Turbot.ParentId, "parent_akas", d, meta); err != nil {
-		return err
-	}
-
 	d.Set("parent", resource.Turbot.ParentId)
 	d.Set("type", resource.Type.Uri)
-	d.Set("tags", resource.Turbot.Tags)
-	d.Set("data", data)
+	// Turbot may add akas of its own, so keep the configured akas if they are all still akas of the resource
+	if !akasContainAll(resource.Turbot.Akas, d.Get("akas").([]interface{})) {
+		d.Set("akas", resource.Turbot.Akas)
+	}
+	if err := setResourceObject(d, resource.Data); err != nil {
+		return err
+	}
+	values := map[string]interface{}{
+		"tags":     resourceTags(d, resource.Turbot.Tags, meta),
+		"tags_all": resource.Turbot.Tags,
+	}
+	if getDataAttribute(d) == "data_map" {
+		if values["data_map"], err = dataMapFromResourceData(resourceData); err != nil {
+			return fmt.Errorf("error building resource data: %s", err.Error())
+		}
+	} else {
+		values["data"] = data
+	}
+	if err := setExternallyChangedAttributes(d, values, meta); err != nil {
+		return err
+	}
+	storeDataSource(d)
+	storeTurbotMetadata(d, resource.Turbot)
 	return nil
 }
 
+// keep the data as it was written in the configuration while it matches the data in the state
+func storeDataSource(d *schema.ResourceData) {
+	data := d.Get("data").(string)
+	if !helpers.JsonOrYamlStringsAreEqual(d.Get("data_source").(string), data) {
+		d.Set("data_source", data)
+	}
+}
+
 func resourceTurbotResourceUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
 	// build input map to pass to mutation
 	id := d.Id()
-	input, err := buildResourceInput(d, getResourceUpdateProperties())
+	input, err := buildResourceInput(d, getResourceUpdateProperties(), meta)
 	if err != nil {
 		return err
 	}
@@ -166,27 +387,54 @@ func resourceTurbotResourceUpdate(d *schema.ResourceData, meta interface{}) erro
 	if err != nil {
 		return err
 	}
-	input["data"], _ = buildDataUpdateProperties(d, excludedPropertiesInUpdate)
+	dataMap := input["data"].(map[string]interface{})
+	if d.Get("full_resource").(bool) {
+		// any property which should be removed must be explicitly set to null in the mutation input
+		removedProperties, err := getRemovedDataProperties(d)
+		if err != nil {
+			return err
+		}
+		for _, property := range removedProperties {
+			dataMap[property.(string)] = nil
+		}
+	}
+	// the ignored properties are managed by Turbot, so are not overwritten with the configured values
+	for _, path := range ignoredProperties(d) {
+		helpers.DeletePropertyPath(dataMap, path)
+	}
+	// the properties which may not be updated are unchanged, so are still part of the object
+	if err := setResourceObject(d, dataMap); err != nil {
+		return err
+	}
+	input["data"] = removeDataProperties(dataMap, excludedPropertiesInUpdate)
+	if d.HasChange("tags") || d.HasChange("tags_all") {
+		input["tags"] = buildTagsUpdateInput(d, meta)
+	} else {
+		delete(input, "tags")
+	}
 	input["id"] = d.Id()
 
 	turbotMetadata, err := client.UpdateResource(input)
 	if err != nil {
-		return err
+		return apiValidationError(getDataAttribute(d), err)
 	}
 	// save the formatted data: this is to ensure the acceptance tests behave in a consistent way regardless of the ordering of the json data
-	d.Set("data", helpers.FormatJson(d.Get("data").(string)))
+	if d.HasChange("data") {
+		d.Set("data_source", d.Get("data"))
+	}
+	d.Set("data", helpers.FormatJsonOrYaml(d.Get("data").(string)))
 	if metadata, ok := d.GetOk("metadata"); ok {
-		d.Set("metadata", helpers.FormatJson(metadata.(string)))
+		d.Set("metadata", helpers.FormatJsonOrYaml(metadata.(string)))
 	}
-	// set parent_akas property by loading resource and fetching the akas
-	return storeAkas(turbotMetadata.ParentId, "parent_akas", d, meta)
+	storeTurbotMetadata(d, *turbotMetadata)
+	return nil
 }
 
 func resourceTurbotResourceDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
 	id := d.Id()
 	err := client.DeleteResource(id)
-	if err != nil {
+	if err != nil && !apiClient.NotFoundError(err) {
 		return err
 	}
 
@@ -197,47 +445,270 @@ func resourceTurbotResourceDelete(d *schema.ResourceData, meta interface{}) erro
 }
 
 func resourceTurbotResourceImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// the resource may be imported using an aka - resolve it to the id, and keep the aka as the akas of the resource
+	// (as if it had been configured), rather than all of the akas Turbot has assigned
+	if !isResourceId(d.Id()) {
+		client := meta.(*apiClient.Client)
+		aka := d.Id()
+		resource, err := client.ReadResource(aka, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving aka '%s': %w", aka, err)
+		}
+		d.SetId(resource.Turbot.Id)
+		d.Set("akas", []string{aka})
+	}
 	if err := resourceTurbotResourceRead(d, meta); err != nil {
 		return nil, err
 	}
 	return []*schema.ResourceData{d}, nil
 }
 
-func buildDataUpdateProperties(d *schema.ResourceData, properties []interface{}) (map[string]interface{}, error) {
-	var err error
-	// convert data from json string to map
-	var dataMap map[string]interface{}
-	dataString := d.Get("data").(string)
-	if dataMap, err = helpers.JsonStringToMap(dataString); err != nil {
-		return nil, fmt.Errorf("error build resource mutation input, failed to unmarshal data: \n%s\nerror: %s", dataString, err.Error())
+// Turbot resource ids are numeric
+func isResourceId(id string) bool {
+	_, err := strconv.ParseUint(id, 10, 64)
+	return err == nil
+}
+
+// are all the given akas in the list of akas of the resource - an empty list is never contained
+func akasContainAll(resourceAkas []string, akas []interface{}) bool {
+	if len(akas) == 0 {
+		return false
+	}
+	for _, aka := range akas {
+		if !helpers.SliceContains(resourceAkas, aka.(string)) {
+			return false
+		}
+	}
+	return true
+}
+
+// get the properties in the data in the state file which are not in the config
+func getRemovedDataProperties(d *schema.ResourceData) ([]interface{}, error) {
+	dataAttribute := getDataAttribute(d)
+	old, new := d.GetChange(dataAttribute)
+	if dataAttribute == "data_map" {
+		return helpers.GetOldMapProperties(old.(map[string]interface{}), new.(map[string]interface{})), nil
 	}
+	// the data may previously have been given as a map
+	if old.(string) == "" {
+		return nil, nil
+	}
+	oldData, err := helpers.JsonOrYamlStringToMap(old.(string))
+	if err != nil {
+		return nil, attributeError("data", fmt.Errorf("error build resource mutation input, failed to unmarshal data: \n%s\nerror: %s", old.(string), err.Error()))
+	}
+	newData, err := helpers.JsonOrYamlStringToMap(new.(string))
+	if err != nil {
+		return nil, attributeError("data", fmt.Errorf("error build resource mutation input, failed to unmarshal data: \n%s\nerror: %s", new.(string), err.Error()))
+	}
+	return helpers.GetOldMapProperties(oldData, newData), nil
+}
+
+// remove the properties which may not be updated from the data
+func removeDataProperties(dataMap map[string]interface{}, properties []interface{}) map[string]interface{} {
 	for _, element := range properties {
 		if _, ok := dataMap[element.(string)]; ok {
 			delete(dataMap, element.(string))
 		}
 	}
-
-	return dataMap, nil
+	return dataMap
 }
 
-func buildResourceInput(d *schema.ResourceData, properties []interface{}) (map[string]interface{}, error) {
+func buildResourceInput(d *schema.ResourceData, properties []interface{}, meta interface{}) (map[string]interface{}, error) {
 	var err error
 	input := mapFromResourceData(d, properties)
+	// resolve a parent path to the id of the folder
+	if parent, ok := input["parent"].(string); ok {
+		if input["parent"], err = resolveParent(parent, meta); err != nil {
+			return nil, attributeError("parent", err)
+		}
+	}
 	// convert data from json string to map
-	dataString := d.Get("data").(string)
-	if input["data"], err = helpers.JsonStringToMap(dataString); err != nil {
-		return nil, fmt.Errorf("error build resource mutation input, failed to unmarshal data: \n%s\nerror: %s", dataString, err.Error())
+	dataString, err := getResourceDataString(d, meta)
+	if err != nil {
+		return nil, attributeError("data_map", err)
+	}
+	if input["data"], err = helpers.JsonOrYamlStringToMap(dataString); err != nil {
+		return nil, attributeError("data", fmt.Errorf("error build resource mutation input, failed to unmarshal data: \n%s\nerror: %s", dataString, err.Error()))
 	}
 	// convert metadata from json string to map (if present)
 	if metadata, ok := d.GetOk("metadata"); ok {
 		metadataString := metadata.(string)
-		if input["metadata"], err = helpers.JsonStringToMap(metadataString); err != nil {
-			return nil, fmt.Errorf("error build resource mutation input, failed to unmarshal metadata: \n%s\nerror: %s", metadataString, err.Error())
+		if input["metadata"], err = helpers.JsonOrYamlStringToMap(metadataString); err != nil {
+			return nil, attributeError("metadata", fmt.Errorf("error build resource mutation input, failed to unmarshal metadata: \n%s\nerror: %s", metadataString, err.Error()))
+		}
+	} else if metadataMap, ok := d.GetOk("metadata_map"); ok {
+		// metadata has no schema, so only jsonencoded objects and arrays are converted
+		if input["metadata"], err = helpers.ConvertToSchemaTypes(metadataMap.(map[string]interface{}), nil); err != nil {
+			return nil, attributeError("metadata_map", err)
 		}
 	}
 	return input, nil
 }
 
+// the data may be given either as a json string or as a map
+func validateDataAttributes(d *schema.ResourceDiff) error {
+	for _, attributes := range [][]string{{"data", "data_map"}, {"metadata", "metadata_map"}} {
+		_, stringSet := d.GetOk(attributes[0])
+		_, mapSet := d.GetOk(attributes[1])
+		if stringSet && mapSet {
+			return attributeError(attributes[1], fmt.Errorf("only one of '%s' or '%s' may be set", attributes[0], attributes[1]))
+		}
+	}
+	// if the data is interpolated it may not be known until apply
+	if !d.NewValueKnown("data") || !d.NewValueKnown("data_map") {
+		return nil
+	}
+	_, dataSet := d.GetOk("data")
+	_, dataMapSet := d.GetOk("data_map")
+	if !dataSet && !dataMapSet {
+		return attributeError("data", fmt.Errorf("one of 'data' or 'data_map' must be set"))
+	}
+	return nil
+}
+
+// return the name of the attribute the data is given in
+func getDataAttribute(d resourceAttributeGetter) string {
+	if _, ok := d.GetOk("data_map"); ok {
+		return "data_map"
+	}
+	return "data"
+}
+
+// implemented by both schema.ResourceData and schema.ResourceDiff
+type resourceAttributeGetter interface {
+	Get(string) interface{}
+	GetOk(string) (interface{}, bool)
+}
+
+// return the data as a json string - if the data is given as yaml, it is converted to json. if the data is given
+// as a map, the values are converted to the types defined by the schema of the resource type
+func getResourceDataString(d resourceAttributeGetter, meta interface{}) (string, error) {
+	dataMap, ok := d.GetOk("data_map")
+	if !ok {
+		return helpers.FormatJsonOrYaml(d.Get("data").(string)), nil
+	}
+	client := meta.(*apiClient.Client)
+	var createSchema map[string]interface{}
+	resourceSchema, err := client.ReadResourceTypeSchema(d.Get("type").(string))
+	if err != nil {
+		// the resource type may be defined by a mod which is installed in this apply
+		if !apiClient.NotFoundError(err) {
+			return "", err
+		}
+	} else {
+		createSchema, _ = resourceSchema.Resource.CreateSchema.(map[string]interface{})
+	}
+	data, err := helpers.ConvertToSchemaTypes(dataMap.(map[string]interface{}), createSchema)
+	if err != nil {
+		return "", err
+	}
+	return helpers.MapToJsonString(data)
+}
+
+func setResourceObject(d *schema.ResourceData, data map[string]interface{}) error {
+	object, err := dataMapFromResourceData(data)
+	if err != nil {
+		return fmt.Errorf("error building resource object: %s", err.Error())
+	}
+	d.Set("object", object)
+	return nil
+}
+
+// convert resource data read from Turbot to a map of strings, json encoding any values which are not strings
+func dataMapFromResourceData(data map[string]interface{}) (map[string]string, error) {
+	result := map[string]string{}
+	for k, v := range data {
+		if v == nil {
+			continue
+		}
+		if value, ok := v.(string); ok {
+			result[k] = value
+			continue
+		}
+		jsonBytes, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		result[k] = string(jsonBytes)
+	}
+	return result, nil
+}
+
+func getControlDependencies(d *schema.ResourceData) []controlReference {
+	var controls []controlReference
+	for _, element := range d.Get("depends_on_control").([]interface{}) {
+		c := element.(map[string]interface{})
+		controls = append(controls, controlReference{
+			Id:       c["id"].(string),
+			Type:     c["type"].(string),
+			Resource: c["resource"].(string),
+		})
+	}
+	return controls
+}
+
+// how properties in the data which are not defined by the resource type schema are handled - the API may reject them
+// or silently drop them
+const (
+	unknownPropertiesIgnore = "ignore"
+	unknownPropertiesWarn   = "warn"
+	unknownPropertiesError  = "error"
+)
+
+func validateUnknownPropertiesMode(d *schema.ResourceDiff) error {
+	switch mode := d.Get("unknown_properties").(string); mode {
+	case "", unknownPropertiesIgnore, unknownPropertiesWarn, unknownPropertiesError:
+		return nil
+	default:
+		return attributeError("unknown_properties", fmt.Errorf("invalid value '%s' - must be one of '%s', '%s' or '%s'", mode, unknownPropertiesIgnore, unknownPropertiesWarn, unknownPropertiesError))
+	}
+}
+
+// validate the data against the resource type schema - the create schema for a new resource, otherwise the update
+// schema, which may only allow some properties to be updated. if unknownPropertiesMode is "warn", the properties
+// which the schema does not define are returned
+func validateResourceData(resourceTypeUri, dataString, unknownPropertiesMode string, update bool, meta interface{}) ([]string, error) {
+	client := meta.(*apiClient.Client)
+	data, err := helpers.JsonOrYamlStringToMap(dataString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal data: \n%s\nerror: %s", dataString, err.Error())
+	}
+	resourceSchema, err := client.ReadResourceTypeSchema(resourceTypeUri)
+	if err != nil {
+		// the resource type may be defined by a mod which is installed in this apply
+		if apiClient.NotFoundError(err) {
+			client.Logf(helpers.LogResources, "[WARN] resource type %s not found, skipping data validation", resourceTypeUri)
+			return nil, nil
+		}
+		return nil, err
+	}
+	typeSchema := resourceSchema.Resource.CreateSchema
+	if update {
+		typeSchema = resourceSchema.Resource.UpdateSchema
+	}
+	dataSchema, ok := typeSchema.(map[string]interface{})
+	if !ok {
+		// the resource type has no schema - nothing to validate against
+		return nil, nil
+	}
+	if validationErrors := helpers.ValidateJsonSchema(data, dataSchema); len(validationErrors) > 0 {
+		return nil, fmt.Errorf("data is not valid for resource type %s (set skip_validation = true to disable this check):\n  %s", resourceTypeUri, strings.Join(validationErrors, "\n  "))
+	}
+	if unknownPropertiesMode != unknownPropertiesWarn && unknownPropertiesMode != unknownPropertiesError {
+		return nil, nil
+	}
+	unknown := helpers.UnknownJsonSchemaProperties(data, dataSchema)
+	if len(unknown) == 0 {
+		return nil, nil
+	}
+	if unknownPropertiesMode == unknownPropertiesError {
+		return nil, fmt.Errorf("data has properties which are not defined by resource type %s (set unknown_properties = \"%s\" to allow them):\n  %s", resourceTypeUri, unknownPropertiesIgnore, strings.Join(unknown, "\n  "))
+	}
+	client.Logf(helpers.LogResources, "[WARN] data has properties which are not defined by resource type %s: %s", resourceTypeUri, strings.Join(unknown, ", "))
+	return unknown, nil
+}
+
 // the property in the config is an aka - however the state file will have an id.
 // to perform a diff we also store the list of akas in state file
 // if the new value of th eproperty exists in the akas list, then suppress diff
@@ -264,14 +735,84 @@ func suppressIfAkaMatches(propertyName string) func(k, old, new string, d *schem
 
 }
 
-// data is a json string
-// apply standard formatting to old and new data then compare
+// values of a data map may be jsonencoded objects or arrays
+// compare the parsed values, so formatting differences are ignored
+func suppressIfJsonValueMatches(k, old, new string, d *schema.ResourceData) bool {
+	if old == new {
+		return true
+	}
+	if strings.HasPrefix(k, "data_map.") && helpers.SliceContains(ignoredProperties(d), strings.TrimPrefix(k, "data_map.")) {
+		return true
+	}
+	var oldValue, newValue interface{}
+	if json.Unmarshal([]byte(old), &oldValue) != nil || json.Unmarshal([]byte(new), &newValue) != nil {
+		return false
+	}
+	return reflect.DeepEqual(oldValue, newValue)
+}
+
+// data is a json or yaml string
+// compare the parsed old and new data, so formatting differences and the format used do not cause a diff
 func suppressIfDataMatches(k, old, new string, d *schema.ResourceData) bool {
 	if old == "" || new == "" {
 		return false
 	}
+	if old == new || helpers.JsonOrYamlStringsAreEqual(old, new) {
+		return true
+	}
+	ignored := ignoredProperties(d)
+	if k != "data" || len(ignored) == 0 {
+		return false
+	}
+	oldData, oldErr := helpers.JsonOrYamlStringToMap(old)
+	newData, newErr := helpers.JsonOrYamlStringToMap(new)
+	if oldErr != nil || newErr != nil {
+		return false
+	}
+	for _, path := range ignored {
+		helpers.DeletePropertyPath(oldData, path)
+		helpers.DeletePropertyPath(newData, path)
+	}
+	return reflect.DeepEqual(oldData, newData)
+}
+
+// the paths of ignore_properties - the json path prefix "$." is optional
+func ignoredProperties(d resourceAttributeGetter) []string {
+	var paths []string
+	for _, path := range d.Get("ignore_properties").([]interface{}) {
+		paths = append(paths, strings.TrimPrefix(path.(string), "$."))
+	}
+	return paths
+}
 
-	oldFormatted := helpers.FormatJson(old)
-	newFormatted := helpers.FormatJson(new)
-	return oldFormatted == newFormatted
+// return a copy of the data read from Turbot, with the ignored properties set to their values in the state
+// (or removed if they are not in the state)
+func restoreIgnoredProperties(d *schema.ResourceData, data map[string]interface{}, ignored []string) (map[string]interface{}, error) {
+	dataJson, err := helpers.MapToJsonString(data)
+	if err != nil {
+		return nil, err
+	}
+	result, err := helpers.JsonStringToMap(dataJson)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		result = map[string]interface{}{}
+	}
+	stateData := map[string]interface{}{}
+	if getDataAttribute(d) == "data_map" {
+		stateData = d.Get("data_map").(map[string]interface{})
+	} else if dataString := d.Get("data").(string); dataString != "" {
+		if stateData, err = helpers.JsonOrYamlStringToMap(dataString); err != nil {
+			return nil, err
+		}
+	}
+	for _, path := range ignored {
+		if value, ok := helpers.GetPropertyPath(stateData, path); ok {
+			helpers.SetPropertyPath(result, path, value)
+		} else {
+			helpers.DeletePropertyPath(result, path)
+		}
+	}
+	return result, nil
 }
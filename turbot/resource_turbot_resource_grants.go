@@ -0,0 +1,303 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+)
+
+// the complete set of grants on a resource - any grant on the resource which is not in the set is deleted
+// NOTE: this must not be used together with turbot_grant resources for the same resource
+func resourceTurbotResourceGrants() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTurbotResourceGrantsCreate,
+		Read:   resourceTurbotResourceGrantsRead,
+		Update: resourceTurbotResourceGrantsUpdate,
+		Delete: resourceTurbotResourceGrantsDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceTurbotResourceGrantsImport,
+		},
+		Schema: map[string]*schema.Schema{
+			// aka of the resource the grants are on
+			"resource": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				// when doing a diff, the state file will contain the id of the resource but the config contains the aka,
+				// so we need custom diff code
+				DiffSuppressFunc: suppressIfAkaMatches("resource_akas"),
+			},
+			"resource_akas": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"grant": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"identity": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"level": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceTurbotResourceGrantsCreate(d *schema.ResourceData, meta interface{}) error {
+	resourceId, err := applyResourceGrants(d, meta)
+	if err != nil {
+		return err
+	}
+	// assign the id
+	d.SetId(resourceId)
+	return resourceTurbotResourceGrantsRead(d, meta)
+}
+
+func resourceTurbotResourceGrantsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	resourceId := d.Id()
+
+	// set resource_akas property by loading resource and fetching the akas
+	if err := storeAkas(resourceId, "resource_akas", d, meta); err != nil {
+		if apiClient.NotFoundError(err) {
+			// resource was not found - remove it from the state
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	grants, err := readResourceGrants(resourceId, client)
+	if err != nil {
+		return err
+	}
+
+	// the configured grants use akas, but the API returns ids - keep the configured grant for each grant which exists,
+	// and add any other grants using ids, so they are shown as removed in the plan
+	resolver := newAkaResolver(client)
+	configured := map[string]interface{}{}
+	for _, element := range d.Get("grant").(*schema.Set).List() {
+		key, err := resolveGrantKey(element.(map[string]interface{}), resolver)
+		if err != nil {
+			// the identity, permission type or level may have been deleted
+			client.Logf(helpers.LogResources, "[WARN] failed to resolve grant: %s", err.Error())
+			continue
+		}
+		configured[key] = element
+	}
+	var result []interface{}
+	for _, grant := range grants {
+		if element, ok := configured[grantKey(grant.Turbot.ProfileId, grant.PermissionTypeId, grant.PermissionLevelId)]; ok {
+			result = append(result, element)
+			continue
+		}
+		result = append(result, map[string]interface{}{
+			"identity": grant.Turbot.ProfileId,
+			"type":     grant.PermissionTypeId,
+			"level":    grant.PermissionLevelId,
+		})
+	}
+	d.Set("resource", resourceId)
+	d.Set("grant", result)
+	return nil
+}
+
+func resourceTurbotResourceGrantsUpdate(d *schema.ResourceData, meta interface{}) error {
+	if _, err := applyResourceGrants(d, meta); err != nil {
+		return err
+	}
+	return resourceTurbotResourceGrantsRead(d, meta)
+}
+
+// delete the grants in the set - other grants on the resource are left unchanged
+func resourceTurbotResourceGrantsDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	grants, err := readResourceGrants(d.Id(), client)
+	if err != nil {
+		// if the resource has been deleted, so have its grants
+		if apiClient.NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	resolver := newAkaResolver(client)
+	managed := map[string]bool{}
+	for _, element := range d.Get("grant").(*schema.Set).List() {
+		key, err := resolveGrantKey(element.(map[string]interface{}), resolver)
+		if err != nil {
+			client.Logf(helpers.LogResources, "[WARN] failed to resolve grant: %s", err.Error())
+			continue
+		}
+		managed[key] = true
+	}
+	for _, grant := range grants {
+		if managed[grantKey(grant.Turbot.ProfileId, grant.PermissionTypeId, grant.PermissionLevelId)] {
+			if err := client.DeleteGrant(grant.Turbot.Id); err != nil && !apiClient.NotFoundError(err) {
+				return err
+			}
+		}
+	}
+
+	// clear the id to show we have deleted
+	d.SetId("")
+	return nil
+}
+
+func resourceTurbotResourceGrantsImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// the grants may be imported using an aka of the resource - the id of the resource is stored as the id
+	resourceId, err := resolveResourceId(d.Id(), meta.(*apiClient.Client))
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(resourceId)
+	if err := resourceTurbotResourceGrantsRead(d, meta); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// delete any grant on the resource which is not in the set, and create any grant in the set which does not exist
+// returns the id of the resource
+func applyResourceGrants(d *schema.ResourceData, meta interface{}) (string, error) {
+	client := meta.(*apiClient.Client)
+	resolver := newAkaResolver(client)
+	resourceId, err := resolver.resolve(d.Get("resource").(string))
+	if err != nil {
+		return "", attributeError("resource", err)
+	}
+
+	desired := map[string]map[string]interface{}{}
+	for _, element := range d.Get("grant").(*schema.Set).List() {
+		grant := element.(map[string]interface{})
+		key, err := resolveGrantKey(grant, resolver)
+		if err != nil {
+			return "", attributeError("grant", err)
+		}
+		desired[key] = grant
+	}
+
+	grants, err := readResourceGrants(resourceId, client)
+	if err != nil {
+		return "", err
+	}
+	existing := map[string]bool{}
+	var removed []apiClient.Grant
+	for _, grant := range grants {
+		key := grantKey(grant.Turbot.ProfileId, grant.PermissionTypeId, grant.PermissionLevelId)
+		if _, ok := desired[key]; ok {
+			existing[key] = true
+		} else {
+			removed = append(removed, grant)
+		}
+	}
+	// create the new grants before deleting the removed ones, so replacing a grant never leaves the identity without
+	// access, and a failed create leaves the existing grants in place
+	for key, grant := range desired {
+		if existing[key] {
+			continue
+		}
+		input := map[string]interface{}{
+			"resource": resourceId,
+			"identity": grant["identity"],
+			"type":     grant["type"],
+			"level":    grant["level"],
+		}
+		if _, err := client.CreateGrant(input); err != nil {
+			return "", err
+		}
+	}
+	for _, grant := range removed {
+		client.Logf(helpers.LogResources, "[INFO] deleting grant %s on resource %s, as it is not in the set of grants", grant.Turbot.Id, resourceId)
+		if err := client.DeleteGrant(grant.Turbot.Id); err != nil {
+			return "", err
+		}
+	}
+	return resourceId, nil
+}
+
+// read the grants directly on the resource (not on its descendants)
+// the resource may be given as an aka, e.g. when importing - it is resolved to the id, as the grants are matched by
+// the id of their resource
+func readResourceGrants(resource string, client *apiClient.Client) ([]apiClient.Grant, error) {
+	resourceId, err := resolveResourceId(resource, client)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := client.ReadGrantList(fmt.Sprintf("resourceId:%s level:self", resourceId))
+	if err != nil {
+		return nil, err
+	}
+	var result []apiClient.Grant
+	for _, grant := range grants {
+		if grant.Turbot.ResourceId == resourceId {
+			result = append(result, grant)
+		}
+	}
+	return result, nil
+}
+
+func resolveResourceId(aka string, client *apiClient.Client) (string, error) {
+	if isResourceId(aka) {
+		return aka, nil
+	}
+	resource, err := client.ReadResource(aka, nil)
+	if err != nil {
+		return "", fmt.Errorf("error resolving '%s': %w", aka, err)
+	}
+	return resource.Turbot.Id, nil
+}
+
+// grants are identified by the ids of the profile, permission type and permission level
+func grantKey(profileId, permissionTypeId, permissionLevelId string) string {
+	return fmt.Sprintf("%s|%s|%s", profileId, permissionTypeId, permissionLevelId)
+}
+
+func resolveGrantKey(grant map[string]interface{}, resolver *akaResolver) (string, error) {
+	var ids []string
+	for _, attribute := range []string{"identity", "type", "level"} {
+		id, err := resolver.resolve(grant[attribute].(string))
+		if err != nil {
+			return "", err
+		}
+		ids = append(ids, id)
+	}
+	return grantKey(ids[0], ids[1], ids[2]), nil
+}
+
+// resolve akas to resource ids, caching the results
+type akaResolver struct {
+	client *apiClient.Client
+	ids    map[string]string
+}
+
+func newAkaResolver(client *apiClient.Client) *akaResolver {
+	return &akaResolver{client: client, ids: map[string]string{}}
+}
+
+func (r *akaResolver) resolve(aka string) (string, error) {
+	if id, ok := r.ids[aka]; ok {
+		return id, nil
+	}
+	resource, err := r.client.ReadResource(aka, nil)
+	if err != nil {
+		return "", fmt.Errorf("error resolving '%s': %w", aka, err)
+	}
+	r.ids[aka] = resource.Turbot.Id
+	return resource.Turbot.Id, nil
+}
@@ -0,0 +1,153 @@
+package turbot
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"testing"
+)
+
+func TestAccResourceGrants_Basic(t *testing.T) {
+	resourceName := "turbot_resource_grants.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceGrantsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceGrantsConfig(`
+	grant {
+		identity = turbot_profile.test_profile.id
+		type     = "tmod:@turbot/turbot-iam#/permission/types/turbot"
+		level    = "tmod:@turbot/turbot-iam#/permission/levels/owner"
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "grant.#", "1"),
+					testAccCheckResourceGrantCount(resourceName, 1),
+				),
+			},
+			{
+				// the imported grants use ids rather than akas, so only the number of grants is checked
+				ResourceName:     resourceName,
+				ImportState:      true,
+				ImportStateCheck: testAccCheckImportedGrantCount(1),
+			},
+			{
+				// import using an aka of the resource
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccResourceGrantsFolderAka,
+				ImportStateCheck:  testAccCheckImportedGrantCount(1),
+			},
+			{
+				Config: testAccResourceGrantsConfig(`
+	grant {
+		identity = turbot_profile.test_profile.id
+		type     = "tmod:@turbot/turbot-iam#/permission/types/turbot"
+		level    = "tmod:@turbot/turbot-iam#/permission/levels/admin"
+	}
+	grant {
+		identity = turbot_profile.test_profile.id
+		type     = "tmod:@turbot/turbot-iam#/permission/types/turbot"
+		level    = "tmod:@turbot/turbot-iam#/permission/levels/metadata"
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "grant.#", "2"),
+					// the owner grant must have been removed
+					testAccCheckResourceGrantCount(resourceName, 2),
+				),
+			},
+		},
+	})
+}
+
+// check the number of grants which exist on the resource
+func testAccCheckResourceGrantCount(resource string, expected int) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resource)
+		}
+		client := testAccProvider.Meta().(*apiClient.Client)
+		grants, err := readResourceGrants(rs.Primary.ID, client)
+		if err != nil {
+			return err
+		}
+		if len(grants) != expected {
+			return fmt.Errorf("expected %d grants on %s, got %d", expected, rs.Primary.ID, len(grants))
+		}
+		return nil
+	}
+}
+
+func testAccResourceGrantsFolderAka(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources["turbot_folder.test"]
+	if !ok {
+		return "", fmt.Errorf("not found: turbot_folder.test")
+	}
+	var akas []string
+	if err := json.Unmarshal([]byte(rs.Primary.Attributes["turbot.akas"]), &akas); err != nil || len(akas) == 0 {
+		return "", fmt.Errorf("turbot_folder.test has no akas")
+	}
+	return akas[0], nil
+}
+
+func testAccCheckImportedGrantCount(expected int) resource.ImportStateCheckFunc {
+	return func(states []*terraform.InstanceState) error {
+		if len(states) != 1 {
+			return fmt.Errorf("expected 1 imported resource, got %d", len(states))
+		}
+		if count := states[0].Attributes["grant.#"]; count != fmt.Sprint(expected) {
+			return fmt.Errorf("expected %d imported grants, got %s", expected, count)
+		}
+		return nil
+	}
+}
+
+func testAccCheckResourceGrantsDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*apiClient.Client)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "turbot_resource_grants" {
+			grants, err := readResourceGrants(rs.Primary.ID, client)
+			if err != nil {
+				if apiClient.NotFoundError(err) {
+					continue
+				}
+				return err
+			}
+			if len(grants) > 0 {
+				return fmt.Errorf("%d grants still exist on %s", len(grants), rs.Primary.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// configs
+func testAccResourceGrantsConfig(grants string) string {
+	return fmt.Sprintf(`
+resource "turbot_profile" "test_profile" {
+	title             = "provider_test"
+	email             = "rupesh@turbot.com"
+	directory_pool_id = "dpi"
+	given_name 		  = "rupesh"
+	family_name       = "patil"
+	display_name      = "rupesh"
+	parent            = "184227597889872"
+	profile_id        = "170759063660234"
+}
+
+resource "turbot_folder" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	title = "provider_test"
+	description = "test folder"
+}
+
+resource "turbot_resource_grants" "test" {
+	resource = turbot_folder.test.id
+%s
+}
+`, grants)
+}
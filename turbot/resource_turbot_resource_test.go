@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
 	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+	"regexp"
 	"testing"
 )
 
@@ -27,6 +28,12 @@ func TestAccResourceFolder_Basic(t *testing.T) {
 						"turbot_resource.test", "data", helpers.FormatJson(folderData)),
 					resource.TestCheckResourceAttr(
 						"turbot_resource.test", "metadata", helpers.FormatJson(metadata)),
+					resource.TestCheckResourceAttr(
+						"turbot_resource.test", "object.title", "provider_test"),
+					resource.TestCheckResourceAttrSet(
+						"turbot_resource.test", "turbot.parent_id"),
+					resource.TestCheckResourceAttrSet(
+						"turbot_resource.test", "turbot.create_timestamp"),
 				),
 			},
 			{
@@ -55,6 +62,8 @@ func TestAccResourceFolder_Basic(t *testing.T) {
 						"turbot_resource.test", "data", helpers.FormatJson(folderDataUpdatedTitle)),
 					resource.TestCheckResourceAttr(
 						"turbot_resource.test", "metadata", helpers.FormatJson(metadata)),
+					resource.TestCheckResourceAttr(
+						"turbot_resource.test", "object.title", "provider_test_updated"),
 				),
 			},
 			{
@@ -105,6 +114,248 @@ func TestAccResourceFolder_Account(t *testing.T) {
 	})
 }
 
+func TestAccResourceFolder_InvalidData(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceConfigFolder(folderType, folderDataInvalidTitle, metadata),
+				ExpectError: regexp.MustCompile("data.title: expected type string but got number"),
+			},
+		},
+	})
+}
+
+func TestAccResourceFolder_UnknownProperties(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceConfigUnknownProperties(folderType, folderDataMisspeltDescription, "error"),
+				ExpectError: regexp.MustCompile("data.descriptoin: property is not defined by the schema - did you mean 'description'"),
+			},
+			{
+				Config: testAccResourceConfigUnknownProperties(folderType, folderDataMisspeltDescription, "warn"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists("turbot_resource.test"),
+					resource.TestCheckResourceAttr("turbot_resource.test", "unknown_property_count", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceFolder_IgnoreProperties(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceConfigIgnoreProperties(folderType, folderData),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists("turbot_resource.test"),
+					resource.TestCheckResourceAttr("turbot_resource.test", "object.description", "test resource"),
+				),
+			},
+			// a change to an ignored property does not cause a diff
+			{
+				Config:   testAccResourceConfigIgnoreProperties(folderType, folderDataUpdatedDescription),
+				PlanOnly: true,
+			},
+			// other properties are still updated, leaving the ignored property unchanged
+			{
+				Config: testAccResourceConfigIgnoreProperties(folderType, folderDataUpdatedTitleAndDescription),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists("turbot_resource.test"),
+					resource.TestCheckResourceAttr("turbot_resource.test", "object.title", "provider_test_updated"),
+					testAccCheckResourceProperty("turbot_resource.test", "description", "test resource"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceFolder_DependsOnControl(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceConfigDependsOnControl(folderType, folderData),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists("turbot_resource.test"),
+					resource.TestCheckResourceAttr(
+						"turbot_resource.test", "depends_on_control.#", "1"),
+					resource.TestCheckResourceAttr(
+						"turbot_resource.test", "depends_on_control.0.type", "tmod:@turbot/turbot#/control/types/turbotWorkspaceConfigured"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceFolder_ParentPath(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceDestroy,
+		Steps: []resource.TestStep{
+			// the folders in a parent path must exist when the plan is made
+			{
+				Config: testAccResourceParentPathFolderConfig,
+			},
+			{
+				Config:      testAccResourceConfigParentPath("turbot:/parent_path_test/missing", folderType, folderData),
+				ExpectError: regexp.MustCompile("folder 'missing' not found"),
+			},
+			{
+				Config: testAccResourceConfigParentPath("turbot:/parent_path_test", folderType, folderData),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists("turbot_resource.test"),
+					resource.TestCheckResourceAttrPair("turbot_resource.test", "parent", "turbot_folder.parent", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceFolder_RootParent(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceConfigRootParent(folderType, folderData),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists("turbot_resource.test"),
+					resource.TestCheckResourceAttrPair("turbot_resource.test", "parent", "data.turbot_resource_akas.root", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceFolder_DataMap(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceConfigDataMap("provider_test"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists("turbot_resource.test"),
+					resource.TestCheckResourceAttr(
+						"turbot_resource.test", "data_map.title", "provider_test"),
+					resource.TestCheckResourceAttr(
+						"turbot_resource.test", "metadata_map.c1", "custom1"),
+				),
+			},
+			{
+				Config: testAccResourceConfigDataMap("provider_test_updated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists("turbot_resource.test"),
+					resource.TestCheckResourceAttr(
+						"turbot_resource.test", "data_map.title", "provider_test_updated"),
+				),
+			},
+			{
+				Config:      testAccResourceConfigDataAndDataMap(),
+				ExpectError: regexp.MustCompile("only one of 'data' or 'data_map' may be set"),
+			},
+		},
+	})
+}
+
+func TestAccResourceFolder_FullResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceConfigFullResource(folderData),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists("turbot_resource.test"),
+					testAccCheckResourceDataProperty("turbot_resource.test", "description", true),
+				),
+			},
+			{
+				// the description is removed from the data, so is deleted from the resource
+				Config: testAccResourceConfigFullResource(folderDataNoDescription),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"turbot_resource.test", "data", helpers.FormatJson(folderDataNoDescription)),
+					testAccCheckResourceDataProperty("turbot_resource.test", "description", false),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceFolder_Akas(t *testing.T) {
+	resourceName := "turbot_resource.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceConfigAkas(folderData),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists("turbot_resource.test"),
+					resource.TestCheckResourceAttr(
+						"turbot_resource.test", "akas.#", "1"),
+					resource.TestCheckResourceAttr(
+						"turbot_resource.test", "akas.0", "tf_provider_test_aka"),
+				),
+			},
+			{
+				// import using the aka
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateId:           "tf_provider_test_aka",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"parent", "data_source", "object", "on_external_change", "full_resource", "skip_validation", "unknown_properties", "unknown_property_count", "allow_duplicate_titles"},
+			},
+		},
+	})
+}
+
+func TestAccResourceFolder_Yaml(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceConfigFolder(folderType, folderDataYaml, metadata),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists("turbot_resource.test"),
+					resource.TestCheckResourceAttr(
+						"turbot_resource.test", "data", helpers.FormatJson(folderData)),
+					resource.TestCheckResourceAttr(
+						"turbot_resource.test", "data_source", folderDataYaml),
+					resource.TestCheckResourceAttr(
+						"turbot_resource.test", "object.title", "provider_test"),
+				),
+			},
+			// the equivalent JSON data does not cause a diff
+			{
+				Config:   testAccResourceConfigFolder(folderType, folderData, metadata),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 // configs
 var folderType = `tmod:@turbot/turbot#/resource/types/folder`
 var accountType = `tmod:@turbot/aws#/resource/types/account`
@@ -126,6 +377,9 @@ var folderData = `{
  "description": "test resource"
 }
 `
+var folderDataYaml = `title: provider_test
+description: test resource
+`
 var metadata = `{
  "c1": "custom1",
  "c2": "custom2"
@@ -136,11 +390,30 @@ var metadataUpdated = `{
  "c2": "custom3"
 }
 `
+var folderDataInvalidTitle = `{
+ "title": 123,
+ "description": "test resource"
+}
+`
+var folderDataMisspeltDescription = `{
+ "title": "provider_test",
+ "descriptoin": "test resource"
+}
+`
 var folderDataUpdatedTitle = `{
  "title": "provider_test_updated",
  "description": "test resource"
 }
 `
+var folderDataUpdatedTitleAndDescription = `{
+ "title": "provider_test_updated",
+ "description": "test resource_updated"
+}
+`
+var folderDataNoDescription = `{
+ "title": "provider_test"
+}
+`
 var folderDataUpdatedDescription = `{
  "title": "provider_test",
  "description": "test resource_updated"
@@ -161,6 +434,141 @@ resource "turbot_resource" "test" {
 	return config
 }
 
+func testAccResourceConfigUnknownProperties(resourceType, data, mode string) string {
+	config := fmt.Sprintf(`
+resource "turbot_resource" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	type = "%s"
+	unknown_properties = "%s"
+	data =  <<EOF
+%sEOF
+}
+`, resourceType, mode, data)
+	return config
+}
+
+func testAccResourceConfigIgnoreProperties(resourceType, data string) string {
+	config := fmt.Sprintf(`
+resource "turbot_resource" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	type = "%s"
+	ignore_properties = ["$.description"]
+	data =  <<EOF
+%sEOF
+}
+`, resourceType, data)
+	return config
+}
+
+func testAccResourceConfigDependsOnControl(resourceType, data string) string {
+	config := fmt.Sprintf(`
+resource "turbot_resource" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	type = "%s"
+	data =  <<EOF
+%sEOF
+	depends_on_control {
+		type     = "tmod:@turbot/turbot#/control/types/turbotWorkspaceConfigured"
+		resource = "tmod:@turbot/turbot#/"
+	}
+}
+`, resourceType, data)
+	return config
+}
+
+const testAccResourceParentPathFolderConfig = `
+resource "turbot_folder" "parent" {
+	parent = "tmod:@turbot/turbot#/"
+	title = "parent_path_test"
+	description = "test folder for turbot terraform provider"
+}
+`
+
+func testAccResourceConfigParentPath(parent, resourceType, data string) string {
+	config := fmt.Sprintf(`
+resource "turbot_resource" "test" {
+	parent = "%s"
+	type = "%s"
+	data =  <<EOF
+%sEOF
+	depends_on = [turbot_folder.parent]
+}
+`, parent, resourceType, data)
+	return testAccResourceParentPathFolderConfig + config
+}
+
+func testAccResourceConfigRootParent(resourceType, data string) string {
+	config := fmt.Sprintf(`
+data "turbot_resource_akas" "root" {
+	aka = "tmod:@turbot/turbot#/"
+}
+resource "turbot_resource" "test" {
+	parent = "turbot"
+	type = "%s"
+	data =  <<EOF
+%sEOF
+}
+`, resourceType, data)
+	return config
+}
+
+func testAccResourceConfigDataMap(title string) string {
+	config := fmt.Sprintf(`
+resource "turbot_resource" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	type = "tmod:@turbot/turbot#/resource/types/folder"
+	data_map = {
+		title = "%s"
+		description = "test resource"
+	}
+	metadata_map = {
+		c1 = "custom1"
+		c2 = jsonencode({ nested = "custom2" })
+	}
+}
+`, title)
+	return config
+}
+
+func testAccResourceConfigDataAndDataMap() string {
+	return `
+resource "turbot_resource" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	type = "tmod:@turbot/turbot#/resource/types/folder"
+	data = jsonencode({ title = "provider_test" })
+	data_map = {
+		title = "provider_test"
+	}
+}
+`
+}
+
+func testAccResourceConfigFullResource(data string) string {
+	config := fmt.Sprintf(`
+resource "turbot_resource" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	type = "tmod:@turbot/turbot#/resource/types/folder"
+	full_resource = true
+	data =  <<EOF
+%sEOF
+}
+`, data)
+	return config
+}
+
+func testAccResourceConfigAkas(data string) string {
+	config := fmt.Sprintf(`
+resource "turbot_resource" "test" {
+	parent = "tmod:@turbot/turbot#/"
+	type = "tmod:@turbot/turbot#/resource/types/folder"
+	akas = ["tf_provider_test_aka"]
+	data =  <<EOF
+%sEOF
+}
+`, data)
+	return config
+}
+
 func testAccResourceConfigAccount(resourceType, metadata, data string) string {
 	config := fmt.Sprintf(`
 resource "turbot_folder" "test" {
@@ -180,6 +588,25 @@ resource "turbot_resource" "account_resource" {
 }
 
 // helper functions
+// check the value of a property of the resource in Turbot
+func testAccCheckResourceProperty(resource, property, expected string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resource)
+		}
+		client := testAccProvider.Meta().(*apiClient.Client)
+		turbotResource, err := client.ReadResource(rs.Primary.ID, map[string]string{"value": property})
+		if err != nil {
+			return err
+		}
+		if value := helpers.InterfaceToString(turbotResource.Data["value"]); value != expected {
+			return fmt.Errorf("expected %s to be '%s', got '%s'", property, expected, value)
+		}
+		return nil
+	}
+}
+
 func testAccCheckResourceExists(resource string) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		rs, ok := state.RootModule().Resources[resource]
@@ -198,6 +625,25 @@ func testAccCheckResourceExists(resource string) resource.TestCheckFunc {
 	}
 }
 
+// check whether the resource in Turbot has the given data property
+func testAccCheckResourceDataProperty(resource, property string, expected bool) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resource)
+		}
+		client := testAccProvider.Meta().(*apiClient.Client)
+		result, err := client.ReadResource(rs.Primary.ID, map[string]string{property: property})
+		if err != nil {
+			return fmt.Errorf("error fetching item with resource %s. %s", resource, err)
+		}
+		if actual := result.Data[property] != nil; actual != expected {
+			return fmt.Errorf("expected property %s to be present: %t, got %t", property, expected, actual)
+		}
+		return nil
+	}
+}
+
 func testAccCheckResourceDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*apiClient.Client)
 	for _, rs := range s.RootModule().Resources {
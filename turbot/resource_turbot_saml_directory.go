@@ -1,6 +1,7 @@
 package turbot
 
 import (
+	"github.com/hashicorp/terraform/helper/customdiff"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
 	"github.com/terraform-providers/terraform-provider-turbot/helpers"
@@ -27,21 +28,12 @@ func resourceTurbotSamlDirectory() *schema.Resource {
 			State: resourceTurbotSamlDirectoryImport,
 		},
 		Schema: map[string]*schema.Schema{
-			// aka of the parent resourcesamlDirectoryProperties
+			// aka of the parent resource - if not set, the provider default_parent is used
 			"parent": {
 				Type:     schema.TypeString,
-				Required: true,
-				// when doing a diff, the state file will contain the id of the parent but he config contains the aka,
-				// so we need custom diff code
-				DiffSuppressFunc: suppressIfAkaMatches("parent_akas"),
-			},
-			// when doing a read, fetch the parent akas to use in suppressIfAkaMatches("parent_akas")()
-			"parent_akas": {
-				Type:     schema.TypeList,
+				Optional: true,
+				// the state holds the id of the parent, which parentCustomizeDiff compares to the configured parent
 				Computed: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
 			},
 			"title": {
 				Type:     schema.TypeString,
@@ -124,7 +116,14 @@ func resourceTurbotSamlDirectory() *schema.Resource {
 				Type:     schema.TypeMap,
 				Optional: true,
 			},
+			// the tags applied in Turbot, including the provider default_tags
+			"tags_all": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+			"turbot": turbotMetadataSchema(),
 		},
+		CustomizeDiff: customdiff.All(validateParent, parentCustomizeDiff, tagsCustomizeDiff, turbotMetadataCustomizeDiff),
 	}
 }
 
@@ -136,8 +135,12 @@ func resourceTurbotSamlDirectoryExists(d *schema.ResourceData, meta interface{})
 
 func resourceTurbotSamlDirectoryCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
+	if err := setParent(d, meta); err != nil {
+		return err
+	}
 
 	input := mapFromResourceData(d, samlDirectoryInputProperties)
+	input["tags"] = buildTagsInput(d, meta)
 	// set computed properties
 	input["status"] = "ACTIVE"
 	samlDirectory, err := client.CreateSamlDirectory(input)
@@ -145,12 +148,9 @@ func resourceTurbotSamlDirectoryCreate(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
-	// set parent_akas property by loading parent resource and fetching the akas
-	if err := storeAkas(samlDirectory.Turbot.ParentId, "parent_akas", d, meta); err != nil {
-		return err
-	}
 	// assign the id
 	d.SetId(samlDirectory.Turbot.Id)
+	storeTurbotMetadata(d, samlDirectory.Turbot)
 	// assign Read query properties
 	d.Set("status", strings.ToUpper(samlDirectory.Status))
 	d.Set("parent", samlDirectory.Parent)
@@ -166,16 +166,13 @@ func resourceTurbotSamlDirectoryRead(d *schema.ResourceData, meta interface{}) e
 	samlDirectory, err := client.ReadSamlDirectory(id)
 	if err != nil {
 		if apiClient.NotFoundError(err) {
-			// saml directory was not found - clear id
+			// saml directory was not found - remove it from the state
 			d.SetId("")
+			return nil
 		}
 		return err
 	}
 
-	// set parent_akas property by loading parent resource and fetching the akas
-	if err := storeAkas(samlDirectory.Turbot.ParentId, "parent_akas", d, meta); err != nil {
-		return err
-	}
 	// assign results back into ResourceData
 	d.Set("parent", samlDirectory.Parent)
 	d.Set("title", samlDirectory.Title)
@@ -192,12 +189,16 @@ func resourceTurbotSamlDirectoryRead(d *schema.ResourceData, meta interface{}) e
 	d.Set("allow_idp_initiated_sso", samlDirectory.AllowIdpInitiatedSso)
 	d.Set("profile_groups_attribute", samlDirectory.ProfileGroupsAttribute)
 	d.Set("group_filter", samlDirectory.GroupFilter)
-	d.Set("tags", samlDirectory.Turbot.Tags)
+	storeTags(d, samlDirectory.Turbot.Tags, meta)
+	storeTurbotMetadata(d, samlDirectory.Turbot)
 	return nil
 }
 
 func resourceTurbotSamlDirectoryUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
+	if err := setParent(d, meta); err != nil {
+		return err
+	}
 
 	input := mapFromResourceData(d, getSamlDirectoryProperties())
 	input["id"] = d.Id()
@@ -207,20 +208,24 @@ func resourceTurbotSamlDirectoryUpdate(d *schema.ResourceData, meta interface{})
 	if err != nil {
 		return err
 	}
+	// the directory update mutation does not accept tags
+	if err := updateResourceTags(d, meta); err != nil {
+		return err
+	}
 
 	// assign Read query properties
 	d.Set("parent", samlDirectory.Parent)
 	d.Set("title", samlDirectory.Title)
 	d.Set("description", samlDirectory.Description)
-	// set parent_akas property by loading parent resource and fetching the akas
-	return storeAkas(samlDirectory.Turbot.ParentId, "parent_akas", d, meta)
+	storeTurbotMetadata(d, samlDirectory.Turbot)
+	return nil
 }
 
 func resourceTurbotSamlDirectoryDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
 	id := d.Id()
 	err := client.DeleteResource(id)
-	if err != nil {
+	if err != nil && !apiClient.NotFoundError(err) {
 		return err
 	}
 
@@ -2,7 +2,6 @@ package turbot
 
 import (
 	"fmt"
-	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
 	"time"
@@ -51,11 +50,14 @@ func resourceTurbotShadowResourceCreate(d *schema.ResourceData, meta interface{}
 	}
 
 	var turbotResource *apiClient.Resource
-	var err error
+	var lastErr error
 	errorCount := 0
 	maxErrorRetries := 5
-	err = resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
-		turbotResource, err = getResource(filter, resourceAka, client)
+	timeout := d.Timeout(schema.TimeoutCreate)
+	watch := apiClient.NewWatch()
+	found, err := pollWithWatch(watch, timeout, 500*time.Millisecond, 10*time.Second, func() (bool, error) {
+		var err error
+		turbotResource, err = getResource(filter, resourceAka, watch, client)
 		// when we get NotFoundError, we retry for the timeout determined by the parameter TimeoutCreate, controlled by the config parameters timeouts.create (defaulting to 5 minutes). For other random/transient errors retry 5 times (maxErrorRetries)
 		if err != nil {
 			if apiClient.NotFoundError(err) {
@@ -64,12 +66,21 @@ func resourceTurbotShadowResourceCreate(d *schema.ResourceData, meta interface{}
 				errorCount++
 			}
 			if errorCount == maxErrorRetries {
-				return resource.NonRetryableError(err)
+				return false, err
 			}
-			return resource.RetryableError(err)
+			lastErr = err
+			return false, nil
 		}
-		return nil
+		// the resource has not been discovered yet
+		if turbotResource == nil {
+			lastErr = fmt.Errorf("no resource found matching %s", shadowResourceTarget(filter, resourceAka))
+			return false, nil
+		}
+		return true, nil
 	})
+	if err == nil && !found {
+		err = lastErr
+	}
 	if err != nil {
 		return fmt.Errorf("turbot shadow resource creation failed: %s", err)
 	}
@@ -78,9 +89,18 @@ func resourceTurbotShadowResourceCreate(d *schema.ResourceData, meta interface{}
 	return nil
 }
 
-func getResource(filter, resourceAka string, client *apiClient.Client) (*apiClient.Resource, error) {
+func shadowResourceTarget(filter, resourceAka string) string {
+	if resourceAka != "" {
+		return fmt.Sprintf("resource \"%s\"", resourceAka)
+	}
+	return fmt.Sprintf("filter \"%s\"", filter)
+}
+
+// get the resource with the given aka, or the single resource matching the filter, returning nil if there is none
+// if watch is not nil, the read waits for the result to change (see apiClient.Watch)
+func getResource(filter, resourceAka string, watch *apiClient.Watch, client *apiClient.Client) (*apiClient.Resource, error) {
 	if resourceAka != "" {
-		resource, err := client.ReadResource(resourceAka, nil)
+		resource, err := client.WatchResource(watch, resourceAka, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -89,7 +109,7 @@ func getResource(filter, resourceAka string, client *apiClient.Client) (*apiClie
 		}
 		return nil, nil
 	}
-	resourceList, err := client.ReadResourceList(filter, nil)
+	resourceList, err := client.WatchResourceList(watch, filter, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -5,6 +5,7 @@ import (
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"regexp"
 	"testing"
 )
 
@@ -25,6 +26,19 @@ func TestAccShadowResource_Basic(t *testing.T) {
 	})
 }
 
+func TestAccShadowResource_FilterTimeout(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccShadowResourceFilterConfig(),
+				ExpectError: regexp.MustCompile("no resource found matching filter"),
+			},
+		},
+	})
+}
+
 // configs
 func testAccShadowResourceConfig() string {
 	return fmt.Sprintf(`
@@ -50,6 +64,16 @@ resource "turbot_shadow_resource" "shadow_resource" {
 }`)
 }
 
+func testAccShadowResourceFilterConfig() string {
+	return `
+resource "turbot_shadow_resource" "shadow_resource" {
+  filter   = "resourceType:folder title:provider-test-never-discovered"
+  timeouts {
+    create = "10s"
+  }
+}`
+}
+
 // helper functions
 func testAccCheckShadowResourceExists(resource string) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
@@ -1,6 +1,7 @@
 package turbot
 
 import (
+	"github.com/hashicorp/terraform/helper/customdiff"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
 	"github.com/terraform-providers/terraform-provider-turbot/helpers"
@@ -25,21 +26,12 @@ func resourceTurbotSmartFolder() *schema.Resource {
 			State: resourceTurbotSmartFolderImport,
 		},
 		Schema: map[string]*schema.Schema{
-			//aka of the parent resource
+			// aka of the parent resource - if not set, the provider default_parent is used
 			"parent": {
 				Type:     schema.TypeString,
-				Required: true,
-				// when doing a diff, the state file will contain the id of the parent but the config contains the aka,
-				// so we need custom diff code
-				DiffSuppressFunc: suppressIfAkaMatches("parent_akas"),
-			},
-			//when doing a read, fetch the parent akas to use in suppressIfAkaMatches
-			"parent_akas": {
-				Type:     schema.TypeList,
+				Optional: true,
+				// the state holds the id of the parent, which parentCustomizeDiff compares to the configured parent
 				Computed: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
 			},
 			"title": {
 				Type:     schema.TypeString,
@@ -53,7 +45,19 @@ func resourceTurbotSmartFolder() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			// number of resources the smart folder is currently attached to
+			"attached_resource_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			// number of policy settings defined on the smart folder
+			"policy_setting_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"turbot": turbotMetadataSchema(),
 		},
+		CustomizeDiff: customdiff.All(validateParent, parentCustomizeDiff, turbotMetadataCustomizeDiff),
 	}
 }
 
@@ -65,6 +69,9 @@ func resourceTurbotSmartFolderExists(d *schema.ResourceData, meta interface{}) (
 
 func resourceTurbotSmartFolderCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
+	if err := setParent(d, meta); err != nil {
+		return err
+	}
 	// build map of folder properties
 	input := mapFromResourceData(d, smartFolderProperties)
 
@@ -103,17 +110,14 @@ func resourceTurbotSmartFolderRead(d *schema.ResourceData, meta interface{}) err
 	smartFolder, err := client.ReadSmartFolder(id)
 	if err != nil {
 		if apiClient.NotFoundError(err) {
-			// folder was not found - clear id
+			// folder was not found - remove it from the state
 			d.SetId("")
+			return nil
 		}
 		return err
 	}
 
 	// assign results back into ResourceData
-	// set parent_akas property by loading resource and fetching the akas
-	if err := storeAkas(smartFolder.Turbot.ParentId, "parent_akas", d, meta); err != nil {
-		return err
-	}
 	// NOTE currently turbot accepts array of filters but only uses the first
 	if len(smartFolder.Filters) > 0 {
 		d.Set("filter", smartFolder.Filters[0])
@@ -121,7 +125,13 @@ func resourceTurbotSmartFolderRead(d *schema.ResourceData, meta interface{}) err
 	d.Set("parent", smartFolder.Parent)
 	d.Set("title", smartFolder.Title)
 	d.Set("description", smartFolder.Description)
+	d.Set("attached_resource_count", smartFolder.AttachedResources.Metadata.Stats.Total)
+	d.Set("policy_setting_count", smartFolder.PolicySettingCount)
+	if len(smartFolder.Filters) > 0 && smartFolder.AttachedResources.Metadata.Stats.Total == 0 {
+		client.Logf(helpers.LogResources, "[WARN] smart folder %s has filter '%s' but is not attached to any resources", id, smartFolder.Filters[0])
+	}
 
+	storeTurbotMetadata(d, smartFolder.Turbot)
 	return nil
 }
 
@@ -129,7 +139,7 @@ func resourceTurbotSmartFolderDelete(d *schema.ResourceData, meta interface{}) e
 	client := meta.(*apiClient.Client)
 	id := d.Id()
 	err := client.DeleteResource(id)
-	if err != nil {
+	if err != nil && !apiClient.NotFoundError(err) {
 		return err
 	}
 
@@ -50,7 +50,11 @@ func resourceTurbotSmartFolderAttachmentExists(d *schema.ResourceData, meta inte
 	// execute api call
 	smartFolder, err := client.ReadSmartFolder(smartFolderId)
 	if err != nil {
-		return false, fmt.Errorf("error reading smart folder: %s", err.Error())
+		// if the smart folder has been deleted, the attachment no longer exists
+		if apiClient.NotFoundError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("error reading smart folder: %w", err)
 	}
 
 	//find resource aka in list of attached resources
@@ -113,7 +117,7 @@ func resourceTurbotSmartFolderAttachmentDelete(d *schema.ResourceData, meta inte
 	client := meta.(*apiClient.Client)
 	input := mapFromResourceDataWithPropertyMap(d, smartFolderAttachProperties)
 	err := client.DeleteSmartFolderAttachment(input)
-	if err != nil {
+	if err != nil && !apiClient.NotFoundError(err) {
 		return err
 	}
 
@@ -133,8 +137,9 @@ func buildId(smartFolder, resource string) string {
 	return smartFolder + "_" + resource
 }
 
+// the smart folder is always an id, but the resource may be an aka containing underscores
 func parseSmartFolderId(id string) (smartFolder, resource string) {
-	segments := strings.Split(id, "_")
+	segments := strings.SplitN(id, "_", 2)
 	smartFolder = segments[0]
 	resource = segments[1]
 	return
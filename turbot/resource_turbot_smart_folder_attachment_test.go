@@ -21,6 +21,11 @@ func TestAccSmartFolderAttachment_Basic(t *testing.T) {
 					testAccCheckSmartFolderAttachmentExists("turbot_smart_folder_attachment.test"),
 				),
 			},
+			{
+				ResourceName:      "turbot_smart_folder_attachment.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
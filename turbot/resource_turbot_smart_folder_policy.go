@@ -0,0 +1,401 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/customdiff"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+	"reflect"
+	"sort"
+)
+
+// a smart folder and the policy settings defined on it, managed as a single resource
+// the smart folder is created before its settings and deleted after them
+// the id of the setting for each policy type is stored in the 'settings' map (policy type -> setting id)
+func resourceTurbotSmartFolderPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTurbotSmartFolderPolicyCreate,
+		Read:   resourceTurbotSmartFolderPolicyRead,
+		Update: resourceTurbotSmartFolderPolicyUpdate,
+		Delete: resourceTurbotSmartFolderPolicyDelete,
+		Exists: resourceTurbotSmartFolderPolicyExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceTurbotSmartFolderPolicyImport,
+		},
+		Schema: map[string]*schema.Schema{
+			// aka of the parent resource - if not set, the provider default_parent is used
+			"parent": {
+				Type:     schema.TypeString,
+				Optional: true,
+				// the state holds the id of the parent, which parentCustomizeDiff compares to the configured parent
+				Computed: true,
+			},
+			"title": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// the policy settings of the smart folder - at most one setting for each policy type
+			"policy_setting": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"precedence": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "REQUIRED",
+						},
+						"template": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"template_input": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressIfTemplateInputEquivalent,
+						},
+						"note": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			// map of policy type -> policy setting id
+			"settings": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		CustomizeDiff: customdiff.All(validateParent, parentCustomizeDiff, validateSmartFolderPolicySettingTypes),
+	}
+}
+
+// a smart folder can only have one setting for each policy type
+func validateSmartFolderPolicySettingTypes(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("policy_setting") {
+		return nil
+	}
+	types := map[string]bool{}
+	for _, element := range d.Get("policy_setting").([]interface{}) {
+		policyTypeUri := element.(map[string]interface{})["type"].(string)
+		if types[policyTypeUri] {
+			return attributeError("policy_setting", fmt.Errorf("policy type '%s' is set more than once", policyTypeUri))
+		}
+		types[policyTypeUri] = true
+	}
+	return nil
+}
+
+func resourceTurbotSmartFolderPolicyExists(d *schema.ResourceData, meta interface{}) (b bool, e error) {
+	client := meta.(*apiClient.Client)
+	id := d.Id()
+	return client.ResourceExists(id)
+}
+
+func resourceTurbotSmartFolderPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	if err := setParent(d, meta); err != nil {
+		return err
+	}
+	// build map of folder properties
+	input := mapFromResourceData(d, smartFolderProperties)
+
+	smartFolder, err := client.CreateSmartFolder(input)
+	if err != nil {
+		return err
+	}
+	id := smartFolder.Turbot.Id
+
+	// now create the settings - if any setting fails, delete the smart folder and the settings created so far,
+	// so a failed create does not leave a partially configured smart folder
+	settings := map[string]interface{}{}
+	blocks := smartFolderPolicySettingBlocks(d.Get("policy_setting"))
+	if err := createSmartFolderPolicySettings(id, sortedPolicyTypes(blocks), blocks, settings, client); err != nil {
+		if deleteErr := deleteSmartFolderPolicy(id, settings, client); deleteErr != nil {
+			client.Logf(helpers.LogResources, "[WARN] failed to delete smart folder %s after failing to create its policy settings: %s", id, deleteErr.Error())
+			// store the smart folder so it is deleted by the next apply
+			d.SetId(id)
+			d.Set("settings", settings)
+		}
+		return err
+	}
+
+	// assign the id
+	d.SetId(id)
+	return resourceTurbotSmartFolderPolicyRead(d, meta)
+}
+
+func resourceTurbotSmartFolderPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	id := d.Id()
+
+	smartFolder, err := client.ReadSmartFolder(id)
+	if err != nil {
+		if apiClient.NotFoundError(err) {
+			// folder was not found - remove it from the state
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	policySettings, err := client.ReadPolicySettingList(fmt.Sprintf("resource:%s level:self", id))
+	if err != nil {
+		return err
+	}
+
+	// assign results back into ResourceData
+	// NOTE currently turbot accepts array of filters but only uses the first
+	if len(smartFolder.Filters) > 0 {
+		d.Set("filter", smartFolder.Filters[0])
+	}
+	d.Set("parent", smartFolder.Parent)
+	d.Set("title", smartFolder.Title)
+	d.Set("description", smartFolder.Description)
+	return storeSmartFolderPolicySettings(d, policySettings)
+}
+
+func resourceTurbotSmartFolderPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	id := d.Id()
+
+	if d.HasChange("title") || d.HasChange("description") || d.HasChange("filter") {
+		// build map of folder properties
+		input := mapFromResourceData(d, getSmartFolderUpdateProperties())
+		input["id"] = id
+		if _, err := client.UpdateSmartFolder(input); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("policy_setting") {
+		old, new := d.GetChange("policy_setting")
+		oldBlocks, newBlocks := smartFolderPolicySettingBlocks(old), smartFolderPolicySettingBlocks(new)
+		policyTypes := sortedPolicyTypes(newBlocks)
+		settings := map[string]interface{}{}
+		for policyTypeUri, settingId := range d.Get("settings").(map[string]interface{}) {
+			settings[policyTypeUri] = settingId
+		}
+
+		// delete the settings which have been removed
+		for policyTypeUri, settingId := range settings {
+			if _, ok := newBlocks[policyTypeUri]; ok {
+				continue
+			}
+			if err := client.DeletePolicySetting(settingId.(string)); err != nil && !apiClient.NotFoundError(err) {
+				d.Set("settings", settings)
+				return err
+			}
+			delete(settings, policyTypeUri)
+		}
+
+		// update the settings which have changed
+		var newPolicyTypes []string
+		for _, policyTypeUri := range policyTypes {
+			settingId, ok := settings[policyTypeUri]
+			if !ok {
+				newPolicyTypes = append(newPolicyTypes, policyTypeUri)
+				continue
+			}
+			if reflect.DeepEqual(oldBlocks[policyTypeUri], newBlocks[policyTypeUri]) {
+				continue
+			}
+			input, err := buildSmartFolderPolicySettingInput(newBlocks[policyTypeUri])
+			if err != nil {
+				return err
+			}
+			input["id"] = settingId
+			if _, err := applyFanOutPolicySetting(input, client.UpdatePolicySetting); err != nil {
+				d.Set("settings", settings)
+				return fmt.Errorf("error updating policy setting %s for smart folder %s: %w", policyTypeUri, id, err)
+			}
+		}
+
+		// create the settings which have been added
+		err := createSmartFolderPolicySettings(id, newPolicyTypes, newBlocks, settings, client)
+		d.Set("settings", settings)
+		if err != nil {
+			return err
+		}
+	}
+	return resourceTurbotSmartFolderPolicyRead(d, meta)
+}
+
+func resourceTurbotSmartFolderPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	settings := d.Get("settings").(map[string]interface{})
+	if err := deleteSmartFolderPolicy(d.Id(), settings, client); err != nil {
+		// store the settings which have not been deleted
+		d.Set("settings", settings)
+		return err
+	}
+
+	// clear the id to show we have deleted
+	d.SetId("")
+
+	return nil
+}
+
+func resourceTurbotSmartFolderPolicyImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceTurbotSmartFolderPolicyRead(d, meta); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// convert the policy_setting list into a map of policy type -> policy_setting block
+func smartFolderPolicySettingBlocks(value interface{}) map[string]map[string]interface{} {
+	blocks := map[string]map[string]interface{}{}
+	for _, element := range value.([]interface{}) {
+		block := element.(map[string]interface{})
+		blocks[block["type"].(string)] = block
+	}
+	return blocks
+}
+
+func sortedPolicyTypes(blocks map[string]map[string]interface{}) []string {
+	var policyTypes []string
+	for policyTypeUri := range blocks {
+		policyTypes = append(policyTypes, policyTypeUri)
+	}
+	sort.Strings(policyTypes)
+	return policyTypes
+}
+
+// build the create/update input for a policy_setting block
+func buildSmartFolderPolicySettingInput(block map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	input := map[string]interface{}{
+		"precedence": block["precedence"],
+	}
+	for _, property := range []string{"value", "template", "note"} {
+		if value := block[property].(string); value != "" {
+			input[property] = value
+		}
+	}
+	if value := block["template_input"].(string); value != "" {
+		// NOTE: ParseYamlString doesn't validate input as valid YAML format, on error it returns value
+		input["templateInput"], err = helpers.ParseYamlString(value)
+	}
+	return input, err
+}
+
+// create a policy setting on the smart folder for each policy type, adding the setting ids to the settings map
+func createSmartFolderPolicySettings(smartFolderId string, policyTypes []string, blocks map[string]map[string]interface{}, settings map[string]interface{}, client *apiClient.Client) error {
+	for _, policyTypeUri := range policyTypes {
+		input, err := buildSmartFolderPolicySettingInput(blocks[policyTypeUri])
+		if err != nil {
+			return err
+		}
+		input["type"] = policyTypeUri
+		input["resource"] = smartFolderId
+		policySetting, err := applyFanOutPolicySetting(input, client.CreatePolicySetting)
+		if err != nil {
+			return fmt.Errorf("error creating policy setting %s for smart folder %s: %w", policyTypeUri, smartFolderId, err)
+		}
+		settings[policyTypeUri] = policySetting.Turbot.Id
+	}
+	return nil
+}
+
+// delete the policy settings and then the smart folder, removing each deleted setting from the settings map
+func deleteSmartFolderPolicy(smartFolderId string, settings map[string]interface{}, client *apiClient.Client) error {
+	for policyTypeUri, settingId := range settings {
+		if err := client.DeletePolicySetting(settingId.(string)); err != nil && !apiClient.NotFoundError(err) {
+			return err
+		}
+		delete(settings, policyTypeUri)
+	}
+	return client.DeleteResource(smartFolderId)
+}
+
+// store the policy settings of the smart folder - the settings are ordered as in the config,
+// followed by any settings which are not in the config (e.g. when importing), ordered by policy type
+func storeSmartFolderPolicySettings(d *schema.ResourceData, policySettings []apiClient.PolicySetting) error {
+	settingsByType := map[string]apiClient.PolicySetting{}
+	for _, setting := range policySettings {
+		// if there is more than one setting for a policy type, use the default setting
+		if existing, ok := settingsByType[setting.Type.Uri]; ok && existing.Default {
+			continue
+		}
+		settingsByType[setting.Type.Uri] = setting
+	}
+	configured := smartFolderPolicySettingBlocks(d.Get("policy_setting"))
+	var policyTypes []string
+	for _, element := range d.Get("policy_setting").([]interface{}) {
+		policyTypeUri := element.(map[string]interface{})["type"].(string)
+		if _, ok := settingsByType[policyTypeUri]; ok {
+			policyTypes = append(policyTypes, policyTypeUri)
+		}
+	}
+	var unconfigured []string
+	for policyTypeUri := range settingsByType {
+		if _, ok := configured[policyTypeUri]; !ok {
+			unconfigured = append(unconfigured, policyTypeUri)
+		}
+	}
+	sort.Strings(unconfigured)
+	policyTypes = append(policyTypes, unconfigured...)
+
+	var blocks []interface{}
+	settings := map[string]interface{}{}
+	for _, policyTypeUri := range policyTypes {
+		setting := settingsByType[policyTypeUri]
+		// NOTE: TemplateInput can be string or array of strings
+		// - In case of string, we return string
+		// - In array of strings, we return a valid YAML string
+		templateInput, err := helpers.InterfaceToStringOrYaml(setting.TemplateInput)
+		if err != nil {
+			return err
+		}
+		configuredValue := ""
+		if block, ok := configured[policyTypeUri]; ok {
+			configuredValue = block["value"].(string)
+		}
+		blocks = append(blocks, map[string]interface{}{
+			"type":           policyTypeUri,
+			"value":          smartFolderPolicySettingValue(configuredValue, setting),
+			"precedence":     setting.Precedence,
+			"template":       setting.Template,
+			"template_input": templateInput,
+			"note":           setting.Note,
+		})
+		settings[policyTypeUri] = setting.Turbot.Id
+	}
+	d.Set("policy_setting", blocks)
+	d.Set("settings", settings)
+	return nil
+}
+
+// if the configured value was applied as the setting's valueSource (see applyFanOutPolicySetting), Turbot returns
+// the value in a different format - keep the configured value if it is equivalent to the valueSource
+func smartFolderPolicySettingValue(configuredValue string, setting apiClient.PolicySetting) string {
+	value := helpers.InterfaceToString(setting.Value)
+	if configuredValue == "" || configuredValue == value || setting.ValueSource == "" {
+		return value
+	}
+	if equal, err := helpers.YamlStringsAreEqual(configuredValue, setting.ValueSource); err == nil && equal {
+		return configuredValue
+	}
+	return value
+}
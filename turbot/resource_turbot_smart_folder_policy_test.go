@@ -0,0 +1,125 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"testing"
+)
+
+// test suites
+func TestAccSmartFolderPolicy_Basic(t *testing.T) {
+	resourceName := "turbot_smart_folder_policy.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSmartFolderPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSmartFolderPolicyConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "title", "provider_test_smart_folder_policy"),
+					resource.TestCheckResourceAttr(resourceName, "policy_setting.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "policy_setting.0.type", stringPolicyType),
+					resource.TestCheckResourceAttr(resourceName, "policy_setting.0.value", "testValue"),
+					resource.TestCheckResourceAttr(resourceName, "policy_setting.1.type", intPolicyType),
+					resource.TestCheckResourceAttr(resourceName, "policy_setting.1.precedence", "RECOMMENDED"),
+					resource.TestCheckResourceAttr(resourceName, "settings.%", "2"),
+					testAccCheckSmartFolderPolicySettingCount(resourceName, 2),
+				),
+			},
+			{
+				Config: testAccSmartFolderPolicyUpdateConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", "Smart Folder Policy updated"),
+					resource.TestCheckResourceAttr(resourceName, "policy_setting.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "policy_setting.0.type", stringPolicyType),
+					resource.TestCheckResourceAttr(resourceName, "policy_setting.0.value", "testValue-updated"),
+					resource.TestCheckResourceAttr(resourceName, "settings.%", "1"),
+					testAccCheckSmartFolderPolicySettingCount(resourceName, 1),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// the configured parent is an aka, but the imported parent is the id
+				ImportStateVerifyIgnore: []string{"parent"},
+			},
+		},
+	})
+}
+
+// configs
+func testAccSmartFolderPolicyConfig() string {
+	return fmt.Sprintf(`
+resource "turbot_smart_folder_policy" "test" {
+	parent      = "tmod:@turbot/turbot#/"
+	title       = "provider_test_smart_folder_policy"
+	description = "Smart Folder Policy Testing"
+
+	policy_setting {
+		type  = "%s"
+		value = "testValue"
+	}
+
+	policy_setting {
+		type       = "%s"
+		value      = "1"
+		precedence = "RECOMMENDED"
+	}
+}
+`, stringPolicyType, intPolicyType)
+}
+
+func testAccSmartFolderPolicyUpdateConfig() string {
+	return fmt.Sprintf(`
+resource "turbot_smart_folder_policy" "test" {
+	parent      = "tmod:@turbot/turbot#/"
+	title       = "provider_test_smart_folder_policy"
+	description = "Smart Folder Policy updated"
+
+	policy_setting {
+		type  = "%s"
+		value = "testValue-updated"
+	}
+}
+`, stringPolicyType)
+}
+
+// helper functions
+func testAccCheckSmartFolderPolicySettingCount(resource string, expected int) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("not found: %s", resource)
+		}
+		client := testAccProvider.Meta().(*apiClient.Client)
+		smartFolder, err := client.ReadSmartFolder(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error fetching item with resource %s. %s", resource, err)
+		}
+		if smartFolder.PolicySettingCount != expected {
+			return fmt.Errorf("expected %d policy settings on the smart folder, got %d", expected, smartFolder.PolicySettingCount)
+		}
+		return nil
+	}
+}
+
+func testAccCheckSmartFolderPolicyDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*apiClient.Client)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "turbot_smart_folder_policy" {
+			_, err := client.ReadSmartFolder(rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("smart folder still exists")
+			}
+			if !apiClient.NotFoundError(err) {
+				return fmt.Errorf("expected 'not found' error, got %s", err)
+			}
+		}
+	}
+
+	return nil
+}
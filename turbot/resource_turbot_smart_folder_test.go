@@ -24,6 +24,8 @@ func TestAccSmartFolder_Basic(t *testing.T) {
 					resource.TestCheckResourceAttr("turbot_smart_folder.test", "description", "Smart Folder Testing"),
 					resource.TestCheckResourceAttr("turbot_smart_folder.test", "parent", "178806508050433"),
 					resource.TestCheckResourceAttr("turbot_smart_folder.test", "filter", "resourceType:181381985925765 $.turbot.tags.a:b"),
+					resource.TestCheckResourceAttrSet("turbot_smart_folder.test", "attached_resource_count"),
+					resource.TestCheckResourceAttr("turbot_smart_folder.test", "policy_setting_count", "0"),
 				),
 			},
 			{
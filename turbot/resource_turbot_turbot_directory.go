@@ -1,6 +1,7 @@
 package turbot
 
 import (
+	"github.com/hashicorp/terraform/helper/customdiff"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
 	"github.com/terraform-providers/terraform-provider-turbot/helpers"
@@ -25,33 +26,27 @@ func resourceTurbotTurbotDirectory() *schema.Resource {
 			State: resourceTurbotTurbotDirectoryImport,
 		},
 		Schema: map[string]*schema.Schema{
-			// aka of the parent resource
+			// aka of the parent resource - if not set, the provider default_parent is used
 			"parent": {
 				Type:     schema.TypeString,
-				Required: true,
-				// when doing a diff, the state file will contain the id of the parent but the config contains the aka,
-				// so we need custom diff code
-				DiffSuppressFunc: suppressIfAkaMatches("parent_akas"),
-			},
-			// when doing a read, fetch the parent akas to use in suppressIfAkaMatches
-			"parent_akas": {
-				Type:     schema.TypeList,
+				Optional: true,
+				// the state holds the id of the parent, which parentCustomizeDiff compares to the configured parent
 				Computed: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
 			},
 			"title": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			// profile_id_template and server cannot be updated, so changing them recreates the directory
 			"profile_id_template": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"server": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"description": {
 				Type:     schema.TypeString,
@@ -65,7 +60,14 @@ func resourceTurbotTurbotDirectory() *schema.Resource {
 				Type:     schema.TypeMap,
 				Optional: true,
 			},
+			// the tags applied in Turbot, including the provider default_tags
+			"tags_all": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+			"turbot": turbotMetadataSchema(),
 		},
+		CustomizeDiff: customdiff.All(validateParent, parentCustomizeDiff, tagsCustomizeDiff, turbotMetadataCustomizeDiff),
 	}
 }
 
@@ -77,8 +79,12 @@ func resourceTurbotTurbotDirectoryExists(d *schema.ResourceData, meta interface{
 
 func resourceTurbotTurbotDirectoryCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
+	if err := setParent(d, meta); err != nil {
+		return err
+	}
 	// build mutation input
 	input := mapFromResourceData(d, turbotDirectoryInputProperties)
+	input["tags"] = buildTagsInput(d, meta)
 	// set computed properties
 	input["status"] = "ACTIVE"
 
@@ -88,12 +94,9 @@ func resourceTurbotTurbotDirectoryCreate(d *schema.ResourceData, meta interface{
 		return err
 	}
 
-	// set parent_akas property by loading resource and fetching the akas
-	if err := storeAkas(turbotDirectory.Turbot.ParentId, "parent_akas", d, meta); err != nil {
-		return err
-	}
 	// assign the id
 	d.SetId(turbotDirectory.Turbot.Id)
+	storeTurbotMetadata(d, turbotDirectory.Turbot)
 	// assign properties coming back from create graphQl API
 	d.Set("parent", turbotDirectory.Turbot.ParentId)
 	d.Set("title", turbotDirectory.Title)
@@ -109,8 +112,9 @@ func resourceTurbotTurbotDirectoryRead(d *schema.ResourceData, meta interface{})
 	turbotDirectory, err := client.ReadTurbotDirectory(id)
 	if err != nil {
 		if apiClient.NotFoundError(err) {
-			// local directoery was not found - clear id
+			// local directoery was not found - remove it from the state
 			d.SetId("")
+			return nil
 		}
 		return err
 	}
@@ -121,16 +125,24 @@ func resourceTurbotTurbotDirectoryRead(d *schema.ResourceData, meta interface{})
 	d.Set("status", strings.ToUpper(turbotDirectory.Status))
 	d.Set("parent", turbotDirectory.Turbot.ParentId)
 	d.Set("profile_id_template", turbotDirectory.ProfileIdTemplate)
-	d.Set("tags", turbotDirectory.Turbot.Tags)
+	storeTags(d, turbotDirectory.Turbot.Tags, meta)
 	d.Set("server", turbotDirectory.Server)
-	// set parent_akas property by loading resource and fetching the akas
-	return storeAkas(turbotDirectory.Turbot.ParentId, "parent_akas", d, meta)
+	storeTurbotMetadata(d, turbotDirectory.Turbot)
+	return nil
 }
 
 func resourceTurbotTurbotDirectoryUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*apiClient.Client)
+	if err := setParent(d, meta); err != nil {
+		return err
+	}
 	// build mutation payload
 	input := mapFromResourceData(d, getTurbotDirectoryUpdateProperties())
+	if d.HasChange("tags") || d.HasChange("tags_all") {
+		input["tags"] = buildTagsUpdateInput(d, meta)
+	} else {
+		delete(input, "tags")
+	}
 	input["id"] = d.Id()
 
 	// do update
@@ -142,9 +154,9 @@ func resourceTurbotTurbotDirectoryUpdate(d *schema.ResourceData, meta interface{
 	// assign properties coming back from update graphQl API
 	d.Set("parent", turbotDirectory.Turbot.ParentId)
 	d.Set("title", turbotDirectory.Title)
-	d.Set("status", turbotDirectory.Status)
-	// set parent_akas property by loading resource and fetching the akas
-	return storeAkas(turbotDirectory.Turbot.ParentId, "parent_akas", d, meta)
+	d.Set("status", strings.ToUpper(turbotDirectory.Status))
+	storeTurbotMetadata(d, turbotDirectory.Turbot)
+	return nil
 }
 
 func resourceTurbotTurbotDirectoryImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -158,7 +170,7 @@ func resourceTurbotTurbotDirectoryDelete(d *schema.ResourceData, meta interface{
 	client := meta.(*apiClient.Client)
 	id := d.Id()
 	err := client.DeleteResource(id)
-	if err != nil {
+	if err != nil && !apiClient.NotFoundError(err) {
 		return err
 	}
 
@@ -48,6 +48,17 @@ func TestAccTurbotDirectory_Basic(t *testing.T) {
 						"turbot_turbot_directory.test", "tags.%", "1"),
 				),
 			},
+			{
+				// server cannot be updated, so the directory is recreated
+				Config: testAccTurbotDirectoryServerConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTurbotDirectoryExists("turbot_turbot_directory.test"),
+					resource.TestCheckResourceAttr(
+						"turbot_turbot_directory.test", "server", "test_updated"),
+					resource.TestCheckResourceAttr(
+						"turbot_turbot_directory.test", "tags.%", "1"),
+				),
+			},
 			{
 				ResourceName:            resourceName,
 				ImportState:             true,
@@ -95,6 +106,20 @@ func testAccTurbotDirectoryTagsConfig() string {
 }`
 }
 
+func testAccTurbotDirectoryServerConfig() string {
+	return `
+	resource "turbot_turbot_directory" "test" {
+	parent = "tmod:@turbot/turbot#/"
+  	title = "provider_test_refactor"
+  	description = "test directory"
+  	profile_id_template = "{{profile.email}}"
+  	server = "test_updated"
+	tags = {
+		dev = "prod"
+	}
+}`
+}
+
 // helper functions
 func testAccCheckTurbotDirectoryExists(resource string) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
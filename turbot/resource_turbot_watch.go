@@ -0,0 +1,144 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+)
+
+// properties which must be passed to a create/update call
+var watchProperties = []interface{}{"resource", "action", "filters"}
+
+func getWatchUpdateProperties() []interface{} {
+	excludedProperties := []string{"resource"}
+	return helpers.RemoveProperties(watchProperties, excludedProperties)
+}
+
+// a watch sends the events of a resource (and its descendants) which match the filters to an action,
+// e.g. to route notifications or trigger automation
+func resourceTurbotWatch() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTurbotWatchCreate,
+		Read:   resourceTurbotWatchRead,
+		Update: resourceTurbotWatchUpdate,
+		Delete: resourceTurbotWatchDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceTurbotWatchImport,
+		},
+		Schema: map[string]*schema.Schema{
+			// the id or aka of the watched resource
+			"resource": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressIfAkaMatches("resource_akas"),
+			},
+			"resource_akas": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// the URI of the action which handles the matching events
+			"action": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// the filters the events must match - if there are none, all events of the resource are handled
+			"filters": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// the favorite created for the watched resource
+			"favorite_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceTurbotWatchCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	// build map of watch properties
+	input := mapFromResourceData(d, watchProperties)
+
+	watch, err := client.CreateResourceWatch(input)
+	if err != nil {
+		return err
+	}
+
+	// assign the id
+	d.SetId(watch.Turbot.Id)
+	return resourceTurbotWatchRead(d, meta)
+}
+
+func resourceTurbotWatchRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	id := d.Id()
+
+	watch, err := client.ReadResourceWatch(id)
+	if err != nil {
+		if apiClient.NotFoundError(err) {
+			// watch was not found - remove it from the state
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	// set resource_akas property by loading resource and fetching the akas
+	if err := storeAkas(watch.Turbot.ResourceId, "resource_akas", d, meta); err != nil {
+		return err
+	}
+	// assign results back into ResourceData
+	// when importing there is no configured resource, so use the id
+	if d.Get("resource").(string) == "" {
+		d.Set("resource", watch.Turbot.ResourceId)
+	}
+	d.Set("action", watch.Action)
+	d.Set("filters", watch.Filters)
+	d.Set("favorite_id", watch.Turbot.FavoriteId)
+	return nil
+}
+
+func resourceTurbotWatchUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+
+	// build map of watch properties
+	input := mapFromResourceData(d, getWatchUpdateProperties())
+	input["id"] = d.Id()
+	// removing all filters must be passed explicitly
+	if _, ok := input["filters"]; !ok {
+		input["filters"] = []interface{}{}
+	}
+
+	if _, err := client.UpdateResourceWatch(input); err != nil {
+		return err
+	}
+	return resourceTurbotWatchRead(d, meta)
+}
+
+func resourceTurbotWatchDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*apiClient.Client)
+	err := client.DeleteResourceWatch(d.Id())
+	if err != nil && !apiClient.NotFoundError(err) {
+		return err
+	}
+
+	// clear the id to show we have deleted
+	d.SetId("")
+
+	return nil
+}
+
+func resourceTurbotWatchImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceTurbotWatchRead(d, meta); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,99 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"testing"
+)
+
+// test suites
+func TestAccWatch_Basic(t *testing.T) {
+	resourceName := "turbot_watch.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWatchDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWatchConfig(`["notificationType:resource_created"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWatchExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "action", watchActionUri),
+					resource.TestCheckResourceAttr(resourceName, "filters.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "filters.0", "notificationType:resource_created"),
+					resource.TestCheckResourceAttrPair(resourceName, "resource", "turbot_folder.test", "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "favorite_id"),
+				),
+			},
+			{
+				Config: testAccWatchConfig(`["notificationType:resource_created", "notificationType:resource_deleted"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWatchExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "filters.#", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+var watchActionUri = "tmod:@turbot/turbot#/action/types/notify"
+
+// configs
+func testAccWatchConfig(filters string) string {
+	return fmt.Sprintf(`
+resource "turbot_folder" "test" {
+	parent      = "tmod:@turbot/turbot#/"
+	title       = "provider_test_watch"
+	description = "watch test"
+}
+
+resource "turbot_watch" "test" {
+	resource = turbot_folder.test.id
+	action   = "%s"
+	filters  = %s
+}
+`, watchActionUri, filters)
+}
+
+// helper functions
+func testAccCheckWatchExists(resource string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("not found: %s", resource)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no Record ID is set")
+		}
+		client := testAccProvider.Meta().(*apiClient.Client)
+		_, err := client.ReadResourceWatch(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error fetching item with resource %s. %s", resource, err)
+		}
+		return nil
+	}
+}
+
+func testAccCheckWatchDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*apiClient.Client)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "turbot_watch" {
+			_, err := client.ReadResourceWatch(rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("watch still exists")
+			}
+			if !apiClient.NotFoundError(err) {
+				return fmt.Errorf("expected 'not found' error, got %s", err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+)
+
+type operationFunc = func(*schema.ResourceData, interface{}) error
+
+// wrap the CRUD functions of each resource and the read of each data source, so an operation whose requests
+// succeeded only after retrying is logged at its end - this version of the plugin SDK can't return warnings from
+// an apply, so the retries are reported in the log, where they can be seen at TF_LOG=INFO without debug logging
+func addRetryLogging(resources, dataSources map[string]*schema.Resource) {
+	for name, resource := range resources {
+		resource.Create = withRetryLogging(name, "create", resource.Create)
+		resource.Read = withRetryLogging(name, "read", resource.Read)
+		resource.Update = withRetryLogging(name, "update", resource.Update)
+		resource.Delete = withRetryLogging(name, "delete", resource.Delete)
+	}
+	for name, dataSource := range dataSources {
+		dataSource.Read = withRetryLogging("data."+name, "read", dataSource.Read)
+	}
+}
+
+func withRetryLogging(name, operation string, f operationFunc) operationFunc {
+	if f == nil {
+		return nil
+	}
+	return func(d *schema.ResourceData, meta interface{}) error {
+		client, ok := meta.(*apiClient.Client)
+		if !ok {
+			return f(d, meta)
+		}
+		client = client.ForOperation()
+		// the id is only known after a create, and is cleared by a delete
+		id := d.Id()
+		err := f(d, client)
+		if d.Id() != "" {
+			id = d.Id()
+		}
+		client.LogRetries(fmt.Sprintf("%s %s %s", name, operation, id))
+		return err
+	}
+}
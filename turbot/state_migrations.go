@@ -0,0 +1,109 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// a state upgrade converts the state of a resource from one schema version to the next. when a change to the schema
+// of a resource would break existing state (e.g. renaming an attribute or changing its type), write an upgrade and
+// append it to the resource in resourceStateUpgrades - the schema version of a resource is its number of upgrades
+type stateUpgrade struct {
+	// returns the schema the upgrade converts from, given the current schema of the resource - only used to decode
+	// state written by Terraform 0.11. if nil, the current schema is used, which is correct as long as no attributes
+	// have changed
+	schema func(current map[string]*schema.Schema) map[string]*schema.Schema
+	// the state is decoded into the default JSON types (e.g. a list is a []interface{})
+	upgrade schema.StateUpgradeFunc
+}
+
+// the state upgrades of each resource, in order of schema version
+var resourceStateUpgrades = map[string][]stateUpgrade{
+	"turbot_file":                    {parentAkasRemoval},
+	"turbot_folder":                  {parentAkasRemoval},
+	"turbot_google_directory":        {parentAkasRemoval},
+	"turbot_group_profile":           {parentAkasRemoval},
+	"turbot_ldap_directory":          {parentAkasRemoval},
+	"turbot_local_directory":         {parentAkasRemoval},
+	"turbot_local_directory_user":    {parentAkasRemoval},
+	"turbot_local_directory_users":   {parentAkasRemoval},
+	"turbot_mod":                     {parentAkasRemoval},
+	"turbot_mod_registry_credential": {parentAkasRemoval},
+	"turbot_policy_pack":             {policyPackUpgrade},
+	"turbot_profile":                 {parentAkasRemoval},
+	"turbot_resource":                {parentAkasRemoval},
+	"turbot_saml_directory":          {parentAkasRemoval},
+	"turbot_smart_folder":            {parentAkasRemoval},
+	"turbot_smart_folder_policy":     {parentAkasRemoval},
+	"turbot_turbot_directory":        {parentAkasRemoval},
+}
+
+// set the schema version and state upgraders of each resource from resourceStateUpgrades
+func addStateUpgraders(resources map[string]*schema.Resource) {
+	addResourceStateUpgraders(resources, resourceStateUpgrades)
+}
+
+func addResourceStateUpgraders(resources map[string]*schema.Resource, resourceUpgrades map[string][]stateUpgrade) {
+	for name, upgrades := range resourceUpgrades {
+		resource, ok := resources[name]
+		if !ok {
+			panic(fmt.Sprintf("state upgrades are defined for unknown resource %s", name))
+		}
+		resource.SchemaVersion = len(upgrades)
+		resource.StateUpgraders = nil
+		for version, upgrade := range upgrades {
+			priorSchema := resource.Schema
+			if upgrade.schema != nil {
+				priorSchema = upgrade.schema(resource.Schema)
+			}
+			resource.StateUpgraders = append(resource.StateUpgraders, schema.StateUpgrader{
+				Version: version,
+				Type:    (&schema.Resource{Schema: priorSchema}).CoreConfigSchema().ImpliedType(),
+				Upgrade: upgrade.upgrade,
+			})
+		}
+	}
+}
+
+// version 1: parent_akas is removed - the configured parent is resolved to an id when planning instead
+var parentAkasRemoval = stateUpgrade{schema: schemaWithParentAkas, upgrade: removeParentAkas}
+
+// the version 0 schema of a resource, which has the list of the akas of the parent
+func schemaWithParentAkas(current map[string]*schema.Schema) map[string]*schema.Schema {
+	prior := map[string]*schema.Schema{}
+	for name, s := range current {
+		prior[name] = s
+	}
+	prior["parent_akas"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+	return prior
+}
+
+func removeParentAkas(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	delete(rawState, "parent_akas")
+	return rawState, nil
+}
+
+// version 1 of turbot_policy_pack: as well as removing parent_akas, keep_history is added - set it to its default,
+// so existing policy packs do not show a change to it
+var policyPackUpgrade = stateUpgrade{schema: policyPackSchemaV0, upgrade: upgradePolicyPackV0}
+
+func policyPackSchemaV0(current map[string]*schema.Schema) map[string]*schema.Schema {
+	prior := schemaWithParentAkas(current)
+	delete(prior, "keep_history")
+	return prior
+}
+
+func upgradePolicyPackV0(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	rawState, err := removeParentAkas(rawState, meta)
+	if err != nil {
+		return nil, err
+	}
+	if rawState["keep_history"] == nil {
+		rawState["keep_history"] = false
+	}
+	return rawState, nil
+}
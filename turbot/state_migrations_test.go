@@ -0,0 +1,101 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAddResourceStateUpgraders(t *testing.T) {
+	renameUpgrade := stateUpgrade{
+		schema: func(map[string]*schema.Schema) map[string]*schema.Schema {
+			return map[string]*schema.Schema{
+				"old_name": {Type: schema.TypeString, Optional: true},
+			}
+		},
+		upgrade: func(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+			rawState["name"] = rawState["old_name"]
+			delete(rawState, "old_name")
+			return rawState, nil
+		},
+	}
+	noopUpgrade := stateUpgrade{
+		upgrade: func(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+			return rawState, nil
+		},
+	}
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {Type: schema.TypeString, Optional: true},
+		},
+	}
+	resources := map[string]*schema.Resource{"turbot_test": resource}
+
+	addResourceStateUpgraders(resources, map[string][]stateUpgrade{"turbot_test": {renameUpgrade, noopUpgrade}})
+	assert.Equal(t, 2, resource.SchemaVersion)
+	assert.Len(t, resource.StateUpgraders, 2)
+	assert.Equal(t, 0, resource.StateUpgraders[0].Version)
+	assert.Equal(t, 1, resource.StateUpgraders[1].Version)
+	assert.NoError(t, resource.InternalValidate(nil, true))
+
+	// the prior schema of each upgrade is used to decode state of that version
+	assert.True(t, resource.StateUpgraders[0].Type.HasAttribute("old_name"))
+	assert.False(t, resource.StateUpgraders[1].Type.HasAttribute("old_name"))
+	assert.True(t, resource.StateUpgraders[1].Type.HasAttribute("name"))
+
+	state, err := resource.StateUpgraders[0].Upgrade(map[string]interface{}{"id": "1", "old_name": "a"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"id": "1", "name": "a"}, state)
+
+	// adding the upgrades again replaces them
+	addResourceStateUpgraders(resources, map[string][]stateUpgrade{"turbot_test": {renameUpgrade, noopUpgrade}})
+	assert.Len(t, resource.StateUpgraders, 2)
+
+	assert.Panics(t, func() {
+		addResourceStateUpgraders(resources, map[string][]stateUpgrade{"turbot_unknown": {noopUpgrade}})
+	})
+}
+
+func TestParentAkasRemoval(t *testing.T) {
+	resources := Provider().(*schema.Provider).ResourcesMap
+
+	folder := resources["turbot_folder"]
+	assert.Equal(t, 1, folder.SchemaVersion)
+	// version 0 state has parent_akas, which is decoded as a list of strings
+	priorType := folder.StateUpgraders[0].Type
+	assert.True(t, priorType.HasAttribute("parent_akas"))
+	assert.True(t, priorType.AttributeType("parent_akas").IsListType())
+	assert.True(t, priorType.HasAttribute("title"))
+	// the current schema is unchanged
+	_, ok := folder.Schema["parent_akas"]
+	assert.False(t, ok)
+
+	state, err := folder.StateUpgraders[0].Upgrade(map[string]interface{}{
+		"id":          "190233581346752",
+		"parent":      "162167737977850",
+		"parent_akas": []interface{}{"tmod:@turbot/turbot#/"},
+		"title":       "provider_test",
+	}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"id": "190233581346752", "parent": "162167737977850", "title": "provider_test"}, state)
+
+	// every resource with a parent which is resolved when planning can decode version 0 state
+	for name, upgrades := range resourceStateUpgrades {
+		assert.True(t, resources[name].StateUpgraders[0].Type.HasAttribute("parent_akas"), name)
+		assert.Len(t, upgrades, resources[name].SchemaVersion, name)
+	}
+}
+
+func TestPolicyPackUpgrade(t *testing.T) {
+	policyPack := Provider().(*schema.Provider).ResourcesMap["turbot_policy_pack"]
+	priorType := policyPack.StateUpgraders[0].Type
+	assert.True(t, priorType.HasAttribute("parent_akas"))
+	assert.False(t, priorType.HasAttribute("keep_history"))
+
+	state, err := policyPack.StateUpgraders[0].Upgrade(map[string]interface{}{
+		"id":          "190233581346752",
+		"parent_akas": []interface{}{"tmod:@turbot/turbot#/"},
+	}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"id": "190233581346752", "keep_history": false}, state)
+}
@@ -0,0 +1,82 @@
+package turbot
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"log"
+	"sort"
+	"testing"
+)
+
+// the test provider adds this tag to every resource the acceptance tests create, so resources left behind by failed
+// test runs can be found and deleted by the sweepers - resources are only swept if they have the tag, never by title,
+// so resources which were not created by the tests are never deleted
+const testAccSweepTag = "terraform_provider_turbot_acceptance_test"
+
+// run the sweepers with 'make sweep', or 'go test ./turbot -v -sweep=<profile>'
+// Turbot has no regions, so the sweep argument is the name of the credentials profile to use ("default" for the default credentials)
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	// deleting a folder deletes the resources it contains
+	// smart folders cannot be tagged by the provider, so are not swept
+	resource.AddTestSweepers("turbot_folder", &resource.Sweeper{
+		Name: "turbot_folder",
+		F:    sweepResourcesOfType(folderResourceType),
+	})
+}
+
+// create a client for the sweepers - credentials are read in the same way as for the provider
+func sharedClientForProfile(profile string) (*apiClient.Client, error) {
+	config := apiClient.ClientConfig{}
+	if profile != "default" {
+		config.Profile = profile
+	}
+	client, err := apiClient.CreateClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %s", err.Error())
+	}
+	if err := client.Validate(); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func sweepResourcesOfType(resourceType string) func(string) error {
+	return func(profile string) error {
+		client, err := sharedClientForProfile(profile)
+		if err != nil {
+			return err
+		}
+		resources, err := client.ReadResourceList(fmt.Sprintf("resourceType:%s", resourceType), nil)
+		if err != nil {
+			return fmt.Errorf("error listing %s resources: %s", resourceType, err.Error())
+		}
+		var sweep []apiClient.Resource
+		for _, r := range resources {
+			if isTestAccResource(r.Turbot) {
+				sweep = append(sweep, r)
+			}
+		}
+		// delete the most deeply nested resources first, so a parent is never deleted before its children
+		sort.SliceStable(sweep, func(i, j int) bool {
+			return len(sweep[i].Turbot.Path) > len(sweep[j].Turbot.Path)
+		})
+		for _, r := range sweep {
+			log.Printf("[INFO] deleting %s %s (%s)", resourceType, r.Turbot.Id, r.Turbot.Title)
+			if err := client.DeleteResource(r.Turbot.Id); err != nil && !apiClient.NotFoundError(err) {
+				return fmt.Errorf("error deleting %s: %s", r.Turbot.Id, err.Error())
+			}
+		}
+		return nil
+	}
+}
+
+// was the resource created by an acceptance test
+func isTestAccResource(turbot apiClient.TurbotResourceMetadata) bool {
+	_, ok := turbot.Tags[testAccSweepTag]
+	return ok
+}
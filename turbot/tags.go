@@ -0,0 +1,89 @@
+package turbot
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+	"github.com/terraform-providers/terraform-provider-turbot/helpers"
+	"reflect"
+)
+
+// resources have two tags attributes:
+// - tags: the tags set in the config
+// - tags_all: the tags applied in Turbot - the provider default_tags merged with tags, with tags taking precedence
+// Turbot merges the tags in a mutation with the existing tags, so a tag is removed by setting it to null
+
+// the tags to apply to a resource - the default tags merged with the resource tags
+func mergedTags(tags map[string]interface{}, meta interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	for key, value := range meta.(*apiClient.Client).DefaultTags {
+		result[key] = value
+	}
+	for key, value := range tags {
+		result[key] = value
+	}
+	return result
+}
+
+// the tags for a create mutation
+func buildTagsInput(d *schema.ResourceData, meta interface{}) map[string]interface{} {
+	return mergedTags(d.Get("tags").(map[string]interface{}), meta)
+}
+
+// the tags for an update mutation - any tag which was applied but is no longer in the merged tags is set to null
+func buildTagsUpdateInput(d *schema.ResourceData, meta interface{}) map[string]interface{} {
+	tags := buildTagsInput(d, meta)
+	old, _ := d.GetChange("tags_all")
+	applied := old.(map[string]interface{})
+	if len(applied) == 0 {
+		// the state may have been written before tags_all was added
+		old, _ = d.GetChange("tags")
+		applied = old.(map[string]interface{})
+	}
+	for _, key := range helpers.GetOldMapProperties(applied, tags) {
+		tags[key.(string)] = nil
+	}
+	return tags
+}
+
+// for resources whose update mutation does not accept tags, update the tags with a separate mutation
+func updateResourceTags(d *schema.ResourceData, meta interface{}) error {
+	if !d.HasChange("tags") && !d.HasChange("tags_all") {
+		return nil
+	}
+	client := meta.(*apiClient.Client)
+	_, err := client.UpdateResourceTags(d.Id(), buildTagsUpdateInput(d, meta))
+	return err
+}
+
+// the value of the tags attribute for the tags read from Turbot - default tags are excluded,
+// unless they are set in the config or their value has been changed
+func resourceTags(d *schema.ResourceData, turbotTags map[string]interface{}, meta interface{}) map[string]interface{} {
+	configured := d.Get("tags").(map[string]interface{})
+	defaultTags := meta.(*apiClient.Client).DefaultTags
+	result := map[string]interface{}{}
+	for key, value := range turbotTags {
+		if _, ok := configured[key]; !ok && reflect.DeepEqual(defaultTags[key], value) {
+			continue
+		}
+		result[key] = value
+	}
+	return result
+}
+
+// store the tags read from Turbot
+func storeTags(d *schema.ResourceData, turbotTags map[string]interface{}, meta interface{}) {
+	d.Set("tags", resourceTags(d, turbotTags, meta))
+	d.Set("tags_all", turbotTags)
+}
+
+// plan the merged tags, so a change to the provider default_tags updates the resource
+func tagsCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("tags") {
+		return d.SetNewComputed("tags_all")
+	}
+	tags := mergedTags(d.Get("tags").(map[string]interface{}), meta)
+	if d.Id() == "" || !reflect.DeepEqual(tags, d.Get("tags_all").(map[string]interface{})) {
+		return d.SetNew("tags_all", tags)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package turbot
+
+import (
+	"encoding/json"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-turbot/apiClient"
+)
+
+// resources which are Turbot resources have a computed 'turbot' attribute containing the Turbot metadata of the
+// resource, so it can be interpolated without a data source, e.g. turbot_folder.prod.turbot.path
+func turbotMetadataSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// store the metadata in the 'turbot' attribute - the akas are json encoded, as the values of a map must be strings
+func storeTurbotMetadata(d *schema.ResourceData, turbot apiClient.TurbotResourceMetadata) {
+	// a list of strings can always be marshalled
+	akas, _ := json.Marshal(turbot.Akas)
+	d.Set("turbot", map[string]string{
+		"id":               turbot.Id,
+		"akas":             string(akas),
+		"parent_id":        turbot.ParentId,
+		"path":             turbot.Path,
+		"create_timestamp": turbot.CreateTimestamp,
+		"update_timestamp": turbot.UpdateTimestamp,
+		"version_id":       turbot.VersionId,
+	})
+}
+
+// any update may change the metadata (e.g. update_timestamp and version_id), so it is not known until the update is applied
+func turbotMetadataCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || len(d.GetChangedKeysPrefix("")) == 0 {
+		return nil
+	}
+	return d.SetNewComputed("turbot")
+}
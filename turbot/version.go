@@ -0,0 +1,5 @@
+package turbot
+
+// the version of the provider, set when a release is built, e.g.
+// go build -ldflags "-X github.com/terraform-providers/terraform-provider-turbot/turbot.ProviderVersion=1.7.0"
+var ProviderVersion = "dev"